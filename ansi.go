@@ -0,0 +1,176 @@
+package gochoice
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// ansiEscape is the character starting the escape sequences of terminals
+const ansiEscape = '\x1b'
+
+// parseANSI removes the escape sequences from the text, and returns the style set by the SGR sequences, e.g.
+// "\x1b[31m", for each rune of the text returned. The styles only have the colors and attributes set by the
+// sequences, so that they can be applied on top of the style of the theme with overrideStyle.
+// Escape sequences other than SGR sequences are dropped.
+func parseANSI(text string) (string, []tcell.Style) {
+	runes := []rune(text)
+	var stripped []rune
+	var styles []tcell.Style
+	style := tcell.StyleDefault
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != ansiEscape {
+			stripped, styles = append(stripped, runes[i]), append(styles, style)
+			continue
+		}
+		if i+1 >= len(runes) {
+			break
+		}
+		switch runes[i+1] {
+		case '[':
+			// Control Sequence Introducer: parameters followed by a final character between '@' and '~'
+			end := i + 2
+			for end < len(runes) && (runes[end] < '@' || runes[end] > '~') {
+				end++
+			}
+			if end < len(runes) && runes[end] == 'm' {
+				style = applySGR(style, string(runes[i+2:end]))
+			}
+			i = end
+		case ']':
+			// Operating System Command, terminated by BEL or by ESC \
+			end := i + 2
+			for end < len(runes) && runes[end] != '\a' && !(runes[end] == ansiEscape && end+1 < len(runes) && runes[end+1] == '\\') {
+				end++
+			}
+			if end < len(runes) && runes[end] == ansiEscape {
+				end++
+			}
+			i = end
+		default:
+			i++
+		}
+	}
+	return string(stripped), styles
+}
+
+// applySGR returns the style with the parameters of an SGR sequence applied to it, e.g. "1;31" for bold and red
+func applySGR(style tcell.Style, parameters string) tcell.Style {
+	codes := strings.Split(parameters, ";")
+	for i := 0; i < len(codes); i++ {
+		// An empty parameter is the same as 0
+		code, _ := strconv.Atoi(codes[i])
+		switch {
+		case code == 0:
+			style = tcell.StyleDefault
+		case code == 1:
+			style = style.Bold(true)
+		case code == 2:
+			style = style.Dim(true)
+		case code == 3:
+			style = style.Italic(true)
+		case code == 4:
+			style = style.Underline(true)
+		case code == 5:
+			style = style.Blink(true)
+		case code == 7:
+			style = style.Reverse(true)
+		case code == 9:
+			style = style.StrikeThrough(true)
+		case code == 22:
+			style = style.Bold(false).Dim(false)
+		case code == 23:
+			style = style.Italic(false)
+		case code == 24:
+			style = style.Underline(false)
+		case code == 25:
+			style = style.Blink(false)
+		case code == 27:
+			style = style.Reverse(false)
+		case code == 29:
+			style = style.StrikeThrough(false)
+		case code >= 30 && code <= 37:
+			style = style.Foreground(tcell.PaletteColor(code - 30))
+		case code >= 90 && code <= 97:
+			style = style.Foreground(tcell.PaletteColor(code - 90 + 8))
+		case code >= 40 && code <= 47:
+			style = style.Background(tcell.PaletteColor(code - 40))
+		case code >= 100 && code <= 107:
+			style = style.Background(tcell.PaletteColor(code - 100 + 8))
+		case code == 39:
+			style = style.Foreground(tcell.ColorDefault)
+		case code == 49:
+			style = style.Background(tcell.ColorDefault)
+		case code == 38 || code == 48:
+			var color tcell.Color
+			color, i = parseExtendedColor(codes, i+1)
+			if code == 38 {
+				style = style.Foreground(color)
+			} else {
+				style = style.Background(color)
+			}
+		}
+	}
+	return style
+}
+
+// parseExtendedColor parses the color of the parameters following 38 or 48 in an SGR sequence, starting at the
+// given index, which is either 5;n for a color of the 256-color palette or 2;r;g;b for an RGB color.
+// It returns the color, or tcell.ColorDefault if the parameters are invalid, and the index of the last parameter used.
+func parseExtendedColor(codes []string, i int) (tcell.Color, int) {
+	if i >= len(codes) {
+		return tcell.ColorDefault, i
+	}
+	values := make([]int32, 0, 3)
+	switch codes[i] {
+	case "5":
+		if i+1 < len(codes) {
+			n, _ := strconv.Atoi(codes[i+1])
+			return tcell.PaletteColor(n), i + 1
+		}
+	case "2":
+		for j := i + 1; j < len(codes) && len(values) < 3; j++ {
+			n, _ := strconv.Atoi(codes[j])
+			values = append(values, int32(n))
+		}
+		if len(values) == 3 {
+			return tcell.NewRGBColor(values[0], values[1], values[2]), i + 3
+		}
+	}
+	return tcell.ColorDefault, len(codes)
+}
+
+// parseChoicesANSI removes the escape sequences from the values of the choices, and remembers the styles they set
+func parseChoicesANSI(choices []*Choice) {
+	for _, choice := range choices {
+		if strings.ContainsRune(choice.Value, ansiEscape) {
+			choice.Value, choice.ansiStyles = parseANSI(choice.Value)
+		}
+	}
+}
+
+// styleANSI applies the styles of the runes of the text displayed at (x, y) on top of the given style,
+// except for the characters that were drawn with another style, such as those matching the search query
+func styleANSI(screen tcell.Screen, x, y int, text string, styles []tcell.Style, style tcell.Style) {
+	position := 0
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() && position < len(styles) {
+		mainc, combc, cellStyle, _ := screen.GetContent(x, y)
+		if cellStyle == style {
+			screen.SetContent(x, y, mainc, combc, overrideStyle(style, styles[position]))
+		}
+		position += len(graphemes.Runes())
+		x += runewidth.StringWidth(graphemes.Str())
+	}
+}
+
+// OptionANSI interprets the escape sequences setting colors and attributes in the choices, such as those of
+// `git branch --color`, instead of displaying them. The values returned don't include the escape sequences.
+func OptionANSI() func(config *Config) {
+	return func(config *Config) {
+		config.ANSI = true
+	}
+}
@@ -0,0 +1,84 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestParseANSI(t *testing.T) {
+	red := tcell.StyleDefault.Foreground(tcell.PaletteColor(1))
+	scenarios := []struct {
+		name           string
+		text           string
+		expectedText   string
+		expectedStyles []tcell.Style
+	}{
+		{name: "plain", text: "ab", expectedText: "ab", expectedStyles: []tcell.Style{tcell.StyleDefault, tcell.StyleDefault}},
+		{name: "foreground", text: "\x1b[31ma\x1b[0mb", expectedText: "ab", expectedStyles: []tcell.Style{red, tcell.StyleDefault}},
+		{name: "bold-and-bright-background", text: "\x1b[1;101ma", expectedText: "a", expectedStyles: []tcell.Style{tcell.StyleDefault.Bold(true).Background(tcell.PaletteColor(9))}},
+		{name: "256-colors", text: "\x1b[38;5;208ma", expectedText: "a", expectedStyles: []tcell.Style{tcell.StyleDefault.Foreground(tcell.PaletteColor(208))}},
+		{name: "rgb", text: "\x1b[38;2;255;128;0ma", expectedText: "a", expectedStyles: []tcell.Style{tcell.StyleDefault.Foreground(tcell.NewRGBColor(255, 128, 0))}},
+		{name: "reset-without-parameters", text: "\x1b[31ma\x1b[mb", expectedText: "ab", expectedStyles: []tcell.Style{red, tcell.StyleDefault}},
+		{name: "other-sequences", text: "\x1b[2Ka\x1b]8;;https://example.com\x1b\\b\x1b]8;;\ac", expectedText: "abc", expectedStyles: []tcell.Style{tcell.StyleDefault, tcell.StyleDefault, tcell.StyleDefault}},
+		{name: "unterminated", text: "a\x1b[31", expectedText: "a", expectedStyles: []tcell.Style{tcell.StyleDefault}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			text, styles := parseANSI(scenario.text)
+			if text != scenario.expectedText {
+				t.Errorf("expected %q, got %q", scenario.expectedText, text)
+			}
+			if len(styles) != len(scenario.expectedStyles) {
+				t.Fatalf("expected %d styles, got %d", len(scenario.expectedStyles), len(styles))
+			}
+			for i := range styles {
+				if styles[i] != scenario.expectedStyles[i] {
+					t.Errorf("expected the style of rune %d to be %v, got %v", i, scenario.expectedStyles[i], styles[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTruncation_shiftStyles(t *testing.T) {
+	red, blue := tcell.StyleDefault.Foreground(tcell.ColorRed), tcell.StyleDefault.Foreground(tcell.ColorBlue)
+	_, valueTruncation := truncateText("abcdef", 4, TruncateMiddle, "…")
+	styles := valueTruncation.shiftStyles([]tcell.Style{red, red, red, blue, blue, blue})
+	expectedStyles := []tcell.Style{red, red, tcell.StyleDefault, blue}
+	if len(styles) != len(expectedStyles) {
+		t.Fatalf("expected %d styles, got %d", len(expectedStyles), len(styles))
+	}
+	for i := range styles {
+		if styles[i] != expectedStyles[i] {
+			t.Errorf("expected the style of rune %d to be %v, got %v", i, expectedStyles[i], styles[i])
+		}
+	}
+}
+
+func TestPickWithANSI(t *testing.T) {
+	config := defaultConfig
+	OptionANSI()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	go func() {
+		waitForText(t, screen, "feature")
+		// The escape sequences are interpreted instead of being displayed
+		mainc, _, style, _ := screen.GetContent(3, 2)
+		if foreground, _, _ := style.Decompose(); mainc != 'f' || foreground != tcell.PaletteColor(2) {
+			t.Errorf("expected a green f, got %q with %v", mainc, style)
+		}
+		screen.InjectKey(tcell.KeyRune, 'f', tcell.ModNone)
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}()
+	choice, index, err := pick("question", []string{"main", "\x1b[32mfeature\x1b[0m"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "feature" || index != 1 {
+		t.Errorf("expected feature at index 1, got %q at index %d", choice, index)
+	}
+}
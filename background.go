@@ -0,0 +1,182 @@
+package gochoice
+
+import (
+	"errors"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const (
+	// backgroundQueryTimeout is how long to wait for the terminal to answer the query for its background color
+	backgroundQueryTimeout = time.Second
+
+	// backgroundQuery asks for the background color with OSC 11, followed by a request for the primary device
+	// attributes, which all terminals answer, so that terminals not answering OSC 11 don't have to be waited for
+	backgroundQuery = "\x1b]11;?\x1b\\\x1b[c"
+)
+
+var (
+	// backgroundColorReportPattern matches the response of the terminal to OSC 11, whose components have 1 to 4
+	// hexadecimal digits
+	backgroundColorReportPattern = regexp.MustCompile(`\x1b\]11;rgb:([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})/([0-9a-fA-F]{1,4})`)
+
+	// deviceAttributesReportPattern matches the response of the terminal to a request for its primary device attributes
+	deviceAttributesReportPattern = regexp.MustCompile(`\x1b\[\?[\d;]*c`)
+
+	errBackgroundNotReported = errors.New("the terminal didn't report its background color")
+
+	// lightBackground and lightBackgroundDetected are the outcome of detectLightBackground, which is only run once,
+	// since the background of the terminal doesn't change while the process runs
+	lightBackground, lightBackgroundDetected bool
+	detectLightBackgroundOnce                sync.Once
+)
+
+// LightTheme returns a Theme with dark text on a white background, meant for terminals with a light background
+func LightTheme() Theme {
+	base := tcell.StyleDefault.Background(tcell.ColorWhite).Foreground(tcell.ColorBlack)
+	return Theme{
+		Question:    base.Bold(true),
+		Item:        base,
+		Selected:    base.Background(tcell.ColorSilver),
+		Match:       base.Foreground(tcell.ColorGreen).Bold(true),
+		Description: base.Foreground(tcell.ColorGray),
+		Disabled:    base.Foreground(tcell.ColorSilver),
+		Header:      base.Foreground(tcell.ColorNavy).Bold(true),
+		SearchBar:   base,
+		Scrollbar:   base.Foreground(tcell.ColorGray),
+		Preview:     base,
+		HeaderBar:   base,
+		FooterBar:   base.Foreground(tcell.ColorGray),
+		Counter:     base.Foreground(tcell.ColorGray),
+		Border:      base.Foreground(tcell.ColorGray),
+		LineNumber:  base.Foreground(tcell.ColorGray),
+		Spinner:     base.Foreground(tcell.ColorTeal),
+		Placeholder: base.Foreground(tcell.ColorGray),
+		Warning:     base.Foreground(tcell.ColorMaroon).Bold(true),
+		Breadcrumb:  base.Foreground(tcell.ColorGray),
+	}
+}
+
+// applyAutoTheme replaces the theme by LightTheme if the background of the terminal is light,
+// or by DefaultTheme if it is dark. The theme is left as is if the background can't be detected.
+func applyAutoTheme(config *Config) {
+	detectLightBackgroundOnce.Do(func() {
+		lightBackground, lightBackgroundDetected = detectLightBackground()
+	})
+	if !lightBackgroundDetected {
+		return
+	}
+	if lightBackground {
+		config.Theme = config.Theme.withStylesOf(LightTheme())
+	} else {
+		config.Theme = config.Theme.withStylesOf(DefaultTheme())
+	}
+}
+
+// detectLightBackground reports whether the background of the terminal is light, and whether it could be detected,
+// first from the COLORFGBG environment variable, then by asking the terminal
+func detectLightBackground() (bool, bool) {
+	if light, ok := lightBackgroundFromColorFgBg(os.Getenv("COLORFGBG")); ok {
+		return light, true
+	}
+	tty, err := openTty()
+	if err != nil {
+		return false, false
+	}
+	defer tty.Close()
+	if err := tty.Start(); err != nil {
+		return false, false
+	}
+	defer tty.Stop()
+	color, err := queryBackgroundColor(tty)
+	if err != nil {
+		return false, false
+	}
+	return isLightColor(color), true
+}
+
+// lightBackgroundFromColorFgBg reports whether the background set by COLORFGBG, e.g. "0;15", is light, and whether
+// it is set. Its last field is the number of the background color, of which only 7 and 15, white, are light.
+func lightBackgroundFromColorFgBg(colorFgBg string) (bool, bool) {
+	fields := strings.Split(colorFgBg, ";")
+	background, err := strconv.Atoi(fields[len(fields)-1])
+	if len(fields) < 2 || err != nil {
+		return false, false
+	}
+	return background == 7 || background == 15, true
+}
+
+// queryBackgroundColor returns the background color of the terminal, which must be in raw mode
+func queryBackgroundColor(tty tcell.Tty) (tcell.Color, error) {
+	if _, err := tty.Write([]byte(backgroundQuery)); err != nil {
+		return tcell.ColorDefault, err
+	}
+	colors := make(chan tcell.Color, 1)
+	go func() {
+		var input []byte
+		buffer := make([]byte, 64)
+		for {
+			n, err := tty.Read(buffer)
+			input = append(input, buffer[:n]...)
+			// The device attributes are reported last, so the response to OSC 11, if any, has been read by then
+			if deviceAttributesReportPattern.Match(input) {
+				colors <- parseBackgroundColorReport(input)
+				return
+			}
+			if err != nil {
+				colors <- tcell.ColorDefault
+				return
+			}
+		}
+	}()
+	select {
+	case color := <-colors:
+		if color == tcell.ColorDefault {
+			return color, errBackgroundNotReported
+		}
+		return color, nil
+	case <-time.After(backgroundQueryTimeout):
+		// Unblock the read, so that it doesn't consume the input meant for tcell
+		_ = tty.Drain()
+		<-colors
+		return tcell.ColorDefault, errBackgroundNotReported
+	}
+}
+
+// parseBackgroundColorReport returns the color reported by the terminal in response to OSC 11, e.g.
+// "\x1b]11;rgb:ffff/ffff/dddd\x1b\\", or tcell.ColorDefault if the input doesn't contain such a response
+func parseBackgroundColorReport(input []byte) tcell.Color {
+	groups := backgroundColorReportPattern.FindSubmatch(input)
+	if groups == nil {
+		return tcell.ColorDefault
+	}
+	var components [3]int32
+	for i, group := range groups[1:] {
+		value, _ := strconv.ParseUint(string(group), 16, 16)
+		// Each component is scaled from its number of digits to 8 bits, e.g. "ffff" and "f" are both 255
+		components[i] = int32(math.Round(float64(value) * 255 / float64(uint64(1)<<(4*len(group))-1)))
+	}
+	return tcell.NewRGBColor(components[0], components[1], components[2])
+}
+
+// isLightColor reports whether the color is light, i.e. whether dark text is more readable on it than light text
+func isLightColor(color tcell.Color) bool {
+	return brightness(color) > 127.5
+}
+
+// OptionAutoTheme uses LightTheme if the background of the terminal is light, and DefaultTheme if it is dark,
+// so that the prompt is readable on both. The background is detected from the COLORFGBG environment variable,
+// or by asking the terminal with OSC 11. If it can't be detected, the theme is left as is.
+// With NO_COLOR, or GOCHOICE_THEME and OptionEnvOverrides, the theme they set is used instead.
+func OptionAutoTheme() func(config *Config) {
+	return func(config *Config) {
+		config.AutoTheme = true
+	}
+}
@@ -0,0 +1,98 @@
+package gochoice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestLightBackgroundFromColorFgBg(t *testing.T) {
+	scenarios := []struct {
+		colorFgBg     string
+		expectedLight bool
+		expectedOk    bool
+	}{
+		{colorFgBg: "15;0", expectedLight: false, expectedOk: true},
+		{colorFgBg: "0;15", expectedLight: true, expectedOk: true},
+		{colorFgBg: "0;default;7", expectedLight: true, expectedOk: true},
+		{colorFgBg: "15;default", expectedOk: false},
+		{colorFgBg: "", expectedOk: false},
+		{colorFgBg: "15", expectedOk: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.colorFgBg, func(t *testing.T) {
+			light, ok := lightBackgroundFromColorFgBg(scenario.colorFgBg)
+			if light != scenario.expectedLight || ok != scenario.expectedOk {
+				t.Errorf("expected (%v, %v), got (%v, %v)", scenario.expectedLight, scenario.expectedOk, light, ok)
+			}
+		})
+	}
+}
+
+func TestQueryBackgroundColor(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		input         string
+		expectedColor tcell.Color
+		expectedErr   error
+	}{
+		{
+			name:          "four-digits",
+			input:         "\x1b]11;rgb:ffff/ffff/dddd\x1b\\\x1b[?62;22c",
+			expectedColor: tcell.NewRGBColor(255, 255, 221),
+		},
+		{
+			name:          "two-digits-with-bel",
+			input:         "\x1b]11;rgb:28/2a/36\x07\x1b[?1;2c",
+			expectedColor: tcell.NewRGBColor(0x28, 0x2a, 0x36),
+		},
+		{
+			name:          "black",
+			input:         "\x1b]11;rgb:0/0/0\x1b\\\x1b[?62c",
+			expectedColor: tcell.NewRGBColor(0, 0, 0),
+		},
+		{
+			name:        "not-supported",
+			input:       "\x1b[?1;2c",
+			expectedErr: errBackgroundNotReported,
+		},
+		{
+			name:        "no-response",
+			input:       "",
+			expectedErr: errBackgroundNotReported,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			tty := newFakeTty(scenario.input, 80, 24)
+			color, err := queryBackgroundColor(tty)
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected %v, got %v", scenario.expectedErr, err)
+			}
+			if err == nil && color != scenario.expectedColor {
+				t.Errorf("expected %v, got %v", scenario.expectedColor, color)
+			}
+			if output := tty.output.String(); output != backgroundQuery {
+				t.Errorf("expected output %q, got %q", backgroundQuery, output)
+			}
+		})
+	}
+}
+
+func TestIsLightColor(t *testing.T) {
+	scenarios := []struct {
+		color    tcell.Color
+		expected bool
+	}{
+		{color: tcell.NewRGBColor(255, 255, 255), expected: true},
+		{color: tcell.NewHexColor(0xfdf6e3), expected: true},
+		{color: tcell.NewRGBColor(0, 0, 0), expected: false},
+		{color: tcell.NewHexColor(0x282a36), expected: false},
+	}
+	for _, scenario := range scenarios {
+		if isLightColor(scenario.color) != scenario.expected {
+			t.Errorf("expected isLightColor(%v) to be %v", scenario.color, scenario.expected)
+		}
+	}
+}
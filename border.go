@@ -0,0 +1,84 @@
+package gochoice
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// BorderStyle is the set of characters the border drawn around the prompt with OptionBorder is made of
+type BorderStyle int
+
+const (
+	// BorderNone draws no border around the prompt
+	BorderNone BorderStyle = iota
+
+	// BorderSingle draws the border with single lines, e.g. ┌─┐
+	BorderSingle
+
+	// BorderDouble draws the border with double lines, e.g. ╔═╗
+	BorderDouble
+
+	// BorderRounded draws the border with single lines and rounded corners, e.g. ╭─╮
+	BorderRounded
+
+	// BorderASCII draws the border with ASCII characters only, e.g. +-+, for terminals lacking box-drawing characters
+	BorderASCII
+)
+
+// characters returns the characters the border is made of, in the following order: top left corner,
+// top right corner, bottom left corner, bottom right corner, horizontal line and vertical line
+func (style BorderStyle) characters() []rune {
+	switch style {
+	case BorderDouble:
+		return []rune("╔╗╚╝═║")
+	case BorderRounded:
+		return []rune("╭╮╰╯─│")
+	case BorderASCII:
+		return []rune("++++-|")
+	}
+	return []rune("┌┐└┘─│")
+}
+
+// renderBorder draws the border of the box of the given size whose top left corner is at (x, y),
+// with the title embedded in its top side, if any
+func renderBorder(screen tcell.Screen, x, y, width, height int, title string, config *Config) {
+	if width < 2 || height < 2 {
+		return
+	}
+	characters := config.Border.characters()
+	style := config.Theme.Border
+	right, bottom := x+width-1, y+height-1
+	for i := x + 1; i < right; i++ {
+		screen.SetContent(i, y, characters[4], nil, style)
+		screen.SetContent(i, bottom, characters[4], nil, style)
+	}
+	for i := y + 1; i < bottom; i++ {
+		screen.SetContent(x, i, characters[5], nil, style)
+		screen.SetContent(right, i, characters[5], nil, style)
+	}
+	screen.SetContent(x, y, characters[0], nil, style)
+	screen.SetContent(right, y, characters[1], nil, style)
+	screen.SetContent(x, bottom, characters[2], nil, style)
+	screen.SetContent(right, bottom, characters[3], nil, style)
+	// The title is surrounded by spaces, and at least one horizontal line is kept on each side of it
+	if len(title) > 0 && width > 6 {
+		title, _ = truncateText(title, width-6, TruncateEnd, defaultEllipsis)
+		title = " " + title + " "
+		titleRegion := &regionScreen{Screen: screen, x: x + 2, y: y, width: runewidth.StringWidth(title), height: 1}
+		printText(titleRegion, 0, 0, title, config.Theme.Question)
+	}
+}
+
+// OptionBorder draws a border with the given style around the prompt
+func OptionBorder(style BorderStyle) func(config *Config) {
+	return func(config *Config) {
+		config.Border = style
+	}
+}
+
+// OptionBorderTitle embeds the given title in the top side of the border drawn with OptionBorder
+func OptionBorderTitle(title string) func(config *Config) {
+	return func(config *Config) {
+		config.BorderTitle = title
+	}
+}
@@ -0,0 +1,97 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRenderBorder(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		style            BorderStyle
+		title            string
+		expectedTopLine  string
+		expectedSideLine string
+	}{
+		{
+			name:             "single",
+			style:            BorderSingle,
+			expectedTopLine:  "┌──────────┐",
+			expectedSideLine: "│          │",
+		},
+		{
+			name:             "ascii-with-title",
+			style:            BorderASCII,
+			title:            "Fruits",
+			expectedTopLine:  "+- Fruits -+",
+			expectedSideLine: "|          |",
+		},
+		{
+			name:             "rounded-with-title-too-long",
+			style:            BorderRounded,
+			title:            "A long title",
+			expectedTopLine:  "╭─ A lon… ─╮",
+			expectedSideLine: "│          │",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionBorder(scenario.style)(&config)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.SetSize(12, 4)
+			renderBorder(screen, 0, 0, 12, 4, scenario.title, &config)
+			screen.Show()
+			for y, expectedLine := range map[int]string{0: scenario.expectedTopLine, 1: scenario.expectedSideLine} {
+				var line []rune
+				for x := 0; x < 12; x++ {
+					mainc, _, _, _ := screen.GetContent(x, y)
+					line = append(line, mainc)
+				}
+				if string(line) != expectedLine {
+					t.Errorf("expected line %d to be %q, got %q", y, expectedLine, string(line))
+				}
+			}
+		})
+	}
+}
+
+func TestPickWithBorder(t *testing.T) {
+	config := defaultConfig
+	OptionBorder(BorderDouble)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 6)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+	// The prompt is drawn inside the border, so the question starts on the second line
+	scenarios := []struct {
+		x, y         int
+		expectedRune rune
+	}{
+		{x: 0, y: 0, expectedRune: '╔'},
+		{x: 19, y: 5, expectedRune: '╝'},
+		{x: 0, y: 1, expectedRune: '║'},
+		{x: 2, y: 1, expectedRune: 'q'},
+	}
+	for _, scenario := range scenarios {
+		if mainc, _, _, _ := screen.GetContent(scenario.x, scenario.y); mainc != scenario.expectedRune {
+			t.Errorf("expected %q at (%d, %d), got %q", scenario.expectedRune, scenario.x, scenario.y, mainc)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package gochoice
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// breadcrumbSeparator separates the steps of a breadcrumb, e.g. "Cluster ▸ Namespace ▸ Pod"
+const breadcrumbSeparator = " ▸ "
+
+// breadcrumbHeight returns the number of lines of the breadcrumb displayed above the question
+func (config *Config) breadcrumbHeight() int {
+	if len(config.Breadcrumbs) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// questionHeight returns the number of lines of the question, including the breadcrumb displayed above it
+func questionHeight(question string, config *Config) int {
+	return config.breadcrumbHeight() + len(strings.Split(question, "\n"))
+}
+
+// renderBreadcrumb renders the breadcrumb on the first line of the screen. If it is too long to fit in the screen,
+// its start is cut off, so that the last steps, which are the closest to the question, remain visible.
+func renderBreadcrumb(screen tcell.Screen, config *Config) {
+	screenWidth, _ := screen.Size()
+	// The margin of one column is kept on both sides of the line
+	text, _ := truncateText(strings.Join(config.Breadcrumbs, breadcrumbSeparator), screenWidth-2, TruncateStart, defaultEllipsis)
+	printText(screen, 0, 0, " "+text, config.Theme.Breadcrumb)
+}
+
+// OptionBreadcrumbs displays the given steps above the question, separated by arrows, e.g. "Cluster ▸ Namespace ▸ Pod",
+// to show where the prompt stands in a wizard or in nested menus. With PickMenu, the labels of the items leading to
+// the submenu displayed are added to the steps, instead of being displayed in the question.
+func OptionBreadcrumbs(steps ...string) func(config *Config) {
+	return func(config *Config) {
+		config.Breadcrumbs = steps
+	}
+}
@@ -0,0 +1,71 @@
+package gochoice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRenderWithBreadcrumbs(t *testing.T) {
+	config := defaultConfig
+	OptionBreadcrumbs("Cluster", "Namespace", "Pod")(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	choices := newChoices([]string{"a", "b"})
+	choicesByLine := render(screen, "question", choices, &config, &promptState{}, choices[0], "", true, 0, choices)
+	screen.Show()
+	scenarios := []struct {
+		y            int
+		expectedLine string
+	}{
+		// The start of the breadcrumb is cut off, since it doesn't fit in the screen
+		{y: 0, expectedLine: " …▸ Namespace ▸ Pod"},
+		{y: 1, expectedLine: " question"},
+		{y: 2, expectedLine: " > a"},
+	}
+	for _, scenario := range scenarios {
+		var line []rune
+		for x := 0; x < len([]rune(scenario.expectedLine)); x++ {
+			mainc, _, _, _ := screen.GetContent(x, scenario.y)
+			line = append(line, mainc)
+		}
+		if string(line) != scenario.expectedLine {
+			t.Errorf("expected line %d to be %q, got %q", scenario.y, scenario.expectedLine, string(line))
+		}
+	}
+	if choicesByLine[2] != choices[0] {
+		t.Errorf("expected line 2 to be associated with a, got %v", choicesByLine[2])
+	}
+	if height := questionHeight("question", &config); height != 2 {
+		t.Errorf("expected the question to take 2 lines, got %d", height)
+	}
+}
+
+func TestMenuWithBreadcrumbs(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	shared := newSharedScreen(screen)
+	defer shared.stopListening()
+	results := make(chan error)
+	go func() {
+		_, _, err := runMenu(context.Background(), "Menu", newTestMenu(), shared, []Option{OptionBreadcrumbs("App")})
+		results <- err
+	}()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	waitForText(t, screen, "App ▸ Settings")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	waitForText(t, screen, "App ▸ Settings ▸ Display")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	if err := <-results; err != nil {
+		t.Fatal(err.Error())
+	}
+}
@@ -1,164 +1,1020 @@
 package gochoice
 
 import (
+	"context"
 	"errors"
-	"strings"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
 
+const (
+	// doubleClickInterval is the maximum delay between two clicks for them to be considered a double-click
+	doubleClickInterval = 500 * time.Millisecond
+
+	// mouseWheelStep is the number of choices scrolled by each mouse wheel event
+	mouseWheelStep = 1
+)
+
 var (
-	// ErrNoChoiceSelected is the error returned when no choices have been selected.
-	// This can happen when the user quits the application by terminating the process (e.g. CTRL+C)
-	// or by exiting the application through the ESC key or the left arrow key.
+	// ErrNoChoiceSelected is the error matched by both ErrAborted and ErrTimeout with errors.Is,
+	// for callers that don't need to know why no choices have been selected.
 	ErrNoChoiceSelected = errors.New("no choice selected")
 
+	// ErrAborted is the error returned when the user quits the application without selecting a choice,
+	// e.g. with CTRL+C, the ESC key or the left arrow key
+	ErrAborted = fmt.Errorf("aborted: %w", ErrNoChoiceSelected)
+
+	// ErrTimeout is the error returned when the timeout set with OptionTimeout elapses
+	// and there is no choice to resolve the prompt with
+	ErrTimeout = fmt.Errorf("timed out: %w", ErrNoChoiceSelected)
+
+	// ErrScreenInit is the error matched with errors.Is by the errors returned when the screen can't be
+	// initialized, e.g. because there is no terminal. The error of tcell can be retrieved with errors.Unwrap.
+	ErrScreenInit = errors.New("failed to initialize screen")
+
 	// ErrNoChoice is the error returned when there are no choices to pick from
 	ErrNoChoice = errors.New("no choices to choose from")
 
+	// ErrContextCanceled is the error returned when the context passed to PickContext
+	// is canceled or reaches its deadline before a choice has been selected.
+	// The error returned matches it with errors.Is and wraps the error of the context.
+	ErrContextCanceled = errors.New("context canceled before a choice was selected")
+
 	defaultConfig = Config{
-		TextColor:         White.toTcellColor(),
-		BackgroundColor:   Black.toTcellColor(),
-		SelectedTextColor: White.toTcellColor(),
-		SelectedTextBold:  false,
+		Theme:          DefaultTheme(),
+		KeyMap:         DefaultKeyMap(),
+		Mask:           '*',
+		EmptyMessage:   "There are no choices matching your search query",
+		LoadingMessage: "Loading…",
 	}
 )
 
 // Pick prompts the user to choose an option from a list of choices
 func Pick(question string, choicesToPickFrom []string, options ...Option) (string, int, error) {
+	return PickContext(context.Background(), question, choicesToPickFrom, options...)
+}
+
+// PickMultiple prompts the user to choose any number of options from a list of choices.
+// Choices are toggled with the space key and the selection is confirmed with the enter key.
+func PickMultiple(question string, choicesToPickFrom []string, options ...Option) ([]string, []int, error) {
+	config := newConfig(options)
+	config.multiSelect = true
+	return toValuesAndIndices(runPicker(context.Background(), question, newChoices(choicesToPickFrom), config))
+}
+
+// contextCanceledError is the error returned when the context is done before a choice has been selected.
+// It matches ErrContextCanceled and wraps the error of the context.
+type contextCanceledError struct {
+	err error
+}
+
+func (e *contextCanceledError) Error() string {
+	return ErrContextCanceled.Error() + ": " + e.err.Error()
+}
+
+func (e *contextCanceledError) Is(target error) bool {
+	return target == ErrContextCanceled
+}
+
+func (e *contextCanceledError) Unwrap() error {
+	return e.err
+}
+
+// PickContext is like Pick, but the prompt is aborted with ErrContextCanceled
+// as soon as the provided context is done.
+func PickContext(ctx context.Context, question string, choicesToPickFrom []string, options ...Option) (string, int, error) {
+	if len(choicesToPickFrom) == 0 {
+		// Unlike a Picker, whose choices may be set while it is open, there is nothing to wait for
+		return "", 0, ErrNoChoice
+	}
+	return New(question, choicesToPickFrom, options...).RunContext(ctx)
+}
+
+// PickWithScreen is like Pick, but the prompt is displayed on the given screen instead of a newly created one.
+// The screen must already be initialized and is not finalized once a choice has been made, which makes it
+// possible to run the prompt against a tcell.SimulationScreen, e.g. with events queued by SimulateKeys.
+func PickWithScreen(screen tcell.Screen, question string, choicesToPickFrom []string, options ...Option) (string, int, error) {
+	config := newConfig(options)
+	screen.SetStyle(config.Theme.background())
+	return pickContext(context.Background(), question, choicesToPickFrom, screen, config)
+}
+
+func pick(question string, choicesToPickFrom []string, screen tcell.Screen, config *Config) (string, int, error) {
+	return pickContext(context.Background(), question, choicesToPickFrom, screen, config)
+}
+
+func pickContext(ctx context.Context, question string, choicesToPickFrom []string, screen tcell.Screen, config *Config) (string, int, error) {
+	return toValueAndIndex(pickChoices(ctx, question, newChoices(choicesToPickFrom), screen, config))
+}
+
+func pickMultiple(question string, choicesToPickFrom []string, screen tcell.Screen, config *Config) ([]string, []int, error) {
+	config.multiSelect = true
+	return toValuesAndIndices(pickChoices(context.Background(), question, newChoices(choicesToPickFrom), screen, config))
+}
+
+// newConfig returns the default configuration modified by the given options, by NO_COLOR or the background detected
+// with OptionAutoTheme and, with OptionEnvOverrides, by the environment variables
+func newConfig(options []Option) *Config {
 	config := defaultConfig
 	for _, option := range options {
 		option(&config)
 	}
-	screen, err := createScreen()
+	if noColor(os.Getenv) {
+		config.Theme = config.Theme.withStylesOf(MonochromeTheme())
+		config.noColor = true
+	} else if config.AutoTheme {
+		applyAutoTheme(&config)
+	}
+	if config.EnvOverrides {
+		applyEnvOverrides(&config, os.LookupEnv)
+	}
+	return &config
+}
+
+// runPicker prompts the user to choose from the given choices, either on a newly created screen
+// or, if the fallback mode requires it, through a numbered list printed on stderr.
+// The choices selected are echoed once the screen has been finalized if OptionEchoResult is used.
+func runPicker(ctx context.Context, question string, choices []*Choice, config *Config) ([]*Choice, error) {
+	selectedChoices, err := runPrompt(ctx, question, choices, config)
+	if err == nil && config.EchoResult != nil {
+		echoResult(config.EchoResult, question, selectedChoices)
+	}
+	return selectedChoices, err
+}
+
+func runPrompt(ctx context.Context, question string, choices []*Choice, config *Config) ([]*Choice, error) {
+	if choice := autoPickedChoice(choices, config); choice != nil {
+		// There is nothing to choose from, so the prompt isn't even displayed
+		return []*Choice{choice}, nil
+	}
+	if config.useFallback() {
+		if config.loading {
+			// The list can only be printed once all choices are known
+			for update := range config.updates {
+				choices = update(choices)
+			}
+		}
+		return pickWithFallback(ctx, question, choices, config, os.Stdin, os.Stderr)
+	}
+	screen, err := createScreenForConfig(config)
 	if err != nil {
-		return "", 0, err
+		return nil, err
 	}
+	// Deferred calls also run when the event loop panics, so the terminal is restored before the panic is printed
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
-	return pick(question, choicesToPickFrom, screen, &config)
+	screen.SetStyle(config.Theme.background())
+	return pickChoices(ctx, question, choices, screen, config)
 }
 
-func pick(question string, choicesToPickFrom []string, screen tcell.Screen, config *Config) (string, int, error) {
-	if len(choicesToPickFrom) == 0 {
-		return "", 0, ErrNoChoice
+// autoPickedChoice returns the only choice that can be picked with OptionAutoPickSingle, or nil if there are several
+// of them or if the prompt must be displayed anyway, because the validator rejects the choice or because it is
+// dangerous and must be confirmed
+func autoPickedChoice(choices []*Choice, config *Config) *Choice {
+	if !config.AutoPickSingle || config.multiSelect || config.loading {
+		return nil
+	}
+	dedupeChoices(choices, config)
+	choice := uniqueMatch(choices)
+	if choice == nil || choice.Dangerous || len(confirmationMessage(choices, choice, config)) > 0 {
+		return nil
+	}
+	return choice
+}
+
+// toValueAndIndex returns the value and the index of the first choice selected
+func toValueAndIndex(selectedChoices []*Choice, err error) (string, int, error) {
+	if err != nil {
+		return "", 0, err
+	}
+	return selectedChoices[0].Value, selectedChoices[0].Id, nil
+}
+
+// toValuesAndIndices returns the values and the indices of all choices selected
+func toValuesAndIndices(selectedChoices []*Choice, err error) ([]string, []int, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	values := make([]string, 0, len(selectedChoices))
+	indices := make([]int, 0, len(selectedChoices))
+	for _, choice := range selectedChoices {
+		values = append(values, choice.Value)
+		indices = append(indices, choice.Id)
+	}
+	return values, indices, nil
+}
+
+// pickChoices runs the event loop and returns the choices that were selected.
+// Unless config.multiSelect is true, exactly one choice is returned on success.
+func pickChoices(ctx context.Context, question string, choices []*Choice, screen tcell.Screen, config *Config) (selectedChoices []*Choice, err error) {
+	if len(choices) == 0 && config.updates == nil {
+		return nil, ErrNoChoice
+	}
+	events, stopListening := listenToEvents(screen)
+	defer stopListening()
+	state := &promptState{loading: config.loading}
+	if config.noColor {
+		screen = &colorlessScreen{Screen: screen}
+	} else if palette := basicPalette(screen.Colors()); palette != nil {
+		// tcell would map each color to the closest one on its own, which may make the text unreadable
+		state.reverseSelected = selectedBlendsIn(config.Theme, palette)
+		screen = &degradedScreen{Screen: screen, palette: palette}
+	}
+	signals, stopListeningToSignals := listenToSignals(config)
+	defer stopListeningToSignals()
+	if config.Mouse {
+		screen.EnableMouse()
+		defer screen.DisableMouse()
+	}
+	// With padding, centering or a border, the prompt is drawn in a region of the screen
+	var region *regionScreen
+	if config.usesRegion() {
+		region = &regionScreen{Screen: screen, config: config, state: state}
+		screen = region
+		region.fit(question, choices)
+	}
+	if config.State != nil {
+		config.State.restore(question, config)
+	}
+	if config.ANSI {
+		parseChoicesANSI(choices)
+	}
+	deduper := dedupeChoices(choices, config)
+	choices = sortChoices(choices, config)
+	if config.MRU != nil {
+		choices = sortByRecentUse(question, choices, config)
+		defer func() {
+			// Remembering the choices is not worth failing the prompt over
+			for _, choice := range selectedChoices {
+				_ = config.MRU.Add(question, choice.Value)
+			}
+		}()
+	}
+	var selectedChoice *Choice
+	if len(choices) > 0 {
+		selectedChoice = selectDefaultChoice(choices, config)
+	}
+	initialQuery := config.InitialQuery
+	if config.WithoutSearch {
+		initialQuery = ""
+	}
+	searchQuery := newLineEditor(initialQuery)
+	// With vim bindings, the search query can only be typed after the search key has been pressed
+	searching := !config.VimBindings && !config.WithoutSearch
+	visibleChoices := filterChoices(choices, searchQuery.String(), config, state)
+	if len(searchQuery.String()) > 0 {
+		// The default choice may not match the search query
+		selectedChoice = move(visibleChoices, 0)
+	}
+	if config.State != nil {
+		defer func() {
+			config.State.save(question, selectedChoice, searchQuery.String())
+		}()
+	}
+	if config.onClose != nil {
+		defer func() {
+			config.onClose(searchQuery.String())
+		}()
+	}
+	scrollOffset := 0
+	var lastClickedChoice *Choice
+	var lastClickTime time.Time
+	var lastMouseButtons tcell.ButtonMask
+	// The timeout and the countdown are stopped as soon as the user interacts with the prompt
+	var timeout, countdown <-chan time.Time
+	var deadline time.Time
+	if config.Timeout > 0 {
+		timer := time.NewTimer(config.Timeout)
+		defer timer.Stop()
+		timeout, deadline = timer.C, time.Now().Add(config.Timeout)
+		if config.TimeoutCountdown {
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			countdown = ticker.C
+		}
+	}
+	// If the choices are loading, a spinner is displayed until there are no more updates to them
+	updates := config.updates
+	var spinner <-chan time.Time
+	if state.loading {
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		spinner = ticker.C
+	}
+	showingHelp := false
+	// The values of the choices with a URL are written again as hyperlinks once the screen is shown
+	hyperlinks := config.tty != nil && supportsHyperlinks()
+	// statusMessage explains why the last action was refused, or confirms that it succeeded, until the next event
+	statusMessage := ""
+	refuse := func(message string) {
+		statusMessage = message
+		if config.Bell {
+			_ = screen.Beep()
+		}
+	}
+	// confirming is true while the selection, which includes a dangerous choice, waits to be confirmed again,
+	// with confirmingChoice selected
+	confirming := false
+	var confirmingChoice *Choice
+	// canConfirm reports whether the selection can be confirmed with the given choice selected. If it can't,
+	// the reason is displayed, or the user is asked to confirm it again if it includes a dangerous choice.
+	canConfirm := func(choice *Choice) bool {
+		if message := confirmationMessage(choices, choice, config); len(message) > 0 {
+			refuse(message)
+			return false
+		}
+		if isDangerous(choices, choice, config) {
+			confirming, confirmingChoice = true, choice
+			return false
+		}
+		return true
+	}
+	// The choice for which config.OnChange was last called
+	var highlightedChoice *Choice
+	// The preview of the selected choice is computed in the background
+	var previewedChoice *Choice
+	var previewText string
+	var previewDelay <-chan time.Time
+	// Only one preview is computed at a time; if the selection changes in the meantime, the preview of the new
+	// choice is computed once the pending one is received
+	var computingPreview, previewOutdated bool
+	previews := make(chan preview)
+	done := make(chan struct{})
+	defer close(done)
+	// What depends on all the choices is only measured again once they are updated, rather than after every event,
+	// so that handling a keystroke only takes as long as drawing the choices displayed, however many there are.
+	// The choices added to the choices already measured are measured on their own.
+	measureChoices := func(addedChoices []*Choice) {
+		if width := computeLineNumberWidth(addedChoices); config.LineNumbers && width > state.lineNumberWidth {
+			state.lineNumberWidth = width
+		}
+		state.icons = state.icons || hasIcons(addedChoices, config.Theme.Icons)
+	}
+	measureChoices(choices)
+	// pickUniqueMatch is true if the only choice matching the search query typed must be picked with
+	// OptionSelectOnUniqueMatch once the choices have all been filtered
+	pickUniqueMatch := false
+	// startFiltering starts filtering the choices against the search query, which the event loop goes on with between
+	// the events. The first choice matching it is selected once it is found.
+	startFiltering := func() {
+		state.startFiltering(choices, searchQuery.String(), config)
+		if selectedChoice != nil {
+			selectedChoice.Selected = false
+			selectedChoice = nil
+		}
+	}
+	// filterNextChunk filters the next chunk of the choices and, once they have all been filtered,
+	// returns the only choice matching the search query if it must be picked
+	filterNextChunk := func() *Choice {
+		visibleChoices = state.filterNextChunk(config)
+		if state.filter.sorted && !state.filtering() {
+			// The choices were sorted by score once they were all filtered, so the best match is now the first one
+			selectedChoice = selectChoice(selectedChoice, state.filter.first)
+		} else if selectedChoice == nil && state.filter.first != nil {
+			selectedChoice = selectChoice(nil, state.filter.first)
+		}
+		if state.filtering() || !pickUniqueMatch {
+			return nil
+		}
+		pickUniqueMatch = false
+		if choice := uniqueMatch(visibleChoices); choice != nil && canConfirm(choice) {
+			return choice
+		}
+		return nil
 	}
-	var choices []*Choice
-	for i, choice := range choicesToPickFrom {
-		choices = append(choices, &Choice{Id: i, Value: choice, Selected: i == 0})
-	}
-	quit := make(chan struct{})
-	selectedChoice := choices[0]
-	var searchQuery string
-	go func() {
-		for {
-			render(screen, question, choices, config, selectedChoice, searchQuery)
-			ev := screen.PollEvent()
-			switch ev := ev.(type) {
-			case *tcell.EventKey:
-				switch ev.Key() {
-				case tcell.KeyUp:
-					selectedChoice = moveUp(choices, 1)
-				case tcell.KeyDown:
-					selectedChoice = moveDown(choices, 1)
-				case tcell.KeyHome:
-					selectedChoice = moveUp(choices, len(choices))
-				case tcell.KeyEnd:
-					selectedChoice = moveDown(choices, len(choices))
-				case tcell.KeyPgUp:
-					selectedChoice = moveUp(choices, computePageSize(screen, question))
-				case tcell.KeyPgDn:
-					selectedChoice = moveDown(choices, computePageSize(screen, question))
-				case tcell.KeyBackspace, tcell.KeyBackspace2:
-					if len(searchQuery) > 0 {
-						searchQuery = searchQuery[:len(searchQuery)-1]
-						render(screen, question, choices, config, selectedChoice, searchQuery)
-						selectedChoice = moveUp(choices, len(choices))
+	// nextChunk is always ready, so that the event loop filters the next chunk whenever no event is pending
+	nextChunk := make(chan struct{})
+	close(nextChunk)
+	for {
+		if state.filtering() {
+			if choice := filterNextChunk(); choice != nil {
+				return confirm(choices, choice, config)
+			}
+		}
+		if region != nil {
+			region.fit(question, choices)
+		}
+		clampHorizontalOffset(screen, visibleChoices, config, state)
+		selectedChoiceIndex := indexOf(visibleChoices, selectedChoice)
+		scrollOffset = computeOptionsScrollOffset(screen, question, visibleChoices, scrollOffset, selectedChoiceIndex, config, state)
+		if selectedChoiceIndex > 0 && visibleChoices[selectedChoiceIndex-1].header {
+			// Keep the header of the group of the selected choice visible
+			scrollOffset = computeOptionsScrollOffset(screen, question, visibleChoices, scrollOffset, selectedChoiceIndex-1, config, state)
+		}
+		if config.OnChange != nil && selectedChoice != highlightedChoice {
+			highlightedChoice = selectedChoice
+			if selectedChoice != nil {
+				config.OnChange(selectedChoice.Value, selectedChoice.Id)
+			}
+		}
+		displayedQuestion := question
+		if countdown != nil {
+			displayedQuestion = questionWithCountdown(question, time.Until(deadline))
+		}
+		if config.NumberShortcuts {
+			assignShortcuts(visibleChoices, scrollOffset)
+		}
+		choicesByLine := render(screen, displayedQuestion, visibleChoices, config, state, selectedChoice, searchQuery.String(), searching, scrollOffset, choices)
+		if spinner != nil && len(choices) > 0 {
+			// Until the first choices are loaded, the spinner is displayed in place of the choices
+			renderSpinner(screen, searchBarLine(screen, displayedQuestion, config), config, state)
+		}
+		if config.Preview != nil {
+			if selectedChoice != previewedChoice {
+				// Only compute the preview once the selection has stopped changing
+				previewedChoice, previewDelay, previewOutdated = selectedChoice, time.After(previewDebounceDelay), false
+				if selectedChoice == nil {
+					previewText, previewDelay = "", nil
+				}
+			}
+			renderPreview(screen, question, previewText, config)
+		}
+		if confirming {
+			if config.StatusLine {
+				renderStatusLine(screen, dangerConfirmationMessage(config), config.Theme.Warning, config)
+			} else {
+				renderStatusMessage(screen, searchBarLine(screen, displayedQuestion, config), dangerConfirmationMessage(config), config.Theme.Warning)
+			}
+		} else if config.StatusLine {
+			renderStatusLine(screen, statusText(statusMessage, config), config.Theme.Description, config)
+		} else if len(statusMessage) > 0 {
+			renderStatusMessage(screen, searchBarLine(screen, displayedQuestion, config), statusMessage, config.Theme.Description)
+		}
+		if showingHelp {
+			renderHelp(screen, config)
+		}
+		screen.Show()
+		if hyperlinks && !showingHelp {
+			if region != nil {
+				renderHyperlinks(config.tty, screen, choicesByLine, region.x, region.y, config, state)
+			} else {
+				renderHyperlinks(config.tty, screen, choicesByLine, 0, 0, config, state)
+			}
+		}
+		var filtering <-chan struct{}
+		if state.filtering() {
+			filtering = nextChunk
+		}
+		var ev tcell.Event
+		select {
+		case <-filtering:
+			// The next chunk is only filtered once the pending event, if any, has been handled. The goroutines receiving
+			// the events may not have had a chance to run while the previous chunk was filtered, e.g. with a single CPU.
+			runtime.Gosched()
+			select {
+			case ev = <-events:
+			default:
+				continue
+			}
+		case <-ctx.Done():
+			return nil, &contextCanceledError{err: ctx.Err()}
+		case <-signals:
+			return nil, ErrAborted
+		case <-timeout:
+			return timeoutChoices(choices, selectedChoice, config)
+		case <-countdown:
+			continue
+		case <-spinner:
+			state.spinnerFrame++
+			continue
+		case <-previewDelay:
+			previewDelay = nil
+			if computingPreview {
+				previewOutdated = true
+			} else {
+				computingPreview = true
+				go computePreview(previewedChoice, config, previews, done)
+			}
+			continue
+		case result := <-previews:
+			computingPreview = false
+			if result.panicValue != nil {
+				// Panic in the event loop rather than in the goroutine, so that the screen is finalized
+				panic(result.panicValue)
+			}
+			if result.choice == previewedChoice {
+				previewText = result.text
+			} else if previewOutdated {
+				computingPreview = true
+				go computePreview(previewedChoice, config, previews, done)
+			}
+			previewOutdated = false
+			continue
+		case update, ok := <-updates:
+			if !ok {
+				updates, spinner = nil, nil
+				state.loading = false
+				continue
+			}
+			previousChoices := choices
+			choices = update(choices)
+			if sameChoices(choices, previousChoices) {
+				// The choices are unchanged, e.g. only the status changed
+				continue
+			}
+			if len(choices) > len(previousChoices) && sameChoices(choices[:len(previousChoices)], previousChoices) && state.canAddChoices(searchQuery.String(), config) {
+				// Only the choices added, e.g. the next one received by PickFromChannel, are gone through
+				addedChoices := choices[len(previousChoices):]
+				if config.ANSI {
+					parseChoicesANSI(addedChoices)
+				}
+				deduper.add(addedChoices, config)
+				measureChoices(addedChoices)
+				state.addChoices(choices)
+				continue
+			}
+			if config.ANSI {
+				parseChoicesANSI(choices)
+			}
+			deduper = dedupeChoices(choices, config)
+			choices = sortChoices(choices, config)
+			state.lineNumberWidth, state.icons = 0, false
+			measureChoices(choices)
+			state.filterHistory, pickUniqueMatch = nil, false
+			visibleChoices = filterChoices(choices, searchQuery.String(), config, state)
+			// Keep the choice selected before the update, if it is still visible
+			selectedChoice = move(visibleChoices, 0)
+			continue
+		case ev = <-events:
+		}
+		if userInteracted(ev) {
+			timeout, countdown = nil, nil
+			statusMessage = ""
+		}
+		if confirming && userInteracted(ev) {
+			// Any event other than the Confirm key cancels the confirmation, and the Abort key does nothing else
+			confirming = false
+			if ev, ok := ev.(*tcell.EventKey); ok {
+				switch config.KeyMap.actionFor(ev, config.multiSelect, config.tree, config.Grid, false) {
+				case actionConfirm:
+					return confirm(choices, confirmingChoice, config)
+				case actionAbort:
+					continue
+				}
+			}
+		}
+		if showingHelp {
+			// Any key or click closes the help
+			if _, ok := ev.(*tcell.EventKey); ok {
+				showingHelp = false
+				continue
+			}
+			if mouseEvent, ok := ev.(*tcell.EventMouse); ok && mouseEvent.Buttons()&tcell.Button1 != 0 {
+				showingHelp = false
+				lastMouseButtons = mouseEvent.Buttons()
+				continue
+			}
+		}
+		switch ev := ev.(type) {
+		case nil:
+			// The event channel is closed when the screen is finalized
+			return nil, ErrAborted
+		case *tcell.EventKey:
+			typing := searching && config.VimBindings
+			if typing && (ev.Key() == tcell.KeyEnter || ev.Key() == tcell.KeyEscape) {
+				searching = false
+				break
+			}
+			action := config.KeyMap.actionFor(ev, config.multiSelect, config.tree, config.Grid, typing)
+			switch action {
+			case actionEnd, actionConfirm, actionCheckAll, actionUncheckAll, actionInvertChecks:
+				// These act on all the choices matching the search query, rather than on those found so far
+				for state.filtering() {
+					if choice := filterNextChunk(); choice != nil {
+						return confirm(choices, choice, config)
+					}
+				}
+			}
+			switch action {
+			case actionUp:
+				if config.Grid {
+					columns, _ := computeScreenGridColumns(screen, visibleChoices, config, state)
+					selectedChoice = moveInGrid(visibleChoices, -columns)
+				} else {
+					selectedChoice = moveOnce(visibleChoices, selectedChoice, -1, config, state)
+				}
+			case actionDown:
+				if config.Grid {
+					columns, _ := computeScreenGridColumns(screen, visibleChoices, config, state)
+					selectedChoice = moveInGrid(visibleChoices, columns)
+				} else {
+					selectedChoice = moveOnce(visibleChoices, selectedChoice, 1, config, state)
+				}
+			case actionCopy:
+				if selectedChoice == nil {
+					break
+				}
+				if config.tty == nil {
+					refuse("Copying to the clipboard isn't supported by this terminal")
+					break
+				}
+				if err := copyToClipboard(config.tty, selectedChoice.Value); err != nil {
+					refuse("Failed to copy to the clipboard: " + err.Error())
+					break
+				}
+				statusMessage = "Copied to the clipboard"
+			case actionScrollLeft:
+				state.horizontalOffset -= horizontalScrollStep
+			case actionScrollRight:
+				state.horizontalOffset += horizontalScrollStep
+			case actionLeft:
+				selectedChoice = moveOnce(visibleChoices, selectedChoice, -1, config, state)
+			case actionRight:
+				selectedChoice = moveOnce(visibleChoices, selectedChoice, 1, config, state)
+			case actionHome:
+				selectedChoice = moveUp(visibleChoices, selectedChoice, len(visibleChoices))
+			case actionEnd:
+				selectedChoice = moveDown(visibleChoices, selectedChoice, len(visibleChoices))
+			case actionPageUp:
+				if config.Grid {
+					columns, _ := computeScreenGridColumns(screen, visibleChoices, config, state)
+					selectedChoice = moveInGrid(visibleChoices, -columns*computePageSize(screen, question, config))
+				} else {
+					selectedChoice = moveUp(visibleChoices, selectedChoice, computePageSize(screen, question, config))
+				}
+			case actionPageDown:
+				if config.Grid {
+					columns, _ := computeScreenGridColumns(screen, visibleChoices, config, state)
+					selectedChoice = moveInGrid(visibleChoices, columns*computePageSize(screen, question, config))
+				} else {
+					selectedChoice = moveDown(visibleChoices, selectedChoice, computePageSize(screen, question, config))
+				}
+			case actionHalfPageUp:
+				selectedChoice = moveUp(visibleChoices, selectedChoice, computeHalfPageSize(screen, question, config))
+			case actionHalfPageDown:
+				selectedChoice = moveDown(visibleChoices, selectedChoice, computeHalfPageSize(screen, question, config))
+			case actionSearch:
+				searching = !config.WithoutSearch
+			case actionDeleteChar:
+				if searchQuery.deleteBackward() {
+					startFiltering()
+					pickUniqueMatch = false
+				}
+			case actionConfirm:
+				if config.tree && selectedChoice != nil && selectedChoice.branch {
+					selectedChoice.expanded = !selectedChoice.expanded
+					visibleChoices = filterChoices(choices, searchQuery.String(), config, state)
+					break
+				}
+				if selectedChoice == nil && !config.multiSelect {
+					// Nothing matches the search query, which must be edited first
+					break
+				}
+				if canConfirm(selectedChoice) {
+					return confirm(choices, selectedChoice, config)
+				}
+			case actionAbort:
+				// No choices were selected
+				return nil, ErrAborted
+			case actionBack:
+				if config.backAllowed || config.submenu {
+					return nil, errBack
+				}
+			case actionHelp:
+				showingHelp = true
+			case actionSuspend:
+				if config.Suspend {
+					// If the process can't be suspended, the prompt is simply left as it is
+					_ = suspend(screen)
+				}
+			case actionExpand:
+				if selectedChoice == nil || !selectedChoice.branch {
+					if canConfirm(selectedChoice) {
+						return confirm(choices, selectedChoice, config)
 					}
-				case tcell.KeyEnter, tcell.KeyRight:
-					// The current selected choice is already set, so we just quit
-					close(quit)
-					return
-				case tcell.KeyEscape, tcell.KeyCtrlC, tcell.KeyLeft:
-					// No choices were selected, so we'll set selectedChoice to nil and quit
-					selectedChoice = nil
-					close(quit)
-					return
-				case tcell.KeyRune:
-					searchQuery += string(ev.Rune())
-					render(screen, question, choices, config, selectedChoice, searchQuery)
-					selectedChoice = moveUp(choices, len(choices))
-				}
-			case *tcell.EventResize:
-				screen.Sync()
-			}
-		}
-	}()
-	<-quit
+					break
+				}
+				if selectedChoice.expanded {
+					selectedChoice = moveDown(visibleChoices, selectedChoice, 1)
+				} else {
+					selectedChoice.expanded = true
+					visibleChoices = filterChoices(choices, searchQuery.String(), config, state)
+				}
+			case actionCollapse:
+				if selectedChoice != nil && selectedChoice.branch && selectedChoice.expanded {
+					selectedChoice.expanded = false
+					visibleChoices = filterChoices(choices, searchQuery.String(), config, state)
+				} else if selectedChoice != nil && selectedChoice.parent != nil {
+					selectedChoice = selectChoice(selectedChoice, selectedChoice.parent)
+				} else {
+					return nil, ErrAborted
+				}
+			case actionCheckAll:
+				if !setChecked(choices, visibleChoices, func(bool) bool { return true }, config, state) {
+					refuse(maxSelectionsMessage(config))
+				}
+			case actionUncheckAll:
+				setChecked(choices, visibleChoices, func(bool) bool { return false }, config, state)
+			case actionInvertChecks:
+				if !setChecked(choices, visibleChoices, func(checked bool) bool { return !checked }, config, state) {
+					refuse(maxSelectionsMessage(config))
+				}
+			case actionToggleSelect:
+				if selectedChoice == nil {
+					break
+				}
+				if !toggleChoice(choices, selectedChoice, config, state) {
+					refuse(maxSelectionsMessage(config))
+				}
+			case actionNone:
+				if choice, ok := keyShortcutChoice(ev, visibleChoices, choicesByLine, searchQuery.String(), searching, config); ok {
+					if choice == nil || !choice.selectable() {
+						break
+					}
+					selectedChoice = selectChoice(selectedChoice, choice)
+					if config.tree && choice.branch {
+						choice.expanded = !choice.expanded
+						visibleChoices = filterChoices(choices, searchQuery.String(), config, state)
+					} else if config.multiSelect {
+						if !toggleChoice(choices, choice, config, state) {
+							refuse(maxSelectionsMessage(config))
+						}
+					} else if canConfirm(selectedChoice) {
+						return confirm(choices, selectedChoice, config)
+					}
+					break
+				}
+				if ev.Key() == tcell.KeyRune && searching {
+					searchQuery.insert(ev.Rune())
+					startFiltering()
+					pickUniqueMatch = config.SelectOnUniqueMatch && !config.multiSelect
+				} else if ev.Key() == tcell.KeyRune {
+					// The search query isn't being typed, so the rune moves the cursor to the next choice starting with it
+					if choice := jumpToPrefix(visibleChoices, selectedChoice, ev.Rune()); choice != nil {
+						selectedChoice = selectChoice(selectedChoice, choice)
+					}
+				}
+			}
+		case *tcell.EventMouse:
+			buttons := ev.Buttons()
+			pressedButtons := buttons &^ lastMouseButtons
+			lastMouseButtons = buttons
+			switch {
+			case buttons&tcell.WheelUp != 0:
+				selectedChoice = moveUp(visibleChoices, selectedChoice, mouseWheelStep)
+			case buttons&tcell.WheelDown != 0:
+				selectedChoice = moveDown(visibleChoices, selectedChoice, mouseWheelStep)
+			case pressedButtons&tcell.Button1 != 0:
+				x, y := ev.Position()
+				if region != nil {
+					x, y = x-region.x, y-region.y
+				}
+				if y < 0 || y >= len(choicesByLine) || choicesByLine[y] == nil {
+					break
+				}
+				clickedChoice := choicesByLine[y]
+				if config.Grid {
+					columns, columnWidth := computeScreenGridColumns(screen, visibleChoices, config, state)
+					clickedChoice = gridChoiceAt(visibleChoices, clickedChoice, x, columns, columnWidth)
+				}
+				if clickedChoice == nil || !clickedChoice.selectable() {
+					break
+				}
+				if clickedChoice == lastClickedChoice && ev.When().Sub(lastClickTime) < doubleClickInterval {
+					if config.tree && clickedChoice.branch {
+						clickedChoice.expanded = !clickedChoice.expanded
+						visibleChoices = filterChoices(choices, searchQuery.String(), config, state)
+						lastClickedChoice = nil
+						break
+					}
+					selectedChoice = clickedChoice
+					if canConfirm(selectedChoice) {
+						return confirm(choices, selectedChoice, config)
+					}
+					break
+				}
+				selectedChoice = selectChoice(selectedChoice, clickedChoice)
+				lastClickedChoice, lastClickTime = clickedChoice, ev.When()
+			}
+		case *tcell.EventResize:
+			screen.Sync()
+		}
+	}
+}
+
+// confirm returns the choices to return once the user has confirmed their selection
+func confirm(choices []*Choice, selectedChoice *Choice, config *Config) ([]*Choice, error) {
+	if config.multiSelect {
+		return checkedChoices(choices), nil
+	}
 	if selectedChoice == nil {
-		return "", 0, ErrNoChoiceSelected
+		return nil, ErrAborted
 	}
-	return selectedChoice.Value, selectedChoice.Id, nil
+	return []*Choice{selectedChoice}, nil
 }
 
-func computePageSize(screen tcell.Screen, question string) int {
+// indexOf returns the index of the given choice in choices, or 0 if choices doesn't contain it
+func indexOf(choices []*Choice, choiceToFind *Choice) int {
+	if choiceToFind != nil && isDisplayed(choices, choiceToFind) {
+		return choiceToFind.position
+	}
+	for i, choice := range choices {
+		if choice == choiceToFind {
+			return i
+		}
+	}
+	return 0
+}
+
+// isDisplayed reports whether the choice is one of the visible choices, at the position it was given when they
+// were filtered
+func isDisplayed(visibleChoices []*Choice, choice *Choice) bool {
+	return choice.position >= 0 && choice.position < len(visibleChoices) && visibleChoices[choice.position] == choice
+}
+
+// selectChoice selects the given choice, which may be nil, in place of the selected choice, which may be nil too
+func selectChoice(selectedChoice, choiceToSelect *Choice) *Choice {
+	if selectedChoice != nil {
+		selectedChoice.Selected = false
+	}
+	if choiceToSelect != nil {
+		choiceToSelect.Selected = true
+	}
+	return choiceToSelect
+}
+
+// newChoices creates a choice for each value, selecting the first one
+func newChoices(values []string) []*Choice {
+	choices := make([]*Choice, 0, len(values))
+	for i, value := range values {
+		choices = append(choices, &Choice{Id: i, Value: value, Selected: i == 0})
+	}
+	return choices
+}
+
+// selectDefaultChoice selects the choice matching config.DefaultValue or, failing that,
+// config.DefaultIndex. If neither matches a choice or if the default choice cannot be selected,
+// the first choice that can be selected is selected.
+func selectDefaultChoice(choices []*Choice, config *Config) *Choice {
+	defaultChoice := choices[0]
+	if config.DefaultIndex > 0 {
+		for _, choice := range choices {
+			if choice.Id == config.DefaultIndex {
+				defaultChoice = choice
+				break
+			}
+		}
+	}
+	if len(config.DefaultValue) > 0 {
+		for _, choice := range choices {
+			if choice.Value == config.DefaultValue || (len(choice.value) > 0 && choice.value == config.DefaultValue) {
+				defaultChoice = choice
+				break
+			}
+		}
+	}
+	for _, choice := range choices {
+		choice.Selected = choice == defaultChoice
+	}
+	if !defaultChoice.selectable() {
+		return move(choices, 0)
+	}
+	return defaultChoice
+}
+
+// checkedChoices returns all checked choices in their original order.
+// The slice returned is never nil, even if no choices are checked.
+func checkedChoices(choices []*Choice) []*Choice {
+	checked := make([]*Choice, 0)
+	for _, choice := range choices {
+		if choice.Checked {
+			checked = append(checked, choice)
+		}
+	}
+	return checked
+}
+
+func computePageSize(screen tcell.Screen, question string, config *Config) int {
 	_, height := screen.Size()
-	questionLines := len(strings.Split(question, "\n"))
-	if height > questionLines {
-		height -= questionLines + 1
+	// The question and the search bar are always displayed, unless the search is disabled
+	reservedLines := questionHeight(question, config) + config.searchBarHeight()
+	reservedLines += len(textLines(config.Header)) + len(config.footerLines())
+	if len(config.columnHeader) > 0 {
+		reservedLines++
+	}
+	if config.Preview != nil && config.PreviewPosition == PreviewBottom {
+		// The preview is displayed below the options, after a separator
+		reservedLines += computeBottomPreviewHeight(height) + 1
+	}
+	if height >= reservedLines {
+		height -= reservedLines
+	}
+	if config.PageSize > 0 && config.PageSize < height {
+		return config.PageSize
 	}
 	return height
 }
 
+func computeHalfPageSize(screen tcell.Screen, question string, config *Config) int {
+	if halfPageSize := computePageSize(screen, question, config) / 2; halfPageSize > 1 {
+		return halfPageSize
+	}
+	return 1
+}
+
+// move selects the choice that is increment choices away from the selected choice, skipping the choices that
+// can't be selected and stopping at the first and the last choice, and returns it. If no choice is selected,
+// the first choice that can be selected is selected instead. It returns nil if no choice can be selected.
 func move(choices []*Choice, increment int) *Choice {
 	var choicesNotHidden []*Choice
-	selectedChoiceExists := false
+	// The index of the selected choice in choicesNotHidden, or -1 if no choice is selected
+	selectedIndex := -1
 	for _, choice := range choices {
-		if !choice.hidden {
-			choicesNotHidden = append(choicesNotHidden, choice)
-			if choice.Selected {
-				selectedChoiceExists = true
-			}
-		} else {
-			// If we have a hidden choice selected, we need to find the closest one
-			if choice.Selected {
-				choice.Selected = false
+		if choice.selectable() {
+			if choice.Selected && selectedIndex < 0 {
+				selectedIndex = len(choicesNotHidden)
 			}
+			choicesNotHidden = append(choicesNotHidden, choice)
 		}
+		// If we have a hidden or disabled choice selected, we need to find the closest one
+		choice.Selected = false
 	}
 	if len(choicesNotHidden) == 0 {
 		return nil
 	}
-	if !selectedChoiceExists {
-		choicesNotHidden[0].Selected = true
-		return choicesNotHidden[0]
-	}
-	for i, choice := range choicesNotHidden {
-		if choice.Selected {
-			if i+increment < len(choicesNotHidden) && i+increment > 0 { // Between 0 and last choice
-				choicesNotHidden[i].Selected = false
-				choicesNotHidden[i+increment].Selected = true
-				return choicesNotHidden[i+increment]
-			} else if i+increment >= len(choicesNotHidden) { // Higher than last choice
-				choicesNotHidden[i].Selected = false
-				choicesNotHidden[len(choicesNotHidden)-1].Selected = true
-				return choicesNotHidden[len(choicesNotHidden)-1]
-			} else if i+increment <= 0 { // Lower than 0
-				choicesNotHidden[i].Selected = false
-				choicesNotHidden[0].Selected = true
-				return choicesNotHidden[0]
-			}
-			// Choice didn't change, return it
-			return choice
+	newIndex := 0
+	if selectedIndex >= 0 {
+		newIndex = selectedIndex + increment
+		if newIndex >= len(choicesNotHidden) { // Higher than last choice
+			newIndex = len(choicesNotHidden) - 1
+		} else if newIndex < 0 { // Lower than 0
+			newIndex = 0
+		}
+	}
+	choicesNotHidden[newIndex].Selected = true
+	return choicesNotHidden[newIndex]
+}
+
+// moveFrom is like move, but only goes through the choices between the selected choice and the one it selects,
+// rather than through all of them, which matters with hundreds of thousands of choices. It relies on the selected
+// choice being the only one selected, which holds in the prompt, and falls back to move otherwise.
+func moveFrom(choices []*Choice, selectedChoice *Choice, increment int) *Choice {
+	if selectedChoice == nil || !selectedChoice.Selected || !selectedChoice.selectable() {
+		return move(choices, increment)
+	}
+	index := indexOf(choices, selectedChoice)
+	if choices[index] != selectedChoice {
+		return move(choices, increment)
+	}
+	direction, remaining := 1, increment
+	if increment < 0 {
+		direction, remaining = -1, -increment
+	}
+	newIndex := index
+	for i := index + direction; remaining > 0 && i >= 0 && i < len(choices); i += direction {
+		if choices[i].selectable() {
+			newIndex = i
+			remaining--
+		}
+	}
+	selectedChoice.Selected = false
+	choices[newIndex].Selected = true
+	return choices[newIndex]
+}
+
+// moveAround is like moveFrom, but moving down from the last choice that can be selected selects the first one,
+// and moving up from the first choice that can be selected selects the last one
+func moveAround(choices []*Choice, selectedChoice *Choice, increment int, state *promptState) *Choice {
+	first, last := state.selectableBounds(choices)
+	if increment > 0 && last != nil && last.Selected {
+		return selectChoice(last, first)
+	}
+	if increment < 0 && first != nil && first.Selected {
+		return selectChoice(first, last)
+	}
+	return moveFrom(choices, selectedChoice, increment)
+}
+
+// firstSelectable returns the first choice that can be selected, starting from the end if the direction is negative,
+// or nil if none can be selected
+func firstSelectable(choices []*Choice, direction int) *Choice {
+	for i := range choices {
+		if direction < 0 {
+			i = len(choices) - 1 - i
+		}
+		if choices[i].selectable() {
+			return choices[i]
 		}
 	}
-	panic("Something went wrong")
+	return nil
+}
+
+// moveOnce selects the choice right above or below the selected choice, depending on the sign of the increment,
+// going around the edges with OptionWrapAround
+func moveOnce(choices []*Choice, selectedChoice *Choice, increment int, config *Config, state *promptState) *Choice {
+	if config.WrapAround {
+		return moveAround(choices, selectedChoice, increment, state)
+	}
+	return moveFrom(choices, selectedChoice, increment)
 }
 
-func moveUp(choices []*Choice, step int) *Choice {
-	return move(choices, -step)
+func moveUp(choices []*Choice, selectedChoice *Choice, step int) *Choice {
+	return moveFrom(choices, selectedChoice, -step)
 }
 
-func moveDown(choices []*Choice, step int) *Choice {
-	return move(choices, step)
+func moveDown(choices []*Choice, selectedChoice *Choice, step int) *Choice {
+	return moveFrom(choices, selectedChoice, step)
 }
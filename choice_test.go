@@ -1,8 +1,12 @@
 package gochoice
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 )
@@ -14,7 +18,7 @@ func TestPickFirstChoice(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
 	choice, index, _ := pick("question", []string{"A", "B", "C"}, screen, &config)
@@ -33,7 +37,7 @@ func TestPickSecondChoice(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
 	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
@@ -53,7 +57,7 @@ func TestPickThirdChoice(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
 	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
@@ -74,7 +78,7 @@ func TestPickLastChoiceWithEndKey(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyEnd, 0, tcell.ModNone)
 	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
@@ -94,7 +98,7 @@ func TestPickFirstChoiceWithHomeKey(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
 	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
@@ -117,7 +121,7 @@ func TestPickThirdChoiceButWithExtraKeyDownInputs(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
 	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
@@ -141,7 +145,7 @@ func TestPickSecondChoiceButWithMoreComplexKeyCombo(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
 	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
@@ -163,7 +167,7 @@ func TestPickQuit(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
 	_, _, err = pick("question", []string{"A", "B", "C"}, screen, &config)
@@ -179,7 +183,7 @@ func TestPickSearch(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyRune, 'd', tcell.ModNone)
 	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
@@ -202,13 +206,19 @@ func TestPickSearchNoResult(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyRune, 'z', tcell.ModNone)
+	// Enter does nothing while no choices match the search query
 	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
-	_, _, err = pick("question", []string{"john", "doe", "jane"}, screen, &config)
-	if err == nil {
-		t.Fatal("expected error")
+	screen.InjectKey(tcell.KeyBackspace2, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"john", "doe", "jane"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "john" {
+		t.Error("expected john, got", choice)
 	}
 }
 
@@ -219,7 +229,7 @@ func TestPickSearchComplex(t *testing.T) {
 		t.Errorf("encountered error while creating simulation screen: %v", err)
 	}
 	defer screen.Fini()
-	screen.SetStyle(tcell.StyleDefault.Background(config.BackgroundColor))
+	screen.SetStyle(config.Theme.background())
 	screen.Show()
 	screen.InjectKey(tcell.KeyRune, 'j', tcell.ModNone)
 	screen.InjectKey(tcell.KeyRune, 'a', tcell.ModNone)
@@ -240,6 +250,378 @@ func TestPickSearchComplex(t *testing.T) {
 	}
 }
 
+func TestPickMultiple(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choices, indices, err := pickMultiple("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(choices) != 2 || choices[0] != "A" || choices[1] != "C" {
+		t.Error("expected [A C], got", choices)
+	}
+	if len(indices) != 2 || indices[0] != 0 || indices[1] != 2 {
+		t.Error("expected [0 2], got", indices)
+	}
+}
+
+func TestPickMultipleToggleOff(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choices, indices, err := pickMultiple("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(choices) != 0 || len(indices) != 0 {
+		t.Error("expected no choices, got", choices)
+	}
+}
+
+func TestPickMultipleCheckAll(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		keys           []tcell.Key
+		expectedValues []string
+	}{
+		{
+			name:           "check-all",
+			keys:           []tcell.Key{tcell.KeyCtrlA},
+			expectedValues: []string{"apple", "banana", "avocado"},
+		},
+		{
+			name:           "uncheck-all",
+			keys:           []tcell.Key{tcell.KeyCtrlA, tcell.KeyCtrlD},
+			expectedValues: nil,
+		},
+		{
+			name:           "invert",
+			keys:           []tcell.Key{tcell.KeyCtrlI},
+			expectedValues: []string{"apple", "banana", "avocado"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Errorf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.SetStyle(config.Theme.background())
+			screen.Show()
+			for _, key := range scenario.keys {
+				screen.InjectKey(key, 0, tcell.ModNone)
+			}
+			screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+			values, _, err := pickMultiple("question", []string{"apple", "banana", "avocado"}, screen, &config)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if strings.Join(values, ",") != strings.Join(scenario.expectedValues, ",") {
+				t.Errorf("expected %v, got %v", scenario.expectedValues, values)
+			}
+		})
+	}
+}
+
+func TestPickMultipleCheckAllOnlyAffectsVisibleChoices(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	// Check banana, then invert the checks of the choices containing "o", which only checks avocado
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	screen.InjectKeyBytes([]byte("o"))
+	screen.InjectKey(tcell.KeyCtrlI, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	values, _, err := pickMultiple("question", []string{"apple", "banana", "avocado"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if strings.Join(values, ",") != "banana,avocado" {
+		t.Error("expected [banana avocado], got", values)
+	}
+}
+
+func TestPickMultipleQuit(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	_, _, err = pickMultiple("question", []string{"A", "B", "C"}, screen, &config)
+	if !errors.Is(err, ErrAborted) {
+		t.Error("expected ErrAborted, got", err)
+	}
+}
+
+func TestPickContextCanceled(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = pickContext(ctx, "question", []string{"A", "B", "C"}, screen, &config)
+	if !errors.Is(err, ErrContextCanceled) || !errors.Is(err, context.Canceled) {
+		t.Error("expected ErrContextCanceled wrapping context.Canceled, got", err)
+	}
+}
+
+func TestPickContextDeadline(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	_, _, err = pickContext(ctx, "question", []string{"A", "B", "C"}, screen, &config)
+	if !errors.Is(err, ErrContextCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expected ErrContextCanceled wrapping context.DeadlineExceeded, got", err)
+	}
+}
+
+func TestPickWithDefaultIndex(t *testing.T) {
+	config := defaultConfig
+	OptionDefaultIndex(2)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyUp, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, _ := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+}
+
+func TestPickWithDefaultValue(t *testing.T) {
+	config := defaultConfig
+	OptionDefaultIndex(1)(&config)
+	OptionDefaultValue("C")(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, _ := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if choice != "C" {
+		t.Error("expected C, got", choice)
+	}
+	if index != 2 {
+		t.Error("expected 2, got", index)
+	}
+}
+
+func TestPickWithDefaultIndexOutOfRange(t *testing.T) {
+	config := defaultConfig
+	OptionDefaultIndex(10)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, _ := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if choice != "A" {
+		t.Error("expected A, got", choice)
+	}
+}
+
+func TestPickWithMouseClick(t *testing.T) {
+	config := defaultConfig
+	OptionMouse()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	// The first line is the question, so the second choice is on the third line
+	screen.InjectMouse(5, 2, tcell.Button1, tcell.ModNone)
+	screen.InjectMouse(5, 2, tcell.ButtonNone, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+}
+
+func TestPickWithMouseDoubleClick(t *testing.T) {
+	config := defaultConfig
+	OptionMouse()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectMouse(5, 3, tcell.Button1, tcell.ModNone)
+	screen.InjectMouse(5, 3, tcell.ButtonNone, tcell.ModNone)
+	screen.InjectMouse(5, 3, tcell.Button1, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" {
+		t.Error("expected C, got", choice)
+	}
+}
+
+func TestPickWithMouseWheel(t *testing.T) {
+	config := defaultConfig
+	OptionMouse()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectMouse(0, 0, tcell.WheelDown, tcell.ModNone)
+	screen.InjectMouse(0, 0, tcell.WheelDown, tcell.ModNone)
+	screen.InjectMouse(0, 0, tcell.WheelUp, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+}
+
+func TestPickWithMouseClickOnQuestion(t *testing.T) {
+	config := defaultConfig
+	OptionMouse()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectMouse(5, 0, tcell.Button1, tcell.ModNone)
+	screen.InjectMouse(5, 0, tcell.ButtonNone, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "A" {
+		t.Error("expected A, got", choice)
+	}
+}
+
+func TestPickWithScreen(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	var events []tcell.Event
+	// More events than the simulation screen can buffer
+	for i := 0; i < 20; i++ {
+		events = append(events, tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone))
+	}
+	events = append(events, tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	SimulateKeys(screen, events)
+	choice, index, err := PickWithScreen(screen, "question", []string{"A", "B", "C"}, OptionTheme(MonochromeTheme()))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+}
+
+func TestPickWithOnChange(t *testing.T) {
+	config := defaultConfig
+	var highlighted []string
+	OptionOnChange(func(value string, index int) {
+		highlighted = append(highlighted, fmt.Sprintf("%s%d", value, index))
+	})(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	// Moving past the last choice doesn't change the selection
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyUp, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	if _, _, err = pick("question", []string{"A", "B", "C"}, screen, &config); err != nil {
+		t.Fatal(err.Error())
+	}
+	if fmt.Sprint(highlighted) != "[A0 B1 C2 B1]" {
+		t.Error("expected [A0 B1 C2 B1], got", highlighted)
+	}
+}
+
 func createSimulationScreen() (tcell.SimulationScreen, error) {
 	screen := tcell.NewSimulationScreen("UTF-8")
 	if err := screen.Init(); err != nil {
@@ -247,3 +629,297 @@ func createSimulationScreen() (tcell.SimulationScreen, error) {
 	}
 	return screen, nil
 }
+
+func TestMove(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		selectedIndex int
+		increment     int
+		expectedValue string
+	}{
+		{name: "down", selectedIndex: 0, increment: 1, expectedValue: "C"},
+		{name: "up", selectedIndex: 2, increment: -1, expectedValue: "A"},
+		{name: "past-last-choice", selectedIndex: 2, increment: 10, expectedValue: "D"},
+		{name: "past-first-choice", selectedIndex: 2, increment: -10, expectedValue: "A"},
+		{name: "no-choice-selected", selectedIndex: -1, increment: 1, expectedValue: "A"},
+		{name: "disabled-choice-selected", selectedIndex: 1, increment: 0, expectedValue: "A"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			choices := newChoices([]string{"A", "B", "C", "D"})
+			choices[1].Disabled = true
+			for i, choice := range choices {
+				choice.Selected = i == scenario.selectedIndex
+			}
+			selectedChoice := move(choices, scenario.increment)
+			if selectedChoice == nil || selectedChoice.Value != scenario.expectedValue {
+				t.Fatalf("expected %s, got %v", scenario.expectedValue, selectedChoice)
+			}
+			for _, choice := range choices {
+				if choice.Selected != (choice == selectedChoice) {
+					t.Errorf("expected only %s to be selected, but %s has Selected=%v", selectedChoice.Value, choice.Value, choice.Selected)
+				}
+			}
+		})
+	}
+}
+
+func TestMoveAround(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		selectedIndex int
+		increment     int
+		expectedValue string
+	}{
+		{name: "down-from-last-choice", selectedIndex: 3, increment: 1, expectedValue: "B"},
+		{name: "up-from-first-choice", selectedIndex: 1, increment: -1, expectedValue: "D"},
+		{name: "down", selectedIndex: 1, increment: 1, expectedValue: "C"},
+		{name: "up", selectedIndex: 3, increment: -1, expectedValue: "C"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			// The first choice is disabled, so the second choice is the first one that can be selected
+			choices := newChoices([]string{"A", "B", "C", "D"})
+			choices[0].Disabled = true
+			for i, choice := range choices {
+				choice.Selected = i == scenario.selectedIndex
+			}
+			selectedChoice := moveAround(choices, choices[scenario.selectedIndex], scenario.increment, &promptState{})
+			if selectedChoice == nil || selectedChoice.Value != scenario.expectedValue {
+				t.Fatalf("expected %s, got %v", scenario.expectedValue, selectedChoice)
+			}
+			for _, choice := range choices {
+				if choice.Selected != (choice == selectedChoice) {
+					t.Errorf("expected only %s to be selected, but %s has Selected=%v", selectedChoice.Value, choice.Value, choice.Selected)
+				}
+			}
+		})
+	}
+}
+
+func TestMoveFrom(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		selectedIndex int
+		increment     int
+		expectedValue string
+	}{
+		{name: "down", selectedIndex: 0, increment: 1, expectedValue: "C"},
+		{name: "up", selectedIndex: 2, increment: -1, expectedValue: "A"},
+		{name: "past-last-choice", selectedIndex: 2, increment: 10, expectedValue: "D"},
+		{name: "past-first-choice", selectedIndex: 3, increment: -10, expectedValue: "A"},
+		{name: "disabled-choice-selected", selectedIndex: 1, increment: 1, expectedValue: "A"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			choices := withPositions(newChoices([]string{"A", "B", "C", "D"}))
+			choices[1].Disabled = true
+			for i, choice := range choices {
+				choice.Selected = i == scenario.selectedIndex
+			}
+			selectedChoice := moveFrom(choices, choices[scenario.selectedIndex], scenario.increment)
+			if selectedChoice == nil || selectedChoice.Value != scenario.expectedValue {
+				t.Fatalf("expected %s, got %v", scenario.expectedValue, selectedChoice)
+			}
+			for _, choice := range choices {
+				if choice.Selected != (choice == selectedChoice) {
+					t.Errorf("expected only %s to be selected, but %s has Selected=%v", selectedChoice.Value, choice.Value, choice.Selected)
+				}
+			}
+		})
+	}
+}
+
+func TestMoveFromChoiceNotDisplayed(t *testing.T) {
+	choices := withPositions(newChoices([]string{"A", "B", "C"}))
+	// The choice given isn't among the choices, so they are moved through like with move, from A, which is selected
+	hiddenChoice := &Choice{Value: "hidden", position: 1}
+	if selectedChoice := moveFrom(choices, hiddenChoice, 1); selectedChoice != choices[1] {
+		t.Errorf("expected B to be selected, got %v", selectedChoice)
+	}
+}
+
+func TestIndexOf(t *testing.T) {
+	choices := withPositions(newChoices([]string{"A", "B", "C"}))
+	if index := indexOf(choices, choices[2]); index != 2 {
+		t.Errorf("expected 2, got %d", index)
+	}
+	// The position is out of date once the choices have been sorted or filtered without it being updated
+	choices[0], choices[2] = choices[2], choices[0]
+	if index := indexOf(choices, choices[2]); index != 2 {
+		t.Errorf("expected 2, got %d", index)
+	}
+	if index := indexOf(choices, &Choice{Value: "D"}); index != 0 {
+		t.Errorf("expected 0, got %d", index)
+	}
+}
+
+func TestPickWithWrapAround(t *testing.T) {
+	config := defaultConfig
+	OptionWrapAround()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyUp, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" {
+		t.Error("expected C, got", choice)
+	}
+}
+
+func TestMoveWithoutSelectableChoices(t *testing.T) {
+	choices := newChoices([]string{"A", "B"})
+	choices[0].Disabled, choices[1].Disabled = true, true
+	if selectedChoice := move(choices, 1); selectedChoice != nil {
+		t.Error("expected nil, got", selectedChoice)
+	}
+}
+
+func TestPickWithAutoPickSingle(t *testing.T) {
+	// The prompt isn't displayed, so no screen is needed
+	choice, index, err := Pick("question", []string{"only"}, OptionAutoPickSingle())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "only" || index != 0 {
+		t.Errorf("expected only at index 0, got %s at index %d", choice, index)
+	}
+	item, index, err := PickRich("question", []Item{{Label: "A", Disabled: true}, {Label: "B"}}, OptionAutoPickSingle())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "B" || index != 1 {
+		t.Errorf("expected B at index 1, got %s at index %d", item.Label, index)
+	}
+}
+
+func TestAutoPickedChoice(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		items         []Item
+		options       []Option
+		expectedValue string
+	}{
+		{name: "single", items: []Item{{Label: "only"}}, options: []Option{OptionAutoPickSingle()}, expectedValue: "only"},
+		{name: "without-option", items: []Item{{Label: "only"}}},
+		{name: "several", items: []Item{{Label: "A"}, {Label: "B"}}, options: []Option{OptionAutoPickSingle()}},
+		{name: "dangerous", items: []Item{{Label: "only", Dangerous: true}}, options: []Option{OptionAutoPickSingle()}},
+		{
+			name:    "rejected-by-validator",
+			items:   []Item{{Label: "only"}},
+			options: []Option{OptionAutoPickSingle(), OptionValidator(func(string, int) error { return errors.New("not this one") })},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			for _, option := range scenario.options {
+				option(&config)
+			}
+			choice := autoPickedChoice(newChoicesFromItems(scenario.items), &config)
+			if len(scenario.expectedValue) == 0 && choice != nil {
+				t.Errorf("expected the prompt to be displayed, got %s", choice.Value)
+			}
+			if len(scenario.expectedValue) > 0 && (choice == nil || choice.Value != scenario.expectedValue) {
+				t.Errorf("expected %s, got %v", scenario.expectedValue, choice)
+			}
+		})
+	}
+}
+
+// benchmarkChoiceCount is the number of choices the prompt is benchmarked with
+const benchmarkChoiceCount = 1000000
+
+// newBenchmarkValues returns benchmarkChoiceCount values, e.g. "choice number 12345"
+func newBenchmarkValues() []string {
+	values := make([]string, benchmarkChoiceCount)
+	for i := range values {
+		values[i] = fmt.Sprintf("choice number %d", i)
+	}
+	return values
+}
+
+// keystrokeLatencyBudget is how long the prompt may take on average to handle a keystroke with benchmarkChoiceCount
+// choices, so that typing in it doesn't lag
+const keystrokeLatencyBudget = 5 * time.Millisecond
+
+// benchmarkKeystrokes measures how long the prompt takes to handle the i-th keystroke returned by keystroke, once
+// it is displayed with benchmarkChoiceCount choices, leaving out the time it takes to be displayed.
+// It returns the average time it took to handle a keystroke.
+func benchmarkKeystrokes(b *testing.B, config Config, keystroke func(i int) *tcell.EventKey) time.Duration {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		b.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	// OnChange is first called once the prompt is displayed
+	displayed := make(chan struct{}, 1)
+	config.OnChange = func(string, int) {
+		select {
+		case displayed <- struct{}{}:
+		default:
+		}
+	}
+	choices := newChoices(newBenchmarkValues())
+	errs := make(chan error)
+	go func() {
+		_, err := pickChoices(context.Background(), "question", choices, screen, &config)
+		errs <- err
+	}()
+	<-displayed
+	b.ResetTimer()
+	start := time.Now()
+	for i := 0; i < b.N; i++ {
+		screen.PostEventWait(keystroke(i))
+	}
+	latency := time.Since(start) / time.Duration(b.N)
+	b.StopTimer()
+	screen.PostEventWait(tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone))
+	if err := <-errs; err != nil {
+		b.Fatal(err)
+	}
+	return latency
+}
+
+func BenchmarkPickChoicesMoveDown(b *testing.B) {
+	benchmarkKeystrokes(b, defaultConfig, func(int) *tcell.EventKey {
+		return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	})
+}
+
+func BenchmarkPickChoicesPageDown(b *testing.B) {
+	benchmarkKeystrokes(b, defaultConfig, func(int) *tcell.EventKey {
+		return tcell.NewEventKey(tcell.KeyPgDn, 0, tcell.ModNone)
+	})
+}
+
+func BenchmarkPickChoicesRefineSearch(b *testing.B) {
+	config := defaultConfig
+	OptionInitialQuery("choice number 12345")(&config)
+	// A digit is typed, then deleted, so that the search query keeps extending the initial query
+	benchmarkKeystrokes(b, config, func(i int) *tcell.EventKey {
+		if i%2 == 1 {
+			return tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone)
+		}
+		return tcell.NewEventKey(tcell.KeyRune, '6', tcell.ModNone)
+	})
+}
+
+func BenchmarkPickChoicesSearchFromEmptyQuery(b *testing.B) {
+	// The first character typed matches all the choices, which must then all be displayed again once it is deleted
+	latency := benchmarkKeystrokes(b, defaultConfig, func(i int) *tcell.EventKey {
+		if i%2 == 1 {
+			return tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone)
+		}
+		return tcell.NewEventKey(tcell.KeyRune, 'c', tcell.ModNone)
+	})
+	if b.N > 1 && latency > keystrokeLatencyBudget {
+		b.Errorf("expected a keystroke to be handled in less than %v with %d choices, took %v", keystrokeLatencyBudget, benchmarkChoiceCount, latency)
+	}
+}
@@ -0,0 +1,13 @@
+package gochoice
+
+import (
+	"encoding/base64"
+	"io"
+)
+
+// copyToClipboard copies the text to the clipboard of the system with an OSC 52 sequence, which the terminal
+// handles, so that it works over SSH too. Terminals that don't support it ignore the sequence.
+func copyToClipboard(tty io.Writer, text string) error {
+	_, err := io.WriteString(tty, "\x1b]52;c;"+base64.StdEncoding.EncodeToString([]byte(text))+"\a")
+	return err
+}
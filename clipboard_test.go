@@ -0,0 +1,56 @@
+package gochoice
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCopyToClipboard(t *testing.T) {
+	var tty bytes.Buffer
+	if err := copyToClipboard(&tty, "hello"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if expected := "\x1b]52;c;aGVsbG8=\a"; tty.String() != expected {
+		t.Errorf("expected %q, got %q", expected, tty.String())
+	}
+}
+
+func TestPickWithCopy(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		tty             *bytes.Buffer
+		expectedMessage string
+	}{
+		{name: "copied", tty: &bytes.Buffer{}, expectedMessage: "Copied to the clipboard"},
+		{name: "unsupported", tty: nil, expectedMessage: "Copying to the clipboard isn't supported by this terminal"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			if scenario.tty != nil {
+				config.tty = scenario.tty
+			}
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+			screen.InjectKey(tcell.KeyCtrlY, 0, tcell.ModNone)
+			go func() {
+				waitForText(t, screen, scenario.expectedMessage)
+				screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+			}()
+			// Copying the selected choice doesn't pick it
+			if _, _, err := pick("question", []string{"A", "B", "C"}, screen, &config); !errors.Is(err, ErrAborted) {
+				t.Error("expected ErrAborted, got", err)
+			}
+			if scenario.tty != nil && scenario.tty.String() != "\x1b]52;c;Qg==\a" {
+				t.Errorf("expected B to be copied, got %q", scenario.tty.String())
+			}
+		})
+	}
+}
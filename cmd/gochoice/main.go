@@ -0,0 +1,111 @@
+// Command gochoice prompts the user to pick one of the choices passed as arguments, or read from the standard input
+// with one choice per line, and prints the choice selected to the standard output, e.g.
+//
+//	git branch --format='%(refname:short)' | gochoice --question "Which branch?" | xargs git switch
+//
+// It exits with the status 130 if the user aborts the prompt, and 1 if the prompt can't be displayed.
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/TwiN/go-choice"
+)
+
+const (
+	exitError   = 1
+	exitAborted = 130
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run runs the command with the given arguments and returns its exit status
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("gochoice", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: gochoice [flags] [choices...]")
+		fmt.Fprintln(stderr, "Prompts to pick one of the choices, which are read from the standard input if none are given.")
+		flags.PrintDefaults()
+	}
+	question := flags.String("question", "Pick:", "the question displayed above the choices")
+	query := flags.String("query", "", "the search query typed when the prompt opens")
+	fuzzy := flags.Bool("fuzzy", false, "use a fuzzy search instead of a substring search")
+	multi := flags.Bool("multi", false, "allow several choices to be selected with the space key")
+	output := flags.String("output", outputValue, "what to print for each choice selected: value, index or json")
+	delimiter := flags.String("delimiter", "\n", "the separator printed between the choices selected with --multi")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return exitError
+	}
+	if err := validateOutput(*output); err != nil {
+		// The output format is checked before the prompt is displayed, so that the selection isn't lost
+		fmt.Fprintln(stderr, "gochoice:", err)
+		return exitError
+	}
+	choices := flags.Args()
+	if len(choices) == 0 {
+		var err error
+		if choices, err = readChoices(stdin); err != nil {
+			fmt.Fprintln(stderr, "gochoice:", err)
+			return exitError
+		}
+	}
+	if len(choices) == 0 {
+		fmt.Fprintln(stderr, "gochoice:", gochoice.ErrNoChoice)
+		return exitError
+	}
+	options := []gochoice.Option{gochoice.OptionInitialQuery(*query)}
+	if *fuzzy {
+		options = append(options, gochoice.OptionFuzzySearch())
+	}
+	var values []string
+	var indices []int
+	var err error
+	if *multi {
+		values, indices, err = gochoice.PickMultiple(*question, choices, options...)
+	} else {
+		var value string
+		var index int
+		value, index, err = gochoice.Pick(*question, choices, options...)
+		values, indices = []string{value}, []int{index}
+	}
+	if errors.Is(err, gochoice.ErrNoChoiceSelected) {
+		return exitAborted
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "gochoice:", err)
+		return exitError
+	}
+	text, err := formatSelection(values, indices, *output, *delimiter, *multi)
+	if err != nil {
+		fmt.Fprintln(stderr, "gochoice:", err)
+		return exitError
+	}
+	if len(values) > 0 || *output == outputJSON {
+		fmt.Fprintln(stdout, text)
+	}
+	return 0
+}
+
+// readChoices returns the non-empty lines of the reader, which may end with either LF or CRLF
+func readChoices(reader io.Reader) ([]string, error) {
+	var choices []string
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		if line := strings.TrimSuffix(scanner.Text(), "\r"); len(line) > 0 {
+			choices = append(choices, line)
+		}
+	}
+	return choices, scanner.Err()
+}
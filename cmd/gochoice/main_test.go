@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReadChoices(t *testing.T) {
+	choices, err := readChoices(strings.NewReader("production\r\n\nstaging\ntest"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if strings.Join(choices, ",") != "production,staging,test" {
+		t.Error("expected [production staging test], got", choices)
+	}
+}
+
+func TestRunWithoutPrompt(t *testing.T) {
+	scenarios := []struct {
+		name               string
+		args               []string
+		stdin              string
+		expectedStatus     int
+		expectedStderrText string
+	}{
+		{name: "help", args: []string{"--help"}, expectedStatus: 0, expectedStderrText: "Usage: gochoice"},
+		{name: "unknown-flag", args: []string{"--unknown"}, expectedStatus: exitError, expectedStderrText: "flag provided but not defined"},
+		{name: "no-choices", args: nil, stdin: "\n", expectedStatus: exitError, expectedStderrText: "no choices to choose from"},
+		{name: "no-choices-with-multi", args: []string{"--multi"}, expectedStatus: exitError, expectedStderrText: "no choices to choose from"},
+		{name: "invalid-output", args: []string{"--output", "yaml", "a"}, expectedStatus: exitError, expectedStderrText: `invalid output "yaml"`},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			status := run(scenario.args, strings.NewReader(scenario.stdin), &stdout, &stderr)
+			if status != scenario.expectedStatus {
+				t.Errorf("expected status %d, got %d", scenario.expectedStatus, status)
+			}
+			if !strings.Contains(stderr.String(), scenario.expectedStderrText) {
+				t.Errorf("expected %q to be written to stderr, got %q", scenario.expectedStderrText, stderr.String())
+			}
+			if stdout.Len() > 0 {
+				t.Errorf("expected nothing to be written to stdout, got %q", stdout.String())
+			}
+		})
+	}
+}
+
+func TestFormatSelection(t *testing.T) {
+	scenarios := []struct {
+		name         string
+		values       []string
+		indices      []int
+		output       string
+		delimiter    string
+		multi        bool
+		expectedText string
+	}{
+		{name: "value", values: []string{"staging"}, indices: []int{1}, output: outputValue, delimiter: "\n", expectedText: "staging"},
+		{name: "index", values: []string{"staging"}, indices: []int{1}, output: outputIndex, delimiter: "\n", expectedText: "1"},
+		{name: "json", values: []string{"staging"}, indices: []int{1}, output: outputJSON, expectedText: `{"value":"staging","index":1}`},
+		{name: "multi-value", values: []string{"a", "c"}, indices: []int{0, 2}, output: outputValue, delimiter: ",", multi: true, expectedText: "a,c"},
+		{name: "multi-index", values: []string{"a", "c"}, indices: []int{0, 2}, output: outputIndex, delimiter: " ", multi: true, expectedText: "0 2"},
+		{name: "multi-json", values: []string{"a", "c"}, indices: []int{0, 2}, output: outputJSON, multi: true, expectedText: `[{"value":"a","index":0},{"value":"c","index":2}]`},
+		{name: "multi-json-empty", output: outputJSON, multi: true, expectedText: `[]`},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			text, err := formatSelection(scenario.values, scenario.indices, scenario.output, scenario.delimiter, scenario.multi)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if text != scenario.expectedText {
+				t.Errorf("expected %q, got %q", scenario.expectedText, text)
+			}
+		})
+	}
+	if _, err := formatSelection([]string{"a"}, []int{0}, "yaml", "\n", false); err == nil {
+		t.Error("expected an error for an invalid output")
+	}
+}
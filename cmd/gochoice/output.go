@@ -0,0 +1,59 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// The formats of the output, set with --output
+const (
+	outputValue = "value"
+	outputIndex = "index"
+	outputJSON  = "json"
+)
+
+// selection is a choice selected, as printed with --output json
+type selection struct {
+	Value string `json:"value"`
+	Index int    `json:"index"`
+}
+
+// formatSelection returns the text printed for the choices selected, in the given output format. The values and the
+// indices are separated by the delimiter, while the JSON output is an object, or an array of objects if multi is true.
+func formatSelection(values []string, indices []int, output, delimiter string, multi bool) (string, error) {
+	switch output {
+	case outputValue:
+		return strings.Join(values, delimiter), nil
+	case outputIndex:
+		texts := make([]string, 0, len(indices))
+		for _, index := range indices {
+			texts = append(texts, strconv.Itoa(index))
+		}
+		return strings.Join(texts, delimiter), nil
+	case outputJSON:
+		selections := make([]selection, 0, len(values))
+		for i, value := range values {
+			selections = append(selections, selection{Value: value, Index: indices[i]})
+		}
+		var data []byte
+		var err error
+		if multi {
+			data, err = json.Marshal(selections)
+		} else {
+			data, err = json.Marshal(selections[0])
+		}
+		return string(data), err
+	}
+	return "", validateOutput(output)
+}
+
+// validateOutput returns an error if the output format isn't one of the formats supported by --output
+func validateOutput(output string) error {
+	switch output {
+	case outputValue, outputIndex, outputJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid output %q, expected %s, %s or %s", output, outputValue, outputIndex, outputJSON)
+}
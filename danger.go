@@ -0,0 +1,31 @@
+package gochoice
+
+import "fmt"
+
+// isDangerous reports whether confirming the selection with the given choice selected would pick a dangerous choice,
+// in which case the user must confirm it again
+func isDangerous(choices []*Choice, selectedChoice *Choice, config *Config) bool {
+	if !config.multiSelect {
+		return selectedChoice != nil && selectedChoice.Dangerous
+	}
+	for _, choice := range checkedChoices(choices) {
+		if choice.Dangerous {
+			return true
+		}
+	}
+	return false
+}
+
+// dangerConfirmationMessage returns the message asking the user to confirm the selection of a dangerous choice,
+// e.g. "Press Enter again to confirm · Esc to cancel"
+func dangerConfirmationMessage(config *Config) string {
+	confirmKey := "Enter"
+	if len(config.KeyMap.Confirm) > 0 {
+		confirmKey = keyName(config.KeyMap.Confirm[0])
+	}
+	message := fmt.Sprintf("Press %s again to confirm", confirmKey)
+	if len(config.KeyMap.Abort) > 0 {
+		message += keyHintSeparator + keyName(config.KeyMap.Abort[0]) + " to cancel"
+	}
+	return message
+}
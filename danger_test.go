@@ -0,0 +1,69 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickRichWithDangerousItem(t *testing.T) {
+	items := []Item{{Label: "Delete the cluster", Dangerous: true}, {Label: "Keep the cluster"}}
+	scenarios := []struct {
+		name          string
+		keys          []tcell.Key
+		expectedIndex int
+	}{
+		{name: "confirmed", keys: []tcell.Key{tcell.KeyEnter}, expectedIndex: 0},
+		{name: "canceled", keys: []tcell.Key{tcell.KeyEscape, tcell.KeyDown, tcell.KeyEnter}, expectedIndex: 1},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.SetStyle(config.Theme.background())
+			screen.Show()
+			type result struct {
+				index int
+				err   error
+			}
+			results := make(chan result)
+			go func() {
+				_, index, err := pickRich("question", items, screen, &config)
+				results <- result{index, err}
+			}()
+			screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+			waitForText(t, screen, "Press Enter again to confirm · Esc to cancel")
+			for _, key := range scenario.keys {
+				screen.InjectKey(key, 0, tcell.ModNone)
+			}
+			r := <-results
+			if r.err != nil {
+				t.Fatal(r.err.Error())
+			}
+			if r.index != scenario.expectedIndex {
+				t.Errorf("expected %d, got %d", scenario.expectedIndex, r.index)
+			}
+		})
+	}
+}
+
+func TestIsDangerous(t *testing.T) {
+	config := defaultConfig
+	choices := newChoicesFromItems([]Item{{Label: "a"}, {Label: "b", Dangerous: true}})
+	if isDangerous(choices, choices[0], &config) || !isDangerous(choices, choices[1], &config) {
+		t.Error("expected only b to be dangerous")
+	}
+	config.multiSelect = true
+	choices[0].Checked = true
+	if isDangerous(choices, choices[1], &config) {
+		t.Error("expected the selection not to be dangerous while b isn't checked")
+	}
+	choices[1].Checked = true
+	if !isDangerous(choices, choices[0], &config) {
+		t.Error("expected the selection to be dangerous once b is checked")
+	}
+}
@@ -0,0 +1,60 @@
+package gochoice
+
+// dedupeChoices marks the choices whose value is the same as the value of a previous choice of the same group as
+// duplicates, which aren't displayed, and remembers the indices of all the choices with that value in the first one.
+// The choices are marked again from scratch, and the deduper returned marks the choices added after them.
+func dedupeChoices(choices []*Choice, config *Config) *deduper {
+	deduper := &deduper{firstChoiceByValue: make(map[string]*Choice)}
+	deduper.add(choices, config)
+	return deduper
+}
+
+// deduper marks the choices added to the choices already deduplicated, e.g. while they are received by
+// PickFromChannel, without going through all of them again
+type deduper struct {
+	// firstChoiceByValue is the first choice with each value in the last group of choices
+	firstChoiceByValue map[string]*Choice
+}
+
+// add marks the choices that are duplicates of a previous choice, including the choices added before them
+func (deduper *deduper) add(choices []*Choice, config *Config) {
+	if !config.Dedupe || config.tree {
+		return
+	}
+	for _, choice := range choices {
+		choice.duplicate, choice.indices = false, nil
+		if choice.header {
+			// Choices with the same value in different groups stand for different things
+			deduper.firstChoiceByValue = make(map[string]*Choice)
+			continue
+		}
+		firstChoice, ok := deduper.firstChoiceByValue[choice.Value]
+		if !ok {
+			deduper.firstChoiceByValue[choice.Value] = choice
+			continue
+		}
+		choice.duplicate, choice.Selected, choice.Checked = true, false, false
+		if firstChoice.indices == nil {
+			firstChoice.indices = []int{firstChoice.Id}
+		}
+		firstChoice.indices = append(firstChoice.indices, choice.Id)
+	}
+}
+
+// allIndices returns the indices of all the choices the given choice stands for, which, with OptionDedupe, includes
+// the indices of its duplicates
+func (choice *Choice) allIndices() []int {
+	if choice.indices != nil {
+		return choice.indices
+	}
+	return []int{choice.Id}
+}
+
+// OptionDedupe displays the choices with the same value only once, e.g. the lines of a history file. The index
+// returned is the index of the first of them, and PickDetailed returns the indices of all of them.
+// Choices under different headers aren't considered duplicates of each other.
+func OptionDedupe() func(config *Config) {
+	return func(config *Config) {
+		config.Dedupe = true
+	}
+}
@@ -0,0 +1,49 @@
+package gochoice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDedupeChoices(t *testing.T) {
+	config := defaultConfig
+	OptionDedupe()(&config)
+	choices := newChoices([]string{"a", "b", "a", "c", "a", "b"})
+	dedupeChoices(choices, &config)
+	visibleChoices := filterChoices(choices, "", &config, &promptState{})
+	var values []string
+	for _, choice := range visibleChoices {
+		values = append(values, choice.Value)
+	}
+	if !reflect.DeepEqual(values, []string{"a", "b", "c"}) {
+		t.Errorf("expected [a b c], got %v", values)
+	}
+	if indices := choices[0].allIndices(); !reflect.DeepEqual(indices, []int{0, 2, 4}) {
+		t.Errorf("expected the indices of a to be [0 2 4], got %v", indices)
+	}
+	if indices := choices[3].allIndices(); !reflect.DeepEqual(indices, []int{3}) {
+		t.Errorf("expected the indices of c to be [3], got %v", indices)
+	}
+	// Choices added later are deduplicated too
+	choices = appendChoice("c")(choices)
+	dedupeChoices(choices, &config)
+	if indices := choices[3].allIndices(); !reflect.DeepEqual(indices, []int{3, 6}) {
+		t.Errorf("expected the indices of c to be [3 6], got %v", indices)
+	}
+}
+
+func TestDedupeChoicesWithinGroups(t *testing.T) {
+	config := defaultConfig
+	OptionDedupe()(&config)
+	choices := newChoicesFromGroups([]Group{
+		{Name: "Local", Items: []Item{{Label: "main"}, {Label: "main"}}},
+		{Name: "Remote", Items: []Item{{Label: "main"}}},
+	})
+	dedupeChoices(choices, &config)
+	if !choices[2].duplicate {
+		t.Error("expected the second main of the same group to be a duplicate")
+	}
+	if choices[4].duplicate {
+		t.Error("expected main not to be a duplicate of the main of another group")
+	}
+}
@@ -0,0 +1,97 @@
+package gochoice
+
+import (
+	"math"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// minimumContrast is the smallest difference of brightness, out of 255, between the foreground and the background
+// of a style degraded to the basic colors for its text to remain readable
+const minimumContrast = 32
+
+// basicPalette returns the basic colors a terminal supporting the given number of colors can display, i.e. the 8 or
+// 16 ANSI colors, or nil if it supports 256 colors or more, or too few colors to display any of them
+func basicPalette(colors int) []tcell.Color {
+	if colors < 8 || colors >= 256 {
+		return nil
+	}
+	if colors > 16 {
+		colors = 16
+	}
+	palette := make([]tcell.Color, 0, colors)
+	for i := 0; i < colors; i++ {
+		palette = append(palette, tcell.ColorBlack+tcell.Color(i))
+	}
+	return palette
+}
+
+// nearestBasicColor returns the color of the palette closest to the given color, or the color itself if it is
+// the default color or already one of the palette
+func nearestBasicColor(color tcell.Color, palette []tcell.Color) tcell.Color {
+	if !color.Valid() || (!color.IsRGB() && color < tcell.ColorBlack+tcell.Color(len(palette))) {
+		return color
+	}
+	return tcell.FindColor(color, palette)
+}
+
+// degradeStyle maps the colors of the style to the closest colors of the palette. Colors that are far enough apart
+// may still become the same, or almost, in which case the foreground is replaced by black or white, whichever is
+// readable on the background.
+func degradeStyle(style tcell.Style, palette []tcell.Color) tcell.Style {
+	foreground, background, attributes := style.Decompose()
+	degradedForeground, degradedBackground := nearestBasicColor(foreground, palette), nearestBasicColor(background, palette)
+	if degradedForeground.Valid() && degradedBackground.Valid() && brightnessDifference(degradedForeground, degradedBackground) < minimumContrast {
+		degradedForeground = tcell.ColorWhite
+		if isLightColor(degradedBackground) {
+			degradedForeground = tcell.ColorBlack
+		}
+		if degradedForeground == tcell.ColorWhite && len(palette) < 16 {
+			// The 8 colors don't include white, whose closest color is silver
+			degradedForeground = tcell.ColorSilver
+		}
+	}
+	return tcell.StyleDefault.Foreground(degradedForeground).Background(degradedBackground).Attributes(attributes)
+}
+
+// brightnessDifference returns the difference of brightness, out of 255, between the two colors
+func brightnessDifference(a, b tcell.Color) float64 {
+	return math.Abs(brightness(a) - brightness(b))
+}
+
+// brightness returns the perceived brightness of the color, out of 255
+func brightness(color tcell.Color) float64 {
+	r, g, b := color.RGB()
+	return 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+}
+
+// selectedBlendsIn reports whether the selected choice would look like the others once the colors of the theme are
+// mapped to the closest colors of the palette, e.g. because its background was a slightly lighter shade of theirs,
+// in which case it is displayed in reverse video
+func selectedBlendsIn(theme Theme, palette []tcell.Color) bool {
+	return theme.Selected != theme.Item && degradeStyle(theme.Selected, palette) == degradeStyle(theme.Item, palette)
+}
+
+// degradedScreen is a screen drawing with the colors of a palette, used on terminals supporting only the basic colors.
+// The colors of every style, be it one of the theme, of a choice, e.g. Item.Style, or of OptionANSI, are mapped
+// to the closest colors of the palette.
+type degradedScreen struct {
+	tcell.Screen
+	palette []tcell.Color
+}
+
+func (screen *degradedScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	screen.Screen.SetContent(x, y, mainc, combc, degradeStyle(style, screen.palette))
+}
+
+func (screen *degradedScreen) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	screen.Screen.SetCell(x, y, degradeStyle(style, screen.palette), ch...)
+}
+
+func (screen *degradedScreen) Fill(r rune, style tcell.Style) {
+	screen.Screen.Fill(r, degradeStyle(style, screen.palette))
+}
+
+func (screen *degradedScreen) SetStyle(style tcell.Style) {
+	screen.Screen.SetStyle(degradeStyle(style, screen.palette))
+}
@@ -0,0 +1,146 @@
+package gochoice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestBasicPalette(t *testing.T) {
+	scenarios := []struct {
+		colors         int
+		expectedLength int
+	}{
+		{colors: 0, expectedLength: 0},
+		{colors: 2, expectedLength: 0},
+		{colors: 8, expectedLength: 8},
+		{colors: 16, expectedLength: 16},
+		{colors: 88, expectedLength: 16},
+		{colors: 256, expectedLength: 0},
+		{colors: 1 << 24, expectedLength: 0},
+	}
+	for _, scenario := range scenarios {
+		t.Run(fmt.Sprint(scenario.colors), func(t *testing.T) {
+			palette := basicPalette(scenario.colors)
+			if len(palette) != scenario.expectedLength {
+				t.Fatalf("expected %d colors, got %d", scenario.expectedLength, len(palette))
+			}
+			if len(palette) > 0 && (palette[0] != tcell.ColorBlack || palette[len(palette)-1] != tcell.ColorBlack+tcell.Color(len(palette)-1)) {
+				t.Error("expected the palette to start with black and hold the basic colors in order, got", palette)
+			}
+		})
+	}
+}
+
+func TestNearestBasicColor(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		color    tcell.Color
+		colors   int
+		expected tcell.Color
+	}{
+		{name: "default", color: tcell.ColorDefault, colors: 8, expected: tcell.ColorDefault},
+		{name: "basic", color: tcell.ColorRed, colors: 16, expected: tcell.ColorRed},
+		{name: "bright-in-8-colors", color: tcell.ColorRed, colors: 8, expected: tcell.ColorMaroon},
+		{name: "rgb", color: tcell.NewHexColor(0xff0000), colors: 16, expected: tcell.ColorRed},
+		{name: "256-colors", color: tcell.PaletteColor(196), colors: 16, expected: tcell.ColorRed},
+		{name: "dark-rgb", color: tcell.NewHexColor(0x002b36), colors: 16, expected: tcell.ColorBlack},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if color := nearestBasicColor(scenario.color, basicPalette(scenario.colors)); color != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, color)
+			}
+		})
+	}
+}
+
+func TestDegradeStyleKeepsContrast(t *testing.T) {
+	// Both colors are closest to black
+	style := tcell.StyleDefault.Foreground(tcell.NewHexColor(0x1e1f29)).Background(tcell.NewHexColor(0x282a36)).Bold(true)
+	foreground, background, attributes := degradeStyle(style, basicPalette(16)).Decompose()
+	if foreground != tcell.ColorWhite || background != tcell.ColorBlack || attributes != tcell.AttrBold {
+		t.Errorf("expected bold white on black, got %v on %v with %v", foreground, background, attributes)
+	}
+	foreground, _, _ = degradeStyle(style, basicPalette(8)).Decompose()
+	if foreground != tcell.ColorSilver {
+		t.Errorf("expected silver, since white isn't one of the 8 colors, got %v", foreground)
+	}
+}
+
+func TestDegradeStyleKeepsThemesLegible(t *testing.T) {
+	themes := map[string]Theme{
+		"default":    DefaultTheme(),
+		"solarized":  SolarizedTheme(),
+		"dracula":    DraculaTheme(),
+		"light":      LightTheme(),
+		"monochrome": MonochromeTheme(),
+	}
+	for name, theme := range themes {
+		for _, colors := range []int{8, 16} {
+			t.Run(fmt.Sprintf("%s-%d", name, colors), func(t *testing.T) {
+				palette := basicPalette(colors)
+				for i, style := range theme.styles() {
+					foreground, background, _ := degradeStyle(*style, palette).Decompose()
+					for _, color := range []tcell.Color{foreground, background} {
+						if color.Valid() && (color.IsRGB() || color >= tcell.ColorBlack+tcell.Color(colors)) {
+							t.Errorf("expected style %d to only use the %d basic colors, got %v", i, colors, color)
+						}
+					}
+					if foreground.Valid() && background.Valid() && brightnessDifference(foreground, background) < minimumContrast {
+						t.Errorf("expected style %d to be readable, got %v on %v", i, foreground, background)
+					}
+				}
+				selected := theme.Selected
+				if selectedBlendsIn(theme, palette) {
+					selected = selected.Reverse(true)
+				}
+				if theme.Selected != theme.Item && degradeStyle(selected, palette) == degradeStyle(theme.Item, palette) {
+					t.Error("expected the selected choice to look different from the others")
+				}
+			})
+		}
+	}
+}
+
+// limitedColorsScreen is a screen reporting that it only supports the given number of colors
+type limitedColorsScreen struct {
+	tcell.SimulationScreen
+	colors int
+}
+
+func (screen *limitedColorsScreen) Colors() int {
+	return screen.colors
+}
+
+func TestPickRichWithLimitedColors(t *testing.T) {
+	config := defaultConfig
+	OptionTheme(DraculaTheme())(&config)
+	// The first item is not selected, so that it is drawn with its own style
+	config.DefaultIndex = 1
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatal("encountered error while creating simulation screen:", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	items := []Item{{Label: "danger", Style: tcell.StyleDefault.Foreground(tcell.NewHexColor(0xff5555))}, {Label: "safe"}}
+	if _, _, err = pickRich("question", items, &limitedColorsScreen{SimulationScreen: screen, colors: 8}, &config); err != nil {
+		t.Fatal(err.Error())
+	}
+	width, height := screen.Size()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			_, _, style, _ := screen.GetContent(x, y)
+			foreground, background, _ := style.Decompose()
+			for _, color := range []tcell.Color{foreground, background} {
+				if color.Valid() && (color.IsRGB() || color > tcell.ColorSilver) {
+					t.Fatalf("expected only the 8 basic colors at (%d, %d), got %v on %v", x, y, foreground, background)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package gochoice
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// echoResult writes the question followed by the values of the choices selected on a single line,
+// e.g. "Which color? Red, Blue"
+func echoResult(writer io.Writer, question string, selectedChoices []*Choice) {
+	question = strings.Join(strings.Fields(question), " ")
+	if len(question) > 0 && !unicode.IsPunct([]rune(question)[len([]rune(question))-1]) {
+		question += ":"
+	}
+	values := make([]string, 0, len(selectedChoices))
+	for _, choice := range selectedChoices {
+		values = append(values, choice.Value)
+	}
+	_, _ = fmt.Fprintln(writer, strings.TrimSpace(question+" "+strings.Join(values, ", ")))
+}
+
+// OptionEchoResult writes the question and the choices selected to the given writer, e.g. os.Stderr, once the prompt
+// is closed, so that a record of the answer remains in the terminal. Nothing is written if the prompt is aborted.
+func OptionEchoResult(writer io.Writer) func(config *Config) {
+	return func(config *Config) {
+		config.EchoResult = writer
+	}
+}
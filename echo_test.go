@@ -0,0 +1,54 @@
+package gochoice
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestEchoResult(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		question       string
+		values         []string
+		expectedOutput string
+	}{
+		{
+			name:           "question-mark",
+			question:       "Which color?",
+			values:         []string{"Red"},
+			expectedOutput: "Which color? Red\n",
+		},
+		{
+			name:           "no-punctuation",
+			question:       "Color",
+			values:         []string{"Red"},
+			expectedOutput: "Color: Red\n",
+		},
+		{
+			name:           "multiple-values",
+			question:       "Which colors?",
+			values:         []string{"Red", "Blue"},
+			expectedOutput: "Which colors? Red, Blue\n",
+		},
+		{
+			name:           "multiline-question",
+			question:       "Pick a color\nfor the background?",
+			values:         []string{"Red"},
+			expectedOutput: "Pick a color for the background? Red\n",
+		},
+		{
+			name:           "no-values",
+			question:       "Which colors?",
+			expectedOutput: "Which colors?\n",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			var output bytes.Buffer
+			echoResult(&output, scenario.question, newChoices(scenario.values))
+			if output.String() != scenario.expectedOutput {
+				t.Errorf("expected %q, got %q", scenario.expectedOutput, output.String())
+			}
+		})
+	}
+}
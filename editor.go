@@ -0,0 +1,70 @@
+package gochoice
+
+// lineEditor is a single line of text being edited, with a cursor that can be moved within it
+type lineEditor struct {
+	runes []rune
+	// cursor is the index of the rune before which the next rune is inserted
+	cursor int
+}
+
+// newLineEditor returns a lineEditor containing the given text, with the cursor at the end of it
+func newLineEditor(text string) *lineEditor {
+	runes := []rune(text)
+	return &lineEditor{runes: runes, cursor: len(runes)}
+}
+
+// String returns the text being edited
+func (editor *lineEditor) String() string {
+	return string(editor.runes)
+}
+
+// insert inserts the given rune at the position of the cursor and moves the cursor after it
+func (editor *lineEditor) insert(r rune) {
+	editor.runes = append(editor.runes, 0)
+	copy(editor.runes[editor.cursor+1:], editor.runes[editor.cursor:])
+	editor.runes[editor.cursor] = r
+	editor.cursor++
+}
+
+// deleteBackward deletes the rune before the cursor and reports whether there was one
+func (editor *lineEditor) deleteBackward() bool {
+	if editor.cursor == 0 {
+		return false
+	}
+	editor.runes = append(editor.runes[:editor.cursor-1], editor.runes[editor.cursor:]...)
+	editor.cursor--
+	return true
+}
+
+// deleteForward deletes the rune after the cursor and reports whether there was one
+func (editor *lineEditor) deleteForward() bool {
+	if editor.cursor == len(editor.runes) {
+		return false
+	}
+	editor.runes = append(editor.runes[:editor.cursor], editor.runes[editor.cursor+1:]...)
+	return true
+}
+
+// moveLeft moves the cursor one rune to the left, unless it is already at the start of the line
+func (editor *lineEditor) moveLeft() {
+	if editor.cursor > 0 {
+		editor.cursor--
+	}
+}
+
+// moveRight moves the cursor one rune to the right, unless it is already at the end of the line
+func (editor *lineEditor) moveRight() {
+	if editor.cursor < len(editor.runes) {
+		editor.cursor++
+	}
+}
+
+// moveToStart moves the cursor to the start of the line
+func (editor *lineEditor) moveToStart() {
+	editor.cursor = 0
+}
+
+// moveToEnd moves the cursor to the end of the line
+func (editor *lineEditor) moveToEnd() {
+	editor.cursor = len(editor.runes)
+}
@@ -0,0 +1,44 @@
+package gochoice
+
+import "testing"
+
+func TestLineEditor(t *testing.T) {
+	editor := newLineEditor("hllo")
+	if editor.cursor != 4 {
+		t.Fatal("expected cursor to be at the end of the line, got", editor.cursor)
+	}
+	editor.moveToStart()
+	editor.moveRight()
+	editor.insert('e')
+	if editor.String() != "hello" || editor.cursor != 2 {
+		t.Errorf("expected hello with cursor at 2, got %s with cursor at %d", editor.String(), editor.cursor)
+	}
+	editor.moveToEnd()
+	editor.insert('!')
+	editor.moveLeft()
+	if !editor.deleteBackward() || editor.String() != "hell!" {
+		t.Error("expected hell!, got", editor.String())
+	}
+	if !editor.deleteForward() || editor.String() != "hell" {
+		t.Error("expected hell, got", editor.String())
+	}
+	if editor.deleteForward() {
+		t.Error("expected nothing to delete after the cursor")
+	}
+	editor.moveToStart()
+	editor.moveLeft()
+	if editor.deleteBackward() || editor.cursor != 0 {
+		t.Error("expected nothing to delete before the cursor")
+	}
+}
+
+func TestLineEditorWithMultiByteRunes(t *testing.T) {
+	editor := newLineEditor("héllo")
+	editor.moveLeft()
+	editor.moveLeft()
+	editor.moveLeft()
+	editor.deleteBackward()
+	if editor.String() != "hllo" {
+		t.Error("expected hllo, got", editor.String())
+	}
+}
@@ -0,0 +1,86 @@
+package gochoice
+
+import (
+	"strconv"
+	"strings"
+)
+
+// themesByName are the themes that can be set with the GOCHOICE_THEME environment variable
+var themesByName = map[string]func() Theme{
+	"default":    DefaultTheme,
+	"solarized":  SolarizedTheme,
+	"dracula":    DraculaTheme,
+	"light":      LightTheme,
+	"monochrome": MonochromeTheme,
+}
+
+// fallbackModesByName are the fallback modes that can be set with the GOCHOICE_FALLBACK environment variable
+var fallbackModesByName = map[string]FallbackMode{
+	"auto":   FallbackAuto,
+	"always": FallbackAlways,
+	"never":  FallbackNever,
+}
+
+// applyEnvOverrides overrides the configuration with the environment variables returned by lookupEnv.
+// Variables that aren't set or whose value isn't valid are ignored.
+func applyEnvOverrides(config *Config, lookupEnv func(key string) (string, bool)) {
+	if name, ok := lookupEnv("GOCHOICE_THEME"); ok {
+		if theme, ok := themesByName[strings.ToLower(name)]; ok {
+			config.Theme = config.Theme.withStylesOf(theme())
+		}
+	}
+	if noMouse, ok := lookupEnvBool(lookupEnv, "GOCHOICE_NO_MOUSE"); ok {
+		config.Mouse = !noMouse
+	}
+	if vim, ok := lookupEnvBool(lookupEnv, "GOCHOICE_VIM"); ok && vim != config.VimBindings {
+		if vim {
+			config.KeyMap = VimKeyMap()
+		} else {
+			config.KeyMap = DefaultKeyMap()
+		}
+		config.VimBindings = vim
+	}
+	if fuzzy, ok := lookupEnvBool(lookupEnv, "GOCHOICE_FUZZY"); ok {
+		config.FuzzySearch = fuzzy
+	}
+	if keyHints, ok := lookupEnvBool(lookupEnv, "GOCHOICE_KEY_HINTS"); ok {
+		config.KeyHints = keyHints
+	}
+	if accessible, ok := lookupEnvBool(lookupEnv, "GOCHOICE_ACCESSIBLE"); ok {
+		config.Accessible = accessible
+	}
+	if name, ok := lookupEnv("GOCHOICE_FALLBACK"); ok {
+		if mode, ok := fallbackModesByName[strings.ToLower(name)]; ok {
+			config.Fallback = mode
+		}
+	}
+}
+
+// lookupEnvBool returns the boolean value of the environment variable, e.g. "1" or "true", and whether it is set to
+// a valid boolean
+func lookupEnvBool(lookupEnv func(key string) (string, bool), key string) (bool, bool) {
+	value, ok := lookupEnv(key)
+	if !ok {
+		return false, false
+	}
+	parsed, err := strconv.ParseBool(value)
+	return parsed, err == nil
+}
+
+// OptionEnvOverrides lets the end users of a tool built on the prompt adjust it through environment variables,
+// which take precedence over the other options, whatever their order:
+//
+//   - GOCHOICE_THEME sets the theme to default, solarized, dracula, light or monochrome
+//   - GOCHOICE_NO_MOUSE disables the mouse support if true, e.g. 1, or enables it if false
+//   - GOCHOICE_VIM enables or disables the vim bindings
+//   - GOCHOICE_FUZZY enables or disables the fuzzy search
+//   - GOCHOICE_KEY_HINTS enables or disables the key hints
+//   - GOCHOICE_ACCESSIBLE enables or disables the accessible mode of OptionAccessible
+//   - GOCHOICE_FALLBACK sets the fallback mode to auto, always or never
+//
+// Variables that aren't set or whose value isn't valid are ignored.
+func OptionEnvOverrides() func(config *Config) {
+	return func(config *Config) {
+		config.EnvOverrides = true
+	}
+}
@@ -0,0 +1,99 @@
+package gochoice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyEnvOverrides(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		env      map[string]string
+		options  []Option
+		expected func(config *Config)
+	}{
+		{
+			name:     "no-variables",
+			options:  []Option{OptionMouse()},
+			expected: func(config *Config) { config.Mouse = true },
+		},
+		{
+			name:     "theme",
+			env:      map[string]string{"GOCHOICE_THEME": "Dracula"},
+			options:  []Option{OptionCheckboxMarkers("◉", "◯")},
+			expected: func(config *Config) { config.Theme = DraculaTheme(); OptionCheckboxMarkers("◉", "◯")(config) },
+		},
+		{
+			name:     "unknown-theme",
+			env:      map[string]string{"GOCHOICE_THEME": "unknown"},
+			options:  []Option{OptionTheme(SolarizedTheme())},
+			expected: func(config *Config) { config.Theme = SolarizedTheme() },
+		},
+		{
+			name:     "no-mouse",
+			env:      map[string]string{"GOCHOICE_NO_MOUSE": "1"},
+			options:  []Option{OptionMouse()},
+			expected: func(config *Config) {},
+		},
+		{
+			name:     "invalid-no-mouse",
+			env:      map[string]string{"GOCHOICE_NO_MOUSE": "maybe"},
+			options:  []Option{OptionMouse()},
+			expected: func(config *Config) { config.Mouse = true },
+		},
+		{
+			name:     "vim-enabled",
+			env:      map[string]string{"GOCHOICE_VIM": "true"},
+			expected: func(config *Config) { OptionVimBindings()(config) },
+		},
+		{
+			name:     "vim-disabled",
+			env:      map[string]string{"GOCHOICE_VIM": "false"},
+			options:  []Option{OptionVimBindings()},
+			expected: func(config *Config) {},
+		},
+		{
+			name:     "accessible",
+			env:      map[string]string{"GOCHOICE_ACCESSIBLE": "1"},
+			expected: func(config *Config) { config.Accessible = true },
+		},
+		{
+			name:    "fuzzy-key-hints-and-fallback",
+			env:     map[string]string{"GOCHOICE_FUZZY": "1", "GOCHOICE_KEY_HINTS": "0", "GOCHOICE_FALLBACK": "always"},
+			options: []Option{OptionKeyHints()},
+			expected: func(config *Config) {
+				config.FuzzySearch, config.Fallback = true, FallbackAlways
+			},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			for _, option := range scenario.options {
+				option(&config)
+			}
+			applyEnvOverrides(&config, func(key string) (string, bool) {
+				value, ok := scenario.env[key]
+				return value, ok
+			})
+			expected := defaultConfig
+			scenario.expected(&expected)
+			if !reflect.DeepEqual(config.Theme, expected.Theme) || !reflect.DeepEqual(config.KeyMap, expected.KeyMap) {
+				t.Error("expected the theme and the key map to match")
+			}
+			if config.Mouse != expected.Mouse || config.VimBindings != expected.VimBindings || config.FuzzySearch != expected.FuzzySearch || config.KeyHints != expected.KeyHints || config.Accessible != expected.Accessible || config.Fallback != expected.Fallback {
+				t.Errorf("expected %+v, got %+v", expected, config)
+			}
+		})
+	}
+}
+
+func TestOptionEnvOverrides(t *testing.T) {
+	t.Setenv("GOCHOICE_NO_MOUSE", "1")
+	if config := newConfig([]Option{OptionEnvOverrides(), OptionMouse()}); config.Mouse {
+		t.Error("expected the environment to take precedence over the options")
+	}
+	if config := newConfig([]Option{OptionMouse()}); !config.Mouse {
+		t.Error("expected the environment to be ignored without OptionEnvOverrides")
+	}
+}
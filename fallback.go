@@ -0,0 +1,283 @@
+package gochoice
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// FallbackMode determines when choices are picked by typing their number in response to a list
+// printed on stderr, rather than through the interactive screen
+type FallbackMode int
+
+const (
+	// FallbackAuto uses the fallback when there is no terminal, e.g. in CI, or when TERM is dumb. If stdin or stdout is redirected,
+	// e.g. when the output is piped, the prompt is still displayed on the terminal of the process if there is one.
+	FallbackAuto FallbackMode = iota
+
+	// FallbackAlways always uses the fallback
+	FallbackAlways
+
+	// FallbackNever never uses the fallback, even if stdin or stdout isn't a terminal
+	FallbackNever
+)
+
+var errInvalidFallbackSelection = errors.New("invalid selection")
+
+// useFallback reports whether the fallback should be used instead of the interactive screen
+func (config *Config) useFallback() bool {
+	if config.Accessible {
+		return true
+	}
+	switch config.Fallback {
+	case FallbackAlways:
+		return true
+	case FallbackNever:
+		return false
+	default:
+		if isDumbTerminal(os.Getenv) {
+			// The screen can't be displayed on a terminal that can't move the cursor
+			return true
+		}
+		if term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd())) {
+			return false
+		}
+		return !hasTerminal()
+	}
+}
+
+// pickWithFallback prints the question and a numbered list of choices to the given writer,
+// then reads the number of the choice selected from the given reader.
+// Invalid numbers are reported and another number is read until the reader has nothing left.
+func pickWithFallback(ctx context.Context, question string, choices []*Choice, config *Config, in io.Reader, out io.Writer) ([]*Choice, error) {
+	if len(choices) == 0 {
+		return nil, ErrNoChoice
+	}
+	dedupeChoices(choices, config)
+	choices = sortChoices(choices, config)
+	fmt.Fprintln(out, question)
+	for _, headerLine := range textLines(config.Header) {
+		fmt.Fprintln(out, headerLine)
+	}
+	if len(config.columnHeader) > 0 {
+		// Align the header with the values of the choices, which follow their number
+		fmt.Fprintln(out, "     "+config.columnHeader)
+	}
+	var numberedChoices []*Choice
+	for _, choice := range choices {
+		if choice.duplicate {
+			continue
+		}
+		if choice.separator {
+			fmt.Fprintln(out, "  ---")
+			continue
+		}
+		if choice.header || choice.branch {
+			fmt.Fprintln(out, strings.Repeat(treeIndentation, choice.depth)+choice.Value)
+			continue
+		}
+		numberedChoices = append(numberedChoices, choice)
+		line := fmt.Sprintf("%s  %d) %s", strings.Repeat(treeIndentation, choice.depth), len(numberedChoices), choice.Value)
+		if len(choice.Description) > 0 {
+			line += descriptionSeparator + choice.Description
+		}
+		if choice.Disabled {
+			line += " (unavailable)"
+		}
+		fmt.Fprintln(out, line)
+	}
+	defaultChoice := selectDefaultChoice(choices, config)
+	if defaultChoice != nil && defaultChoice.branch {
+		// Only leaves can be picked
+		defaultChoice = nil
+	}
+	prompt := fallbackPrompt(numberedChoices, defaultChoice, config)
+	lines, stopScanning := scanLines(in)
+	defer stopScanning()
+	for {
+		if ctx.Err() != nil {
+			return nil, &contextCanceledError{err: ctx.Err()}
+		}
+		fmt.Fprint(out, prompt)
+		line, err := readLine(ctx, lines)
+		if err == io.EOF {
+			fmt.Fprintln(out)
+			return nil, ErrAborted
+		} else if err != nil {
+			return nil, err
+		}
+		selectedChoices, err := parseFallbackSelection(line, numberedChoices, defaultChoice, config.multiSelect)
+		if err != nil {
+			fmt.Fprintln(out, err)
+			continue
+		}
+		if config.multiSelect {
+			// The choices entered replace the choices checked, against which the selection is validated
+			for _, choice := range choices {
+				choice.Checked = false
+			}
+			for _, choice := range selectedChoices {
+				choice.Checked = true
+			}
+		}
+		var selectedChoice *Choice
+		if !config.multiSelect {
+			selectedChoice = selectedChoices[0]
+		}
+		if message := confirmationMessage(choices, selectedChoice, config); len(message) > 0 {
+			fmt.Fprintln(out, message)
+			continue
+		}
+		if isDangerous(choices, selectedChoice, config) {
+			// Like on the screen, dangerous choices must be confirmed again
+			fmt.Fprintf(out, "Confirm %s? [y/N]: ", strings.Join(dangerousValues(selectedChoices), ", "))
+			answer, err := readLine(ctx, lines)
+			if err == io.EOF {
+				fmt.Fprintln(out)
+				return nil, ErrAborted
+			} else if err != nil {
+				return nil, err
+			}
+			if answer = strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+				continue
+			}
+		}
+		if config.Accessible {
+			// Screen readers read the choice selected back, since nothing else shows which one it was
+			selectedValues := make([]string, 0, len(selectedChoices))
+			for _, choice := range selectedChoices {
+				selectedValues = append(selectedValues, choice.Value)
+			}
+			fmt.Fprintln(out, "Selected:", strings.Join(selectedValues, ", "))
+		}
+		return selectedChoices, nil
+	}
+}
+
+// scanLines reads the lines of the given reader in the background, so that waiting for the next line can be
+// interrupted. The lines are read until the function returned is called or the reader has nothing left,
+// after which the channel returned is closed.
+func scanLines(in io.Reader) (<-chan string, func()) {
+	lines := make(chan string)
+	quit := make(chan struct{})
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-quit:
+				return
+			}
+		}
+	}()
+	return lines, func() { close(quit) }
+}
+
+// readLine returns the next line scanned by scanLines, io.EOF if there is none left,
+// or an error wrapping the error of the given context if it is done first
+func readLine(ctx context.Context, lines <-chan string) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", &contextCanceledError{err: ctx.Err()}
+	case line, ok := <-lines:
+		if !ok {
+			return "", io.EOF
+		}
+		return line, nil
+	}
+}
+
+// dangerousValues returns the values of the dangerous choices among the given ones
+func dangerousValues(choices []*Choice) []string {
+	var values []string
+	for _, choice := range choices {
+		if choice.Dangerous {
+			values = append(values, choice.Value)
+		}
+	}
+	return values
+}
+
+// fallbackPrompt returns the text asking for the numbers of the choices to select. With OptionAccessible,
+// it also states the range of the numbers, so that it can be understood without going back to the list.
+func fallbackPrompt(numberedChoices []*Choice, defaultChoice *Choice, config *Config) string {
+	numbers := "a number"
+	if config.multiSelect {
+		numbers = "numbers"
+	}
+	if config.Accessible {
+		numbers += fmt.Sprintf(" between 1 and %d", len(numberedChoices))
+	}
+	if config.multiSelect {
+		return fmt.Sprintf("Enter %s separated by spaces: ", numbers)
+	}
+	if defaultChoice != nil {
+		return fmt.Sprintf("Enter %s [%d]: ", numbers, indexOf(numberedChoices, defaultChoice)+1)
+	}
+	return fmt.Sprintf("Enter %s: ", numbers)
+}
+
+// parseFallbackSelection returns the choices corresponding to the numbers in the given input, once each and in the order
+// in which they are listed, like the choices checked on the screen.
+// If the input is empty, the default choice is returned, unless multiSelect is true.
+func parseFallbackSelection(input string, numberedChoices []*Choice, defaultChoice *Choice, multiSelect bool) ([]*Choice, error) {
+	fields := strings.FieldsFunc(input, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	if len(fields) == 0 {
+		if multiSelect {
+			return []*Choice{}, nil
+		}
+		if defaultChoice == nil {
+			return nil, fmt.Errorf("%w: please enter a number", errInvalidFallbackSelection)
+		}
+		return []*Choice{defaultChoice}, nil
+	}
+	if len(fields) > 1 && !multiSelect {
+		return nil, fmt.Errorf("%w: please enter a single number", errInvalidFallbackSelection)
+	}
+	entered := make(map[*Choice]bool, len(fields))
+	for _, field := range fields {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 1 || number > len(numberedChoices) {
+			return nil, fmt.Errorf("%w: please enter a number between 1 and %d", errInvalidFallbackSelection, len(numberedChoices))
+		}
+		choice := numberedChoices[number-1]
+		if choice.Disabled {
+			return nil, fmt.Errorf("%w: %s is unavailable", errInvalidFallbackSelection, choice.Value)
+		}
+		entered[choice] = true
+	}
+	selectedChoices := make([]*Choice, 0, len(entered))
+	for _, choice := range numberedChoices {
+		if entered[choice] {
+			selectedChoices = append(selectedChoices, choice)
+		}
+	}
+	return selectedChoices, nil
+}
+
+// OptionAccessible replaces the interactive screen, which screen readers can't follow as it is redrawn, by a prompt
+// printing a numbered list of the choices and reading the number of the choice selected, as with FallbackAlways.
+// The prompt states the range of the numbers, and the choice selected is printed once it has been entered.
+func OptionAccessible() func(config *Config) {
+	return func(config *Config) {
+		config.Accessible = true
+	}
+}
+
+// OptionFallback sets when choices are picked by typing their number in response to a list
+// printed on stderr instead of through the interactive screen. The default is FallbackAuto.
+func OptionFallback(mode FallbackMode) func(config *Config) {
+	return func(config *Config) {
+		config.Fallback = mode
+	}
+}
@@ -0,0 +1,267 @@
+package gochoice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/term"
+)
+
+func TestPickWithFallback(t *testing.T) {
+	config := defaultConfig
+	output := &bytes.Buffer{}
+	selectedChoices, err := pickWithFallback(context.Background(), "question", newChoices([]string{"A", "B", "C"}), &config, strings.NewReader("2\n"), output)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(selectedChoices) != 1 || selectedChoices[0].Value != "B" || selectedChoices[0].Id != 1 {
+		t.Error("expected B, got", selectedChoices)
+	}
+	expectedOutput := "question\n  1) A\n  2) B\n  3) C\nEnter a number [1]: "
+	if output.String() != expectedOutput {
+		t.Errorf("expected output %q, got %q", expectedOutput, output.String())
+	}
+}
+
+func TestPickWithFallbackDefault(t *testing.T) {
+	config := defaultConfig
+	OptionDefaultIndex(2)(&config)
+	selectedChoices, err := pickWithFallback(context.Background(), "question", newChoices([]string{"A", "B", "C"}), &config, strings.NewReader("\n"), &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(selectedChoices) != 1 || selectedChoices[0].Value != "C" {
+		t.Error("expected C, got", selectedChoices)
+	}
+}
+
+func TestPickWithFallbackRetriesOnInvalidInput(t *testing.T) {
+	config := defaultConfig
+	output := &bytes.Buffer{}
+	choices := newChoicesFromItems([]Item{{Label: "A"}, {Label: "B", Disabled: true}, {Label: "C"}})
+	selectedChoices, err := pickWithFallback(context.Background(), "question", choices, &config, strings.NewReader("abc\n4\n2\n3\n"), output)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(selectedChoices) != 1 || selectedChoices[0].Value != "C" {
+		t.Error("expected C, got", selectedChoices)
+	}
+	if strings.Count(output.String(), "invalid selection") != 3 {
+		t.Error("expected 3 invalid selections to be reported, got", output.String())
+	}
+}
+
+func TestPickWithFallbackEndOfInput(t *testing.T) {
+	config := defaultConfig
+	_, err := pickWithFallback(context.Background(), "question", newChoices([]string{"A", "B"}), &config, strings.NewReader(""), &bytes.Buffer{})
+	if !errors.Is(err, ErrAborted) {
+		t.Error("expected ErrAborted, got", err)
+	}
+}
+
+func TestPickWithFallbackContextCanceled(t *testing.T) {
+	config := defaultConfig
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := pickWithFallback(ctx, "question", newChoices([]string{"A", "B"}), &config, strings.NewReader("1\n"), &bytes.Buffer{})
+	if !errors.Is(err, ErrContextCanceled) || !errors.Is(err, context.Canceled) {
+		t.Error("expected ErrContextCanceled wrapping context.Canceled, got", err)
+	}
+}
+
+func TestPickWithFallbackContextCanceledWhileReading(t *testing.T) {
+	config := defaultConfig
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// Nothing is ever written to the reader, so reading from it blocks until it is closed
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	_, err := pickWithFallback(ctx, "question", newChoices([]string{"A", "B"}), &config, reader, &bytes.Buffer{})
+	if !errors.Is(err, ErrContextCanceled) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("expected ErrContextCanceled wrapping context.DeadlineExceeded, got", err)
+	}
+}
+
+func TestPickWithFallbackMultiple(t *testing.T) {
+	config := defaultConfig
+	config.multiSelect = true
+	selectedChoices, err := pickWithFallback(context.Background(), "question", newChoices([]string{"A", "B", "C"}), &config, strings.NewReader("1, 3\n"), &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(selectedChoices) != 2 || selectedChoices[0].Value != "A" || selectedChoices[1].Value != "C" {
+		t.Error("expected [A C], got", selectedChoices)
+	}
+}
+
+func TestPickWithFallbackMultipleInAnyOrder(t *testing.T) {
+	config := defaultConfig
+	config.multiSelect = true
+	OptionSelectionLimits(0, 2)(&config)
+	// The number entered twice only counts once towards the maximum number of choices
+	selectedChoices, err := pickWithFallback(context.Background(), "question", newChoices([]string{"A", "B", "C"}), &config, strings.NewReader("3 1 3\n"), &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(selectedChoices) != 2 || selectedChoices[0].Value != "A" || selectedChoices[1].Value != "C" {
+		t.Error("expected [A C], got", selectedChoices)
+	}
+}
+
+func TestPickWithFallbackSelectionLimits(t *testing.T) {
+	config := defaultConfig
+	config.multiSelect = true
+	OptionSelectionLimits(2, 3)(&config)
+	output := &bytes.Buffer{}
+	choices := newChoices([]string{"A", "B", "C", "D", "E"})
+	selectedChoices, err := pickWithFallback(context.Background(), "question", choices, &config, strings.NewReader("1\n1 2 3 4\n2 4\n"), output)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(selectedChoices) != 2 || selectedChoices[0].Value != "B" || selectedChoices[1].Value != "D" {
+		t.Error("expected [B D], got", selectedChoices)
+	}
+	if !strings.Contains(output.String(), "Select at least 2 choices") || !strings.Contains(output.String(), "Select at most 3 choices") {
+		t.Error("expected both limits to be reported, got", output.String())
+	}
+}
+
+func TestPickWithFallbackValidator(t *testing.T) {
+	config := defaultConfig
+	OptionValidator(func(value string, index int) error {
+		if value == "B" {
+			return errors.New("B is checked out")
+		}
+		return nil
+	})(&config)
+	output := &bytes.Buffer{}
+	selectedChoices, err := pickWithFallback(context.Background(), "question", newChoices([]string{"A", "B", "C"}), &config, strings.NewReader("2\n3\n"), output)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(selectedChoices) != 1 || selectedChoices[0].Value != "C" {
+		t.Error("expected C, got", selectedChoices)
+	}
+	if !strings.Contains(output.String(), "B is checked out\n") {
+		t.Error("expected the validation error to be reported, got", output.String())
+	}
+}
+
+func TestPickWithFallbackDangerous(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		input         string
+		expectedValue string
+		expectedErr   error
+	}{
+		{name: "confirmed", input: "2\ny\n", expectedValue: "drop"},
+		{name: "confirmed-with-yes", input: "2\nYes\n", expectedValue: "drop"},
+		{name: "not-confirmed", input: "2\n\n1\n", expectedValue: "keep"},
+		{name: "end-of-input", input: "2\n", expectedErr: ErrAborted},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			output := &bytes.Buffer{}
+			choices := newChoicesFromItems([]Item{{Label: "keep"}, {Label: "drop", Dangerous: true}})
+			selectedChoices, err := pickWithFallback(context.Background(), "question", choices, &config, strings.NewReader(scenario.input), output)
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil && (len(selectedChoices) != 1 || selectedChoices[0].Value != scenario.expectedValue) {
+				t.Errorf("expected %s, got %v", scenario.expectedValue, selectedChoices)
+			}
+			if !strings.Contains(output.String(), "Confirm drop? [y/N]: ") {
+				t.Error("expected the dangerous choice to be confirmed, got", output.String())
+			}
+		})
+	}
+}
+
+func TestPickWithFallbackGroups(t *testing.T) {
+	config := defaultConfig
+	output := &bytes.Buffer{}
+	selectedChoices, err := pickWithFallback(context.Background(), "question", newChoicesFromGroups(testGroups), &config, strings.NewReader("3\n"), output)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(selectedChoices) != 1 || selectedChoices[0].Value != "staging-us" {
+		t.Error("expected staging-us, got", selectedChoices)
+	}
+	if !strings.HasPrefix(output.String(), "question\nProduction\n  1) prod-us\n  2) prod-eu\nStaging\n  3) staging-us\n") {
+		t.Error("expected groups to be printed as headers, got", output.String())
+	}
+}
+
+func TestConfigUseFallback(t *testing.T) {
+	config := defaultConfig
+	OptionFallback(FallbackAlways)(&config)
+	if !config.useFallback() {
+		t.Error("expected fallback to be used with FallbackAlways")
+	}
+	OptionFallback(FallbackNever)(&config)
+	if config.useFallback() {
+		t.Error("expected fallback not to be used with FallbackNever")
+	}
+}
+
+func TestConfigUseFallbackWithRedirectedOutput(t *testing.T) {
+	if term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd())) {
+		t.Skip("stdin and stdout must not both be terminals")
+	}
+	config := defaultConfig
+	// The prompt is displayed on the terminal of the process if there is one, even if stdin or stdout is redirected
+	if config.useFallback() != !hasTerminal() {
+		t.Errorf("expected the fallback to be used only if there is no terminal, got useFallback()=%v and hasTerminal()=%v", config.useFallback(), hasTerminal())
+	}
+}
+
+func TestPickWithFallbackAccessible(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		multiSelect    bool
+		input          string
+		expectedOutput string
+	}{
+		{
+			name:           "single",
+			input:          "2\n",
+			expectedOutput: "question\n  1) A\n  2) B\n  3) C\nEnter a number between 1 and 3 [1]: Selected: B\n",
+		},
+		{
+			name:           "multiple",
+			multiSelect:    true,
+			input:          "1 3\n",
+			expectedOutput: "question\n  1) A\n  2) B\n  3) C\nEnter numbers between 1 and 3 separated by spaces: Selected: A, C\n",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionAccessible()(&config)
+			config.multiSelect = scenario.multiSelect
+			output := &bytes.Buffer{}
+			if _, err := pickWithFallback(context.Background(), "question", newChoices([]string{"A", "B", "C"}), &config, strings.NewReader(scenario.input), output); err != nil {
+				t.Fatal(err.Error())
+			}
+			if output.String() != scenario.expectedOutput {
+				t.Errorf("expected output %q, got %q", scenario.expectedOutput, output.String())
+			}
+		})
+	}
+}
+
+func TestConfigUseFallbackWithAccessible(t *testing.T) {
+	config := defaultConfig
+	OptionFallback(FallbackNever)(&config)
+	OptionAccessible()(&config)
+	if !config.useFallback() {
+		t.Error("expected the fallback to be used in accessible mode")
+	}
+}
@@ -0,0 +1,164 @@
+package gochoice
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+)
+
+// errBack is the error returned by a step of a Form when the user goes back to the previous step,
+// and by a submenu of PickMenu when the user goes back to its parent menu
+var errBack = errors.New("back to the previous step")
+
+// Answers are the answers given to the steps of a Form, indexed by the key of each step
+type Answers map[string]string
+
+type formStepKind int
+
+const (
+	formStepPick formStepKind = iota
+	formStepInput
+	formStepPassword
+	formStepConfirm
+)
+
+type formStep struct {
+	kind     formStepKind
+	key      string
+	question string
+	choices  func(answers Answers) []string
+	options  []Option
+}
+
+// Form is a sequence of prompts displayed one after the other on the same screen.
+// While a step is displayed, the keys bound to KeyMap.Back (Shift+Tab by default) go back to the previous step.
+type Form struct {
+	options []Option
+	steps   []formStep
+}
+
+// NewForm creates an empty Form. The options given apply to every step of the form.
+func NewForm(options ...Option) *Form {
+	return &Form{options: options}
+}
+
+// Pick adds a step prompting the user to choose from the choices returned by the given function,
+// which receives the answers given to the previous steps. The answer is the value of the choice selected.
+func (form *Form) Pick(key, question string, choices func(answers Answers) []string, options ...Option) *Form {
+	form.steps = append(form.steps, formStep{kind: formStepPick, key: key, question: question, choices: choices, options: options})
+	return form
+}
+
+// Input adds a step prompting the user to type a line of text, like Input
+func (form *Form) Input(key, question string, options ...Option) *Form {
+	form.steps = append(form.steps, formStep{kind: formStepInput, key: key, question: question, options: options})
+	return form
+}
+
+// Password adds a step prompting the user to type a secret, like Password
+func (form *Form) Password(key, question string, options ...Option) *Form {
+	form.steps = append(form.steps, formStep{kind: formStepPassword, key: key, question: question, options: options})
+	return form
+}
+
+// Confirm adds a step prompting the user to answer yes or no. The answer is either "true" or "false".
+func (form *Form) Confirm(key, question string, options ...Option) *Form {
+	form.steps = append(form.steps, formStep{kind: formStepConfirm, key: key, question: question, options: options})
+	return form
+}
+
+// Run displays each step of the form and returns the answers given once the last step is completed.
+// Going back to a step preselects the answer previously given to it.
+func (form *Form) Run() (Answers, error) {
+	return form.RunContext(context.Background())
+}
+
+// RunContext is like Run, but the form is aborted with ErrContextCanceled as soon as the provided context is done
+func (form *Form) RunContext(ctx context.Context) (Answers, error) {
+	// Without the interactive screen, the steps are run through the fallback and cannot be gone back to
+	var shared *sharedScreen
+	if config := newConfig(form.options); !config.useFallback() {
+		screen, err := createScreenForConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		defer screen.Fini()
+		shared = newSharedScreen(screen)
+		shared.tty = config.tty
+		defer shared.stopListening()
+	}
+	answers := make(Answers)
+	if err := form.runSteps(ctx, shared, answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// runSteps runs each step of the form on the given screen, or through the fallback if the screen is nil,
+// and stores their answers
+func (form *Form) runSteps(ctx context.Context, screen *sharedScreen, answers Answers) error {
+	for i := 0; i < len(form.steps); {
+		step := form.steps[i]
+		config := newConfig(append(append([]Option{}, form.options...), step.options...))
+		config.backAllowed = i > 0
+		answer, err := form.runStep(ctx, step, screen, config, answers)
+		if err == errBack {
+			i--
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		answers[step.key] = answer
+		i++
+	}
+	return nil
+}
+
+// runStep runs a single step of the form and returns its answer
+func (form *Form) runStep(ctx context.Context, step formStep, screen *sharedScreen, config *Config, answers Answers) (string, error) {
+	previousAnswer, answered := answers[step.key]
+	switch step.kind {
+	case formStepInput, formStepPassword:
+		config.secret = step.kind == formStepPassword
+		if config.secret {
+			config.DefaultValue = ""
+		} else if answered {
+			config.DefaultValue = previousAnswer
+		}
+		if screen == nil {
+			return inputWithFallback(ctx, step.question, config, os.Stdin, os.Stderr)
+		}
+		screen.SetStyle(config.Theme.background())
+		return input(ctx, step.question, screen, config)
+	case formStepConfirm:
+		if answered && previousAnswer == strconv.FormatBool(false) {
+			config.DefaultIndex = 1
+		}
+		selectedChoices, err := form.pick(ctx, step.question, newChoices([]string{"Yes", "No"}), screen, config)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatBool(selectedChoices[0].Id == 0), nil
+	default:
+		if answered {
+			config.DefaultValue = previousAnswer
+		}
+		selectedChoices, err := form.pick(ctx, step.question, newChoices(step.choices(answers)), screen, config)
+		if err != nil {
+			return "", err
+		}
+		return selectedChoices[0].Value, nil
+	}
+}
+
+// pick prompts the user to choose from the given choices on the given screen, or through the fallback if the screen is nil
+func (form *Form) pick(ctx context.Context, question string, choices []*Choice, screen *sharedScreen, config *Config) ([]*Choice, error) {
+	if screen == nil {
+		return pickWithFallback(ctx, question, choices, config, os.Stdin, os.Stderr)
+	}
+	config.tty = screen.tty
+	screen.SetStyle(config.Theme.background())
+	return pickChoices(ctx, question, choices, screen, config)
+}
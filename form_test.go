@@ -0,0 +1,107 @@
+package gochoice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFormRunSteps(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	SimulateKeys(screen, []tcell.Event{
+		// Pick "staging"
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		// Pick "staging-eu", whose choices are derived from the previous answer
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		// Type a name
+		tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		// Answer "No"
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+	})
+	shared := newSharedScreen(screen)
+	defer shared.stopListening()
+	answers := make(Answers)
+	err = newTestForm().runSteps(context.Background(), shared, answers)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expectedAnswers := Answers{"environment": "staging", "region": "staging-eu", "name": "x", "confirmed": "false"}
+	for key, expectedAnswer := range expectedAnswers {
+		if answers[key] != expectedAnswer {
+			t.Errorf("expected %s to be %q, got %q", key, expectedAnswer, answers[key])
+		}
+	}
+}
+
+func TestFormRunStepsWithBackNavigation(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	SimulateKeys(screen, []tcell.Event{
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		// Go back to the first step, where "production" is preselected, and pick "staging" instead
+		tcell.NewEventKey(tcell.KeyBacktab, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'x', tcell.ModNone),
+		// Going back from the input preselects the region previously picked
+		tcell.NewEventKey(tcell.KeyBacktab, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRune, 'y', tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+	})
+	shared := newSharedScreen(screen)
+	defer shared.stopListening()
+	answers := make(Answers)
+	err = newTestForm().runSteps(context.Background(), shared, answers)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expectedAnswers := Answers{"environment": "staging", "region": "staging-us", "name": "y", "confirmed": "true"}
+	for key, expectedAnswer := range expectedAnswers {
+		if answers[key] != expectedAnswer {
+			t.Errorf("expected %s to be %q, got %q", key, expectedAnswer, answers[key])
+		}
+	}
+}
+
+func TestFormRunStepsAborted(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	shared := newSharedScreen(screen)
+	defer shared.stopListening()
+	if err = newTestForm().runSteps(context.Background(), shared, make(Answers)); !errors.Is(err, ErrAborted) {
+		t.Error("expected ErrAborted, got", err)
+	}
+}
+
+func newTestForm() *Form {
+	return NewForm().
+		Pick("environment", "Environment?", func(Answers) []string {
+			return []string{"production", "staging"}
+		}).
+		Pick("region", "Region?", func(answers Answers) []string {
+			return []string{answers["environment"] + "-us", answers["environment"] + "-eu"}
+		}).
+		Input("name", "Name?").
+		Confirm("confirmed", "Are you sure?")
+}
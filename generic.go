@@ -0,0 +1,96 @@
+package gochoice
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// PickT prompts the user to choose an item from a slice of arbitrary values.
+// The label function is used to compute the text displayed for each item.
+func PickT[T any](question string, items []T, label func(T) string, options ...Option) (T, int, error) {
+	selectedChoices, err := runPicker(context.Background(), question, newChoicesFromT(items, label), withoutCustomChoice(newConfig(options)))
+	return toItemAndIndex(items, selectedChoices, err)
+}
+
+func pickT[T any](question string, items []T, label func(T) string, screen tcell.Screen, config *Config) (T, int, error) {
+	selectedChoices, err := pickChoices(context.Background(), question, newChoicesFromT(items, label), screen, withoutCustomChoice(config))
+	return toItemAndIndex(items, selectedChoices, err)
+}
+
+// newChoicesFromT creates a choice for each item, selecting the first one
+func newChoicesFromT[T any](items []T, label func(T) string) []*Choice {
+	choices := make([]*Choice, 0, len(items))
+	for i, item := range items {
+		choices = append(choices, &Choice{Id: i, Value: label(item), Data: item, Selected: i == 0})
+	}
+	return choices
+}
+
+// withoutCustomChoice disables OptionAllowCustom for the prompts returning one of the items rather than its value,
+// since the search query picked through it isn't one of them
+func withoutCustomChoice(config *Config) *Config {
+	config.CustomLabel = ""
+	return config
+}
+
+// toItemAndIndex returns the item and the index of the first choice selected
+func toItemAndIndex[T any](items []T, selectedChoices []*Choice, err error) (T, int, error) {
+	if err != nil {
+		var zero T
+		return zero, 0, err
+	}
+	return items[selectedChoices[0].Id], selectedChoices[0].Id, nil
+}
+
+// mapEntry is a key of a map picked with PickMap, along with its value
+type mapEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// PickMap prompts the user to choose an entry from a map. The label function is used to compute the text displayed
+// for each entry. Since the order of a map is random, the entries are sorted by label alphabetically, or with the
+// function passed to OptionSort, and entries with the same label are sorted by key.
+func PickMap[K comparable, V any](question string, m map[K]V, label func(K, V) string, options ...Option) (K, V, error) {
+	entries, entryLabel := mapEntries(m, label)
+	return toKeyAndValue(PickT(question, entries, entryLabel, options...))
+}
+
+func pickMap[K comparable, V any](question string, m map[K]V, label func(K, V) string, screen tcell.Screen, config *Config) (K, V, error) {
+	entries, entryLabel := mapEntries(m, label)
+	return toKeyAndValue(pickT(question, entries, entryLabel, screen, config))
+}
+
+// mapEntries returns the entries of the map sorted alphabetically by label, then by key, as well as a function
+// returning the label of an entry. Those that compare equal with OptionSort thus keep a deterministic order.
+func mapEntries[K comparable, V any](m map[K]V, label func(K, V) string) ([]mapEntry[K, V], func(mapEntry[K, V]) string) {
+	entries := make([]mapEntry[K, V], 0, len(m))
+	labels := make(map[K]string, len(m))
+	for key, value := range m {
+		entries = append(entries, mapEntry[K, V]{key: key, value: value})
+		labels[key] = label(key, value)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		labelI, labelJ := labels[entries[i].key], labels[entries[j].key]
+		if labelI != labelJ {
+			return SortAlphabetical(labelI, labelJ)
+		}
+		return fmt.Sprint(entries[i].key) < fmt.Sprint(entries[j].key)
+	})
+	return entries, func(entry mapEntry[K, V]) string {
+		return labels[entry.key]
+	}
+}
+
+// toKeyAndValue returns the key and the value of the entry selected
+func toKeyAndValue[K comparable, V any](entry mapEntry[K, V], _ int, err error) (K, V, error) {
+	if err != nil {
+		var zeroKey K
+		var zeroValue V
+		return zeroKey, zeroValue, err
+	}
+	return entry.key, entry.value, nil
+}
@@ -0,0 +1,216 @@
+package gochoice
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+type environment struct {
+	Name string
+	URL  string
+}
+
+func TestPickT(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, 's', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	environments := []environment{
+		{Name: "production", URL: "https://example.com"},
+		{Name: "staging", URL: "https://staging.example.com"},
+	}
+	env, index, err := pickT("question", environments, func(e environment) string { return e.Name }, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if env != environments[1] {
+		t.Error("expected staging, got", env.Name)
+	}
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+}
+
+func TestPickTQuit(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	env, _, err := pickT("question", []environment{{Name: "production"}}, func(e environment) string { return e.Name }, screen, &config)
+	if !errors.Is(err, ErrAborted) {
+		t.Error("expected ErrAborted, got", err)
+	}
+	if env != (environment{}) {
+		t.Error("expected zero value, got", env)
+	}
+}
+
+func TestPickMap(t *testing.T) {
+	regions := map[string]int{"us-west-2": 2, "eu-west-3": 3, "us-east-1": 1, "ap-south-1": 4}
+	scenarios := []struct {
+		name          string
+		options       []Option
+		keys          []tcell.Key
+		expectedKey   string
+		expectedValue int
+	}{
+		{name: "sorted-alphabetically", keys: []tcell.Key{tcell.KeyEnter}, expectedKey: "ap-south-1", expectedValue: 4},
+		{name: "sorted-alphabetically-down", keys: []tcell.Key{tcell.KeyDown, tcell.KeyEnter}, expectedKey: "eu-west-3", expectedValue: 3},
+		{name: "sorted-with-option", options: []Option{OptionSort(func(a, b string) bool { return a > b })}, keys: []tcell.Key{tcell.KeyEnter}, expectedKey: "us-west-2", expectedValue: 2},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			for _, option := range scenario.options {
+				option(&config)
+			}
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatal("encountered error while creating simulation screen:", err)
+			}
+			defer screen.Fini()
+			screen.SetStyle(config.Theme.background())
+			screen.Show()
+			for _, key := range scenario.keys {
+				screen.InjectKey(key, 0, tcell.ModNone)
+			}
+			key, value, err := pickMap("question", regions, func(region string, _ int) string { return region }, screen, &config)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if key != scenario.expectedKey || value != scenario.expectedValue {
+				t.Errorf("expected %s=%d, got %s=%d", scenario.expectedKey, scenario.expectedValue, key, value)
+			}
+		})
+	}
+}
+
+func TestMapEntriesWithSameLabel(t *testing.T) {
+	entries, label := mapEntries(map[int]string{3: "same", 1: "same", 2: "other"}, func(_ int, value string) string { return value })
+	var keys []int
+	for _, entry := range entries {
+		keys = append(keys, entry.key)
+		if label(entry) != entry.value {
+			t.Errorf("expected label %s, got %s", entry.value, label(entry))
+		}
+	}
+	if len(keys) != 3 || keys[0] != 2 || keys[1] != 1 || keys[2] != 3 {
+		t.Error("expected [2 1 3], got", keys)
+	}
+}
+
+func TestPickMapWithoutEntries(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatal("encountered error while creating simulation screen:", err)
+	}
+	defer screen.Fini()
+	_, _, err = pickMap("question", map[string]int{}, func(key string, _ int) string { return key }, screen, &config)
+	if !errors.Is(err, ErrNoChoice) {
+		t.Error("expected ErrNoChoice, got", err)
+	}
+}
+
+func TestTypedPickersWithAllowCustom(t *testing.T) {
+	scenarios := []struct {
+		name string
+		// pick returns the label of the item picked
+		pick func(screen tcell.Screen, config *Config) (string, error)
+	}{
+		{
+			name: "PickT",
+			pick: func(screen tcell.Screen, config *Config) (string, error) {
+				item, _, err := pickT("question", []string{"a", "b"}, func(item string) string { return item }, screen, config)
+				return item, err
+			},
+		},
+		{
+			name: "PickMap",
+			pick: func(screen tcell.Screen, config *Config) (string, error) {
+				key, _, err := pickMap("question", map[string]int{"a": 1, "b": 2}, func(key string, _ int) string { return key }, screen, config)
+				return key, err
+			},
+		},
+		{
+			name: "PickRich",
+			pick: func(screen tcell.Screen, config *Config) (string, error) {
+				item, _, err := pickRich("question", []Item{{Label: "a"}, {Label: "b"}}, screen, config)
+				return item.Label, err
+			},
+		},
+		{
+			name: "PickTable",
+			pick: func(screen tcell.Screen, config *Config) (string, error) {
+				row, _, err := pickTable("question", []string{"name"}, [][]string{{"a"}, {"b"}}, screen, config)
+				return strings.Join(row, ","), err
+			},
+		},
+		{
+			name: "PickGrouped",
+			pick: func(screen tcell.Screen, config *Config) (string, error) {
+				item, _, _, err := pickGrouped("question", []Group{{Name: "group", Items: []Item{{Label: "a"}, {Label: "b"}}}}, screen, config)
+				return item.Label, err
+			},
+		},
+		{
+			name: "PickMenu",
+			pick: func(screen tcell.Screen, config *Config) (string, error) {
+				shared := newSharedScreen(screen)
+				defer shared.stopListening()
+				item, _, err := runMenu(context.Background(), "question", []*MenuItem{{Label: "a"}, {Label: "b"}}, shared, []Option{OptionAllowCustom("Create")})
+				if err != nil {
+					return "", err
+				}
+				return item.Label, nil
+			},
+		},
+		{
+			name: "PickFromSpec",
+			pick: func(screen tcell.Screen, config *Config) (string, error) {
+				item, _, err := pickFromSpec(strings.NewReader(`{"question": "question", "items": [{"label": "a"}, {"label": "b"}]}`), FormatJSON, screen, config)
+				return item.Label, err
+			},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionAllowCustom("Create")(&config)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			// The search query can't be picked, since it isn't one of the items, so the first one is picked once it is deleted
+			SimulateKeys(screen, []tcell.Event{
+				tcell.NewEventKey(tcell.KeyRune, 'z', tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyBackspace2, 0, tcell.ModNone),
+				tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+			})
+			label, err := scenario.pick(screen, &config)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if label != "a" {
+				t.Error("expected a, got", label)
+			}
+		})
+	}
+}
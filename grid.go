@@ -0,0 +1,160 @@
+package gochoice
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// gridColumnGap is the minimum number of columns between the values of two choices displayed side by side in a grid
+const gridColumnGap = 2
+
+// computeGridColumns returns the number of columns of the grid in which the options are displayed in the given width,
+// and the width of each column. Unless the number of columns is set with OptionColumns, there are as many columns
+// as the widest option allows.
+func computeGridColumns(options []*Choice, width int, config *Config, state *promptState) (int, int) {
+	columns := config.Columns
+	if columns <= 0 {
+		widestOption := 0
+		for _, option := range options {
+			if optionWidth := runewidth.StringWidth(choicePrefix(option, config, state) + option.Value); optionWidth > widestOption && !option.header {
+				widestOption = optionWidth
+			}
+		}
+		columns = width / (widestOption + gridColumnGap)
+	}
+	if columns < 1 {
+		columns = 1
+	}
+	return columns, width / columns
+}
+
+// computeScreenGridColumns is like computeGridColumns, using the width in which the options are displayed on the screen
+func computeScreenGridColumns(screen tcell.Screen, options []*Choice, config *Config, state *promptState) (int, int) {
+	screenWidth, _ := screen.Size()
+	// The last column of the options is left for the scrollbar
+	return computeGridColumns(options, computeOptionsWidth(screenWidth, config)-1, config, state)
+}
+
+// renderGrid renders the options from the given scroll offset row by row, from lineNumber until maxLineNumber,
+// and returns the line that follows the grid and the index of the first option that wasn't rendered.
+// The first option of each row is the one recorded for its line in choicesByLine.
+func renderGrid(screen tcell.Screen, lineNumber, maxLineNumber int, options []*Choice, scrollOffset, width int, config *Config, state *promptState, choicesByLine []*Choice) (int, int) {
+	columns, columnWidth := computeGridColumns(options, width, config, state)
+	i := scrollOffset
+	for ; i < len(options) && lineNumber < maxLineNumber; lineNumber++ {
+		printText(screen, 0, lineNumber, "", config.Theme.background())
+		choicesByLine[lineNumber] = options[i]
+		for column := 0; column < columns && i < len(options); column++ {
+			cell := &regionScreen{Screen: screen, x: column * columnWidth, y: lineNumber, width: columnWidth, height: 1}
+			renderGridCell(cell, options[i], columnWidth-gridColumnGap, config, state)
+			i++
+		}
+	}
+	return lineNumber, i
+}
+
+// renderGridCell renders the option on the first line of the cell, cutting off its value if it is wider than the
+// given width, which leaves the rest of the cell empty so that it is separated from the next cell
+func renderGridCell(cell tcell.Screen, option *Choice, width int, config *Config, state *promptState) {
+	if option.header {
+		if option.separator {
+			printText(cell, 0, 0, " "+strings.Repeat(string(tcell.RuneHLine), width-1), config.Theme.Scrollbar)
+		} else {
+			printText(cell, 0, 0, " "+option.Value, config.Theme.Header)
+		}
+		return
+	}
+	prefix := choicePrefix(option, config, state)
+	value, valueTruncation := truncateText(option.Value, width-runewidth.StringWidth(prefix), TruncateEnd, defaultEllipsis)
+	highlightedPositions := offsetPositions(valueTruncation.shiftPositions(option.matchedPositions), len([]rune(prefix)))
+	style := choiceStyle(option, config, state)
+	printHighlightedText(cell, 0, 0, prefix+value, highlightedPositions, style, config.Theme.Match)
+	if option.ansiStyles != nil {
+		styleANSI(cell, runewidth.StringWidth(prefix), 0, value, valueTruncation.shiftStyles(option.ansiStyles), style)
+	}
+	if config.LineNumbers {
+		styleLineNumber(cell, 0, config, state)
+	}
+	if option.Hotkey != 0 {
+		underlineHotkey(cell, runewidth.StringWidth(prefix), 0, value, option.Hotkey)
+	}
+	// Clear the end of the cell, which the style of the option may have been applied to
+	printText(cell, runewidth.StringWidth(prefix+value), 0, "", config.Theme.background())
+}
+
+// computeGridScrollOffset returns the index of the first option to display in the grid so that the option
+// at selectedIndex is visible, scrolling whole rows at a time
+func computeGridScrollOffset(screen tcell.Screen, question string, options []*Choice, previousScrollOffset, selectedIndex int, config *Config, state *promptState) int {
+	columns, _ := computeScreenGridColumns(screen, options, config, state)
+	rows := (len(options) + columns - 1) / columns
+	pageSize := computePageSize(screen, question, config)
+	return computeScrollOffset(previousScrollOffset/columns, selectedIndex/columns, pageSize, rows, config.ScrollOffset) * columns
+}
+
+// moveInGrid selects the choice that is increment choices away from the selected choice, which is the choice in the
+// same column increment/columns rows away when increment is a multiple of the number of columns. If that choice
+// can't be selected, the next one that can in the direction of the move is selected instead, or failing that,
+// the closest one that can between the choice and the selected choice. The selection is left unchanged if there is none.
+func moveInGrid(choices []*Choice, increment int) *Choice {
+	selectedIndex := -1
+	for i, choice := range choices {
+		if choice.Selected && choice.selectable() {
+			selectedIndex = i
+			break
+		}
+	}
+	if selectedIndex < 0 {
+		return move(choices, 0)
+	}
+	target := selectedIndex + increment
+	if target < 0 {
+		target = 0
+	} else if target >= len(choices) {
+		target = len(choices) - 1
+	}
+	step := 1
+	if increment < 0 {
+		step = -1
+	}
+	for i := target; i >= 0 && i < len(choices); i += step {
+		if choices[i].selectable() {
+			return selectChoice(choices[selectedIndex], choices[i])
+		}
+	}
+	for i := target; i != selectedIndex; i -= step {
+		if choices[i].selectable() {
+			return selectChoice(choices[selectedIndex], choices[i])
+		}
+	}
+	return choices[selectedIndex]
+}
+
+// gridChoiceAt returns the choice displayed in the grid at the column x of the row starting with rowChoice,
+// or nil if there is none
+func gridChoiceAt(options []*Choice, rowChoice *Choice, x, columns, columnWidth int) *Choice {
+	column := x / columnWidth
+	if column >= columns {
+		return nil
+	}
+	for i, option := range options {
+		if option == rowChoice {
+			if i+column < len(options) {
+				return options[i+column]
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// OptionColumns displays the choices in a grid with the given number of columns, filled row by row, which suits
+// long lists of short choices. Left and Right move across the columns, and Up and Down across the rows.
+// If the number of columns is 0, there are as many columns as the width of the screen and of the choices allow.
+func OptionColumns(columns int) func(config *Config) {
+	return func(config *Config) {
+		config.Grid = true
+		config.Columns = columns
+	}
+}
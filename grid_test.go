@@ -0,0 +1,147 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRenderGrid(t *testing.T) {
+	config := defaultConfig
+	OptionColumns(3)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(19, 10)
+	choices := newChoices([]string{"a", "b", "c", "d", "e", "f", "g"})
+	choicesByLine := render(screen, "question", choices, &config, &promptState{}, choices[0], "", true, 0, choices)
+	screen.Show()
+	scenarios := []struct {
+		y            int
+		expectedLine string
+	}{
+		{y: 1, expectedLine: " > a     b     c"},
+		{y: 2, expectedLine: "   d     e     f"},
+		{y: 3, expectedLine: "   g            "},
+	}
+	for _, scenario := range scenarios {
+		var line []rune
+		for x := 0; x < len(scenario.expectedLine); x++ {
+			mainc, _, _, _ := screen.GetContent(x, scenario.y)
+			line = append(line, mainc)
+		}
+		if string(line) != scenario.expectedLine {
+			t.Errorf("expected line %d to be %q, got %q", scenario.y, scenario.expectedLine, string(line))
+		}
+	}
+	// Each line is associated with the first choice of its row
+	if choicesByLine[2] != choices[3] {
+		t.Errorf("expected line 2 to be associated with d, got %v", choicesByLine[2])
+	}
+}
+
+func TestComputeGridColumns(t *testing.T) {
+	scenarios := []struct {
+		name                string
+		columns             int
+		width               int
+		expectedColumns     int
+		expectedColumnWidth int
+	}{
+		{name: "fixed", columns: 2, width: 40, expectedColumns: 2, expectedColumnWidth: 20},
+		// The widest choice takes 3 columns for its prefix, 5 for its value and 2 for the gap
+		{name: "automatic", columns: 0, width: 40, expectedColumns: 4, expectedColumnWidth: 10},
+		{name: "automatic-narrow", columns: 0, width: 5, expectedColumns: 1, expectedColumnWidth: 5},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionColumns(scenario.columns)(&config)
+			choices := newChoices([]string{"a", "bcdef", "gh"})
+			columns, columnWidth := computeGridColumns(choices, scenario.width, &config, &promptState{})
+			if columns != scenario.expectedColumns || columnWidth != scenario.expectedColumnWidth {
+				t.Errorf("expected %d columns of width %d, got %d columns of width %d", scenario.expectedColumns, scenario.expectedColumnWidth, columns, columnWidth)
+			}
+		})
+	}
+}
+
+func TestMoveInGrid(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		selectedIndex int
+		increment     int
+		expectedValue string
+	}{
+		{name: "down", selectedIndex: 1, increment: 3, expectedValue: "E"},
+		{name: "up", selectedIndex: 4, increment: -3, expectedValue: "B"},
+		{name: "down-past-last-row", selectedIndex: 5, increment: 3, expectedValue: "G"},
+		{name: "up-past-first-row", selectedIndex: 2, increment: -3, expectedValue: "B"},
+		{name: "up-onto-disabled-choice", selectedIndex: 6, increment: -3, expectedValue: "C"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			// The first choice and the fourth choice are disabled
+			choices := newChoices([]string{"A", "B", "C", "D", "E", "F", "G"})
+			choices[0].Disabled, choices[3].Disabled = true, true
+			for i, choice := range choices {
+				choice.Selected = i == scenario.selectedIndex
+			}
+			selectedChoice := moveInGrid(choices, scenario.increment)
+			if selectedChoice == nil || selectedChoice.Value != scenario.expectedValue {
+				t.Fatalf("expected %s, got %v", scenario.expectedValue, selectedChoice)
+			}
+		})
+	}
+}
+
+func TestPickWithColumns(t *testing.T) {
+	config := defaultConfig
+	OptionColumns(3)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyRight, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyRight, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyLeft, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := pick("question", []string{"A", "B", "C", "D", "E", "F", "G"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "E" {
+		t.Error("expected E, got", choice)
+	}
+	if index != 4 {
+		t.Error("expected 4, got", index)
+	}
+}
+
+func TestPickWithColumnsAndMouseClick(t *testing.T) {
+	config := defaultConfig
+	OptionColumns(2)(&config)
+	OptionMouse()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	// The screen is 80 columns wide, so the second column of the grid starts at x=39
+	screen.InjectMouse(45, 2, tcell.Button1, tcell.ModNone)
+	screen.InjectMouse(45, 2, tcell.ButtonNone, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B", "C", "D"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "D" {
+		t.Error("expected D, got", choice)
+	}
+}
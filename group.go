@@ -0,0 +1,76 @@
+package gochoice
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Group is a set of items displayed under a header that cannot be selected
+type Group struct {
+	// Name is the text displayed in the header of the group
+	Name string
+
+	// Items are the items that belong to the group
+	Items []Item
+}
+
+// groupedItem is the data of a choice created from an item that belongs to a group
+type groupedItem struct {
+	item       Item
+	groupIndex int
+	itemIndex  int
+}
+
+// PickGrouped prompts the user to choose an item from a list of groups.
+// It returns the item selected, the index of its group and its index within that group.
+func PickGrouped(question string, groups []Group, options ...Option) (Item, int, int, error) {
+	choices := newChoicesFromGroups(groups)
+	if len(choices) == len(groups) {
+		// There are no items in any of the groups, only headers
+		return Item{}, 0, 0, ErrNoChoice
+	}
+	return toGroupedItem(runPicker(context.Background(), question, choices, withoutCustomChoice(newConfig(options))))
+}
+
+func pickGrouped(question string, groups []Group, screen tcell.Screen, config *Config) (Item, int, int, error) {
+	choices := newChoicesFromGroups(groups)
+	if len(choices) == len(groups) {
+		// There are no items in any of the groups, only headers
+		return Item{}, 0, 0, ErrNoChoice
+	}
+	return toGroupedItem(pickChoices(context.Background(), question, choices, screen, withoutCustomChoice(config)))
+}
+
+// newChoicesFromGroups creates a header choice for each group, followed by a choice for each of its items
+func newChoicesFromGroups(groups []Group) []*Choice {
+	var choices []*Choice
+	for groupIndex, group := range groups {
+		choices = append(choices, &Choice{Id: -1, Value: group.Name, header: true})
+		for itemIndex, item := range group.Items {
+			choices = append(choices, &Choice{
+				Id:          len(choices) - groupIndex - 1,
+				Value:       item.Label,
+				value:       item.Value,
+				Description: item.Description,
+				Disabled:    item.Disabled,
+				Style:       item.Style,
+				Hotkey:      item.Hotkey,
+				Icon:        item.Icon,
+				URL:         item.URL,
+				Dangerous:   item.Dangerous,
+				Data:        groupedItem{item: item, groupIndex: groupIndex, itemIndex: itemIndex},
+			})
+		}
+	}
+	return choices
+}
+
+// toGroupedItem returns the item of the first choice selected, the index of its group and its index within that group
+func toGroupedItem(selectedChoices []*Choice, err error) (Item, int, int, error) {
+	if err != nil {
+		return Item{}, 0, 0, err
+	}
+	selectedItem := selectedChoices[0].Data.(groupedItem)
+	return selectedItem.item, selectedItem.groupIndex, selectedItem.itemIndex, nil
+}
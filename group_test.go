@@ -0,0 +1,110 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+var testGroups = []Group{
+	{Name: "Production", Items: []Item{{Label: "prod-us"}, {Label: "prod-eu"}}},
+	{Name: "Staging", Items: []Item{{Label: "staging-us"}, {Label: "staging-eu"}}},
+}
+
+func TestPickGrouped(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	// The headers are skipped, so the second down goes from prod-eu to staging-us
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	item, groupIndex, itemIndex, err := pickGrouped("question", testGroups, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "staging-us" {
+		t.Error("expected staging-us, got", item.Label)
+	}
+	if groupIndex != 1 || itemIndex != 0 {
+		t.Errorf("expected group 1 and item 0, got group %d and item %d", groupIndex, itemIndex)
+	}
+}
+
+func TestPickGroupedWithSearch(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, 'e', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'u', tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	item, groupIndex, itemIndex, err := pickGrouped("question", testGroups, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "staging-eu" {
+		t.Error("expected staging-eu, got", item.Label)
+	}
+	if groupIndex != 1 || itemIndex != 1 {
+		t.Errorf("expected group 1 and item 1, got group %d and item %d", groupIndex, itemIndex)
+	}
+}
+
+func TestPickGroupedWithDefaultIndex(t *testing.T) {
+	config := defaultConfig
+	// The default index refers to the position of the item across all groups, excluding headers
+	OptionDefaultIndex(2)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	item, _, _, err := pickGrouped("question", testGroups, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "staging-us" {
+		t.Error("expected staging-us, got", item.Label)
+	}
+}
+
+func TestPickGroupedWithoutItems(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	_, _, _, err = pickGrouped("question", []Group{{Name: "Empty"}}, screen, &config)
+	if err != ErrNoChoice {
+		t.Error("expected ErrNoChoice, got", err)
+	}
+}
+
+func TestFilterChoicesHidesEmptyGroups(t *testing.T) {
+	config := defaultConfig
+	choices := []*Choice{
+		{Id: -1, Value: "Production", header: true},
+		{Id: 0, Value: "prod-us"},
+		{Id: -1, Value: "Staging", header: true},
+		{Id: 1, Value: "staging-us"},
+	}
+	visibleChoices := filterChoices(choices, "stag", &config, &promptState{})
+	if len(visibleChoices) != 2 || visibleChoices[0] != choices[2] || visibleChoices[1] != choices[3] {
+		t.Error("expected only the Staging header and staging-us to be visible")
+	}
+}
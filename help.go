@@ -0,0 +1,169 @@
+package gochoice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// keyHintSeparator is the separator between the hints of the key hint bar
+const keyHintSeparator = " · "
+
+// helpEntry is a line of the help overlay, describing what the keys bound to an action do
+type helpEntry struct {
+	keys        []Key
+	description string
+}
+
+// helpEntries returns the entries of the help overlay for the actions available with the given config.
+// Actions with no keys bound to them are left out.
+func helpEntries(config *Config) []helpEntry {
+	keyMap := config.KeyMap
+	entries := []helpEntry{
+		{keyMap.Up, "move up"},
+		{keyMap.Down, "move down"},
+		{keyMap.PageUp, "move a page up"},
+		{keyMap.PageDown, "move a page down"},
+		{keyMap.HalfPageUp, "move half a page up"},
+		{keyMap.HalfPageDown, "move half a page down"},
+		{keyMap.Home, "go to the first choice"},
+		{keyMap.End, "go to the last choice"},
+	}
+	if config.Grid {
+		entries = append(entries, helpEntry{keyMap.Left, "move left"}, helpEntry{keyMap.Right, "move right"})
+	} else if !config.Wrap {
+		entries = append(entries, helpEntry{keyMap.ScrollLeft, "scroll left"}, helpEntry{keyMap.ScrollRight, "scroll right"})
+	}
+	if config.tree {
+		entries = append(entries, helpEntry{keyMap.Expand, "expand"}, helpEntry{keyMap.Collapse, "collapse"})
+	}
+	if config.multiSelect {
+		entries = append(entries,
+			helpEntry{keyMap.ToggleSelect, "check or uncheck"},
+			helpEntry{keyMap.CheckAll, "check all the choices displayed"},
+			helpEntry{keyMap.UncheckAll, "uncheck all the choices displayed"},
+			helpEntry{keyMap.InvertChecks, "invert the choices checked among those displayed"},
+			helpEntry{keyMap.Confirm, "confirm the checked choices"},
+		)
+	} else {
+		entries = append(entries, helpEntry{keyMap.Confirm, "select"})
+	}
+	if !config.WithoutSearch {
+		if config.VimBindings {
+			entries = append(entries, helpEntry{keyMap.Search, "search"})
+		}
+		entries = append(entries, helpEntry{keyMap.DeleteChar, "delete the last character of the search"})
+	}
+	entries = append(entries, helpEntry{keyMap.Copy, "copy the selected choice to the clipboard"})
+	if config.backAllowed {
+		entries = append(entries, helpEntry{keyMap.Back, "go back to the previous step"})
+	}
+	if config.Suspend {
+		entries = append(entries, helpEntry{keyMap.Suspend, "suspend"})
+	}
+	if config.submenu {
+		entries = append(entries, helpEntry{keyMap.Back, "go back to the parent menu"})
+	}
+	entries = append(entries, helpEntry{keyMap.Abort, "cancel"})
+	entries = append(entries, helpEntry{keyMap.Help, "show or hide this help"})
+	var availableEntries []helpEntry
+	for _, entry := range entries {
+		if len(entry.keys) > 0 {
+			availableEntries = append(availableEntries, entry)
+		}
+	}
+	return availableEntries
+}
+
+// keyHints returns a single line describing the main keys of the given config, e.g. "↑/↓ move · Enter select · Esc cancel"
+func keyHints(config *Config) string {
+	keyMap := config.KeyMap
+	var hints []string
+	if len(keyMap.Up) > 0 && len(keyMap.Down) > 0 {
+		hints = append(hints, keyName(keyMap.Up[0])+"/"+keyName(keyMap.Down[0])+" move")
+	}
+	if config.multiSelect && len(keyMap.ToggleSelect) > 0 {
+		hints = append(hints, keyName(keyMap.ToggleSelect[0])+" check")
+	}
+	if len(keyMap.Confirm) > 0 {
+		hints = append(hints, keyName(keyMap.Confirm[0])+" select")
+	}
+	if config.VimBindings && !config.WithoutSearch && len(keyMap.Search) > 0 {
+		hints = append(hints, keyName(keyMap.Search[0])+" search")
+	}
+	if len(keyMap.Back) > 0 && config.submenu {
+		hints = append(hints, keyName(keyMap.Back[0])+" back")
+	}
+	if len(keyMap.Abort) > 0 {
+		hints = append(hints, keyName(keyMap.Abort[0])+" cancel")
+	}
+	if len(keyMap.Help) > 0 {
+		hints = append(hints, keyName(keyMap.Help[0])+" help")
+	}
+	return strings.Join(hints, keyHintSeparator)
+}
+
+// keyName returns the name of the key as displayed in the help
+func keyName(key Key) string {
+	switch key.Key {
+	case tcell.KeyRune:
+		if key.Rune == ' ' {
+			return "Space"
+		}
+		return string(key.Rune)
+	case tcell.KeyUp:
+		return "↑"
+	case tcell.KeyDown:
+		return "↓"
+	case tcell.KeyLeft:
+		return "←"
+	case tcell.KeyRight:
+		return "→"
+	}
+	if name, ok := tcell.KeyNames[key.Key]; ok {
+		return name
+	}
+	return fmt.Sprintf("Key[%d]", key.Key)
+}
+
+// renderHelp renders the help overlay over the whole screen
+func renderHelp(screen tcell.Screen, config *Config) {
+	_, screenHeight := screen.Size()
+	entries := helpEntries(config)
+	keysByEntry := make([]string, len(entries))
+	keysWidth := 0
+	for i, entry := range entries {
+		var names []string
+		for _, key := range entry.keys {
+			names = append(names, keyName(key))
+		}
+		keysByEntry[i] = strings.Join(names, ", ")
+		if width := runewidth.StringWidth(keysByEntry[i]); width > keysWidth {
+			keysWidth = width
+		}
+	}
+	printText(screen, 0, 0, " Keys", config.Theme.Question)
+	lineNumber := 1
+	for i, entry := range entries {
+		if lineNumber >= screenHeight-1 {
+			break
+		}
+		padding := strings.Repeat(" ", keysWidth-runewidth.StringWidth(keysByEntry[i]))
+		printText(screen, 0, lineNumber, fmt.Sprintf("   %s%s  %s", keysByEntry[i], padding, entry.description), config.Theme.Item)
+		lineNumber++
+	}
+	for ; lineNumber < screenHeight-1; lineNumber++ {
+		printText(screen, 0, lineNumber, "", config.Theme.Item)
+	}
+	printText(screen, 1, screenHeight-1, "Press any key to close the help", config.Theme.Description)
+}
+
+// OptionKeyHints displays a line describing the main keys above the search bar, below the footer if there is one.
+// The hints are generated from the KeyMap, and the full list of keys is displayed with the keys bound to KeyMap.Help.
+func OptionKeyHints() func(config *Config) {
+	return func(config *Config) {
+		config.KeyHints = true
+	}
+}
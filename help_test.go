@@ -0,0 +1,108 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickWithHelp(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyF1, 0, tcell.ModNone)
+	go func() {
+		waitForText(t, screen, "show or hide this help")
+		// The first key closes the help instead of aborting
+		screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}()
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+}
+
+func TestPickWithQuestionMarkInSearchQuery(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	// ? is part of the query rather than showing the help
+	screen.InjectKey(tcell.KeyRune, '?', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"why", "what?"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "what?" {
+		t.Error("expected what?, got", choice)
+	}
+}
+
+func TestPickWithVimBindingsHelp(t *testing.T) {
+	config := newConfig([]Option{OptionVimBindings()})
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyRune, '?', tcell.ModNone)
+	go func() {
+		waitForText(t, screen, "show or hide this help")
+		screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}()
+	choice, _, err := pick("question", []string{"A", "B"}, screen, config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "A" {
+		t.Error("expected A, got", choice)
+	}
+}
+
+func TestKeyHints(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		options       []Option
+		multiSelect   bool
+		submenu       bool
+		expectedHints string
+	}{
+		{name: "default", expectedHints: "↑/↓ move · Enter select · Esc cancel · F1 help"},
+		{name: "multi-select", multiSelect: true, expectedHints: "↑/↓ move · Space check · Enter select · Esc cancel · F1 help"},
+		{name: "vim-bindings", options: []Option{OptionVimBindings()}, expectedHints: "↑/↓ move · Enter select · / search · Esc cancel · F1 help"},
+		{name: "custom-keymap", options: []Option{OptionKeyMap(KeyMap{Confirm: []Key{{Key: tcell.KeyTab}}, Abort: []Key{{Key: tcell.KeyCtrlQ}}})}, expectedHints: "Tab select · Ctrl-Q cancel"},
+		{name: "submenu", submenu: true, expectedHints: "↑/↓ move · Enter select · Esc back · Ctrl-C cancel · F1 help"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := newConfig(scenario.options)
+			config.multiSelect = scenario.multiSelect
+			if scenario.submenu {
+				config.submenu, config.KeyMap = true, submenuKeyMap(config.KeyMap)
+			}
+			if hints := keyHints(config); hints != scenario.expectedHints {
+				t.Errorf("expected %q, got %q", scenario.expectedHints, hints)
+			}
+		})
+	}
+}
+
+func TestHelpEntries(t *testing.T) {
+	config := newConfig([]Option{OptionKeyMap(KeyMap{Down: []Key{{Key: tcell.KeyDown}}, Abort: []Key{{Key: tcell.KeyEscape}}})})
+	entries := helpEntries(config)
+	if len(entries) != 2 || entries[0].description != "move down" || entries[1].description != "cancel" {
+		t.Error("expected only the actions with keys bound to them, got", entries)
+	}
+}
@@ -0,0 +1,85 @@
+package gochoice
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// horizontalScrollStep is the number of columns by which the choices are scrolled horizontally at once
+const horizontalScrollStep = 4
+
+// scrollText cuts off the start of the text so that it begins offset columns further, and replaces what was cut off
+// by the ellipsis. It returns the scrolled text along with which runes were replaced.
+func scrollText(text string, offset int, ellipsis string) (string, truncation) {
+	if offset <= 0 {
+		return text, truncation{}
+	}
+	droppedWidth, droppedRunes := 0, 0
+	graphemes := uniseg.NewGraphemes(text)
+	for droppedWidth < offset && graphemes.Next() {
+		droppedWidth += runewidth.StringWidth(graphemes.Str())
+		droppedRunes += len(graphemes.Runes())
+	}
+	runes := []rune(text)
+	return ellipsis + string(runes[droppedRunes:]), truncation{start: 0, end: droppedRunes, ellipsisLength: len([]rune(ellipsis))}
+}
+
+// computeMaxHorizontalOffset returns the offset past which scrolling the options horizontally would not reveal
+// anything more, given the width in which they are displayed. Options that are wrapped, laid out in a grid
+// or drawn by an ItemRenderer are never scrolled.
+func computeMaxHorizontalOffset(options []*Choice, width int, config *Config, state *promptState) int {
+	if config.Wrap || config.Grid || config.ItemRenderer != nil {
+		return 0
+	}
+	maxOffset := 0
+	for _, option := range options {
+		if option.header {
+			continue
+		}
+		if overflow := optionOverflow(option, width, config, state); overflow > maxOffset {
+			maxOffset = overflow
+		}
+	}
+	return maxOffset
+}
+
+// optionOverflow returns the number of columns by which the option, once scrolled, overflows the width
+func optionOverflow(option *Choice, width int, config *Config, state *promptState) int {
+	value := option.Value
+	if option.custom {
+		value = customChoiceText(option, config)
+	}
+	// Once scrolled, the start of the value is replaced by the ellipsis
+	return runewidth.StringWidth(choicePrefix(option, config, state)+value+defaultEllipsis) - width
+}
+
+// clampHorizontalOffset makes sure the options aren't scrolled horizontally further than needed to reveal
+// the end of the longest one, which may have changed since they were scrolled
+func clampHorizontalOffset(screen tcell.Screen, options []*Choice, config *Config, state *promptState) {
+	if state.horizontalOffset <= 0 {
+		// Measuring every option, of which there may be millions, is only needed once they are scrolled
+		state.horizontalOffset = 0
+		return
+	}
+	screenWidth, _ := screen.Size()
+	// The last column of the options is left for the scrollbar
+	width := computeOptionsWidth(screenWidth, config) - 1
+	if !overflowsBy(options, width, state.horizontalOffset, config, state) {
+		state.horizontalOffset = computeMaxHorizontalOffset(options, width, config, state)
+	}
+}
+
+// overflowsBy reports whether one of the options overflows the width by at least the given number of columns, which
+// stops at the first one that does rather than measuring all of them like computeMaxHorizontalOffset
+func overflowsBy(options []*Choice, width, columns int, config *Config, state *promptState) bool {
+	if config.Wrap || config.Grid || config.ItemRenderer != nil {
+		return columns <= 0
+	}
+	for _, option := range options {
+		if !option.header && optionOverflow(option, width, config, state) >= columns {
+			return true
+		}
+	}
+	return false
+}
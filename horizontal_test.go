@@ -0,0 +1,138 @@
+package gochoice
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestScrollText(t *testing.T) {
+	scenarios := []struct {
+		name                     string
+		text                     string
+		offset                   int
+		positions                []int
+		expectedText             string
+		expectedShiftedPositions []int
+	}{
+		{name: "not-scrolled", text: "abcdef", offset: 0, positions: []int{0, 3}, expectedText: "abcdef", expectedShiftedPositions: []int{0, 3}},
+		{name: "scrolled", text: "abcdef", offset: 2, positions: []int{0, 3}, expectedText: "…cdef", expectedShiftedPositions: []int{2}},
+		{name: "scrolled-past-end", text: "ab", offset: 4, positions: []int{1}, expectedText: "…", expectedShiftedPositions: nil},
+		{name: "wide-characters", text: "日本語です", offset: 3, positions: []int{2}, expectedText: "…語です", expectedShiftedPositions: []int{1}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			text, scroll := scrollText(scenario.text, scenario.offset, "…")
+			if text != scenario.expectedText {
+				t.Errorf("expected %q, got %q", scenario.expectedText, text)
+			}
+			if shiftedPositions := scroll.shiftPositions(scenario.positions); !reflect.DeepEqual(shiftedPositions, scenario.expectedShiftedPositions) {
+				t.Errorf("expected positions %v, got %v", scenario.expectedShiftedPositions, shiftedPositions)
+			}
+		})
+	}
+}
+
+func TestComputeMaxHorizontalOffset(t *testing.T) {
+	config := defaultConfig
+	choices := newChoices([]string{"short", "abcdefghijklmnopqrstuvwxyz"})
+	// The prefix takes 3 columns and the ellipsis 1, so 30 columns are needed to display the end of the second choice
+	if offset := computeMaxHorizontalOffset(choices, 20, &config, &promptState{}); offset != 10 {
+		t.Errorf("expected 10, got %d", offset)
+	}
+	if offset := computeMaxHorizontalOffset(choices, 40, &config, &promptState{}); offset != 0 {
+		t.Errorf("expected 0, got %d", offset)
+	}
+	OptionWrap()(&config)
+	if offset := computeMaxHorizontalOffset(choices, 20, &config, &promptState{}); offset != 0 {
+		t.Errorf("expected wrapped choices not to be scrolled, got %d", offset)
+	}
+}
+
+func TestClampHorizontalOffset(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		offset         int
+		expectedOffset int
+	}{
+		{name: "not-scrolled", offset: 0, expectedOffset: 0},
+		{name: "negative", offset: -4, expectedOffset: 0},
+		{name: "within-longest-choice", offset: 8, expectedOffset: 8},
+		{name: "past-longest-choice", offset: 40, expectedOffset: 25},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			state := &promptState{horizontalOffset: scenario.offset}
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			// The prefix takes 3 columns and the ellipsis 1, so the second choice overflows the 79 columns of the options,
+			// the last one being left for the scrollbar, by 25 columns
+			choices := newChoices([]string{"short", strings.Repeat("a", 100)})
+			clampHorizontalOffset(screen, choices, &config, state)
+			if state.horizontalOffset != scenario.expectedOffset {
+				t.Errorf("expected %d, got %d", scenario.expectedOffset, state.horizontalOffset)
+			}
+		})
+	}
+}
+
+func TestRenderScrolledHorizontally(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	choices := newChoices([]string{"abcdefghijklmnopqrstuvwxyz"})
+	render(screen, "question", choices, &config, &promptState{horizontalOffset: 4}, choices[0], "", true, 0, choices)
+	screen.Show()
+	// The prefix isn't scrolled
+	expectedLine := " > …efghijklmnopq"
+	var line []rune
+	for x := 0; x < len([]rune(expectedLine)); x++ {
+		mainc, _, _, _ := screen.GetContent(x, 1)
+		line = append(line, mainc)
+	}
+	if string(line) != expectedLine {
+		t.Errorf("expected %q, got %q", expectedLine, string(line))
+	}
+}
+
+func TestPickScrolledHorizontally(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	screen.InjectKey(tcell.KeyCtrlF, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyCtrlF, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyCtrlF, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyCtrlB, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"abcdefghijklmnopqrstuvwxyz"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "abcdefghijklmnopqrstuvwxyz" {
+		t.Error("expected the choice not to be affected by scrolling, got", choice)
+	}
+	// Scrolling stops once the end of the choice is visible, at an offset of 7, so scrolling back starts from there
+	expectedLine := " > …hijklmnopqrs"
+	var line []rune
+	for x := 0; x < len([]rune(expectedLine)); x++ {
+		mainc, _, _, _ := screen.GetContent(x, 1)
+		line = append(line, mainc)
+	}
+	if string(line) != expectedLine {
+		t.Errorf("expected %q, got %q", expectedLine, string(line))
+	}
+}
@@ -0,0 +1,124 @@
+package gochoice
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// supportsHyperlinks reports whether the terminal is known to support hyperlinks written with OSC 8 sequences.
+// Terminals that don't would display the sequences instead of ignoring them, so they are only written to terminals
+// identified by their environment variables, or if FORCE_HYPERLINK is set to 1.
+func supportsHyperlinks() bool {
+	if forced, ok := os.LookupEnv("FORCE_HYPERLINK"); ok {
+		return forced == "1"
+	}
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty":
+		return true
+	}
+	if len(os.Getenv("WT_SESSION")) > 0 || len(os.Getenv("KITTY_WINDOW_ID")) > 0 || len(os.Getenv("DOMTERM")) > 0 {
+		return true
+	}
+	if version, err := strconv.Atoi(os.Getenv("VTE_VERSION")); err == nil && version >= 5000 {
+		return true
+	}
+	term := os.Getenv("TERM")
+	return strings.HasPrefix(term, "xterm-kitty") || strings.HasPrefix(term, "foot") || strings.HasPrefix(term, "alacritty")
+}
+
+// renderHyperlinks turns the values of the choices that have a URL into hyperlinks by writing them to the terminal
+// again, wrapped in OSC 8 sequences. Since tcell can't write these sequences, this must be done once the screen has
+// been shown. The cursor is saved and restored around them, so that tcell's idea of where it is remains correct.
+// The screen is drawn at (offsetX, offsetY) in the terminal.
+func renderHyperlinks(tty io.Writer, screen tcell.Screen, choicesByLine []*Choice, offsetX, offsetY int, config *Config, state *promptState) {
+	if config.Grid || config.ItemRenderer != nil {
+		return
+	}
+	screenWidth, _ := screen.Size()
+	// The last column of the options is left for the scrollbar
+	optionsWidth := computeOptionsWidth(screenWidth, config) - 1
+	var output strings.Builder
+	for y, choice := range choicesByLine {
+		if choice == nil || len(choice.URL) == 0 || (y > 0 && choicesByLine[y-1] == choice) {
+			// Only the first line of a wrapped choice is a hyperlink
+			continue
+		}
+		if containsControlCharacters(choice.URL) {
+			// The URL could end the OSC 8 sequence and write anything to the terminal
+			continue
+		}
+		start := runewidth.StringWidth(choicePrefix(choice, config, state))
+		end := start + runewidth.StringWidth(choice.Value)
+		if end > optionsWidth {
+			end = optionsWidth
+		}
+		if start >= end {
+			continue
+		}
+		fmt.Fprintf(&output, "\x1b7\x1b[%d;%dH\x1b]8;;%s\x1b\\", offsetY+y+1, offsetX+start+1, choice.URL)
+		for x := start; x < end; {
+			mainc, combc, style, width := screen.GetContent(x, y)
+			output.WriteString(sgr(style))
+			output.WriteString(string(append([]rune{mainc}, combc...)))
+			if width < 1 {
+				width = 1
+			}
+			x += width
+		}
+		output.WriteString("\x1b]8;;\x1b\\\x1b8")
+	}
+	if output.Len() > 0 {
+		_, _ = io.WriteString(tty, output.String())
+	}
+}
+
+// containsControlCharacters reports whether the given string contains a C0 control character or DEL
+func containsControlCharacters(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 0x20 || s[i] == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// sgrAttributes are the parameters of SGR sequences enabling bold, dim, italic, underline, blink, reverse
+// and strikethrough, in that order
+var sgrAttributes = []string{"1", "2", "3", "4", "5", "7", "9"}
+
+// sgr returns the SGR sequence setting the colors and the attributes of the given style
+func sgr(style tcell.Style) string {
+	foreground, background, attributes := style.Decompose()
+	parameters := []string{"0"}
+	for i, attribute := range []tcell.AttrMask{tcell.AttrBold, tcell.AttrDim, tcell.AttrItalic, tcell.AttrUnderline, tcell.AttrBlink, tcell.AttrReverse, tcell.AttrStrikeThrough} {
+		if attributes&attribute != 0 {
+			parameters = append(parameters, sgrAttributes[i])
+		}
+	}
+	if color := sgrColor(foreground); len(color) > 0 {
+		parameters = append(parameters, "38;"+color)
+	}
+	if color := sgrColor(background); len(color) > 0 {
+		parameters = append(parameters, "48;"+color)
+	}
+	return "\x1b[" + strings.Join(parameters, ";") + "m"
+}
+
+// sgrColor returns the parameters of an SGR sequence selecting the given color after 38 or 48,
+// or an empty string for the default color
+func sgrColor(color tcell.Color) string {
+	if color == tcell.ColorDefault || !color.Valid() {
+		return ""
+	}
+	if color&tcell.ColorIsRGB == 0 && color-tcell.ColorValid < 256 {
+		return fmt.Sprintf("5;%d", color-tcell.ColorValid)
+	}
+	r, g, b := color.RGB()
+	return fmt.Sprintf("2;%d;%d;%d", r, g, b)
+}
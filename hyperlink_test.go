@@ -0,0 +1,109 @@
+package gochoice
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSupportsHyperlinks(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		env      map[string]string
+		expected bool
+	}{
+		{name: "unknown-terminal", env: map[string]string{"TERM": "xterm-256color"}, expected: false},
+		{name: "iterm", env: map[string]string{"TERM_PROGRAM": "iTerm.app"}, expected: true},
+		{name: "vte", env: map[string]string{"VTE_VERSION": "6003"}, expected: true},
+		{name: "old-vte", env: map[string]string{"VTE_VERSION": "4601"}, expected: false},
+		{name: "forced", env: map[string]string{"FORCE_HYPERLINK": "1"}, expected: true},
+		{name: "disabled", env: map[string]string{"FORCE_HYPERLINK": "0", "TERM_PROGRAM": "WezTerm"}, expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			for _, name := range []string{"FORCE_HYPERLINK", "TERM_PROGRAM", "WT_SESSION", "KITTY_WINDOW_ID", "DOMTERM", "VTE_VERSION", "TERM"} {
+				t.Setenv(name, scenario.env[name])
+				if _, ok := scenario.env[name]; !ok {
+					_ = os.Unsetenv(name)
+				}
+			}
+			if supported := supportsHyperlinks(); supported != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, supported)
+			}
+		})
+	}
+}
+
+func TestSGR(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		style    tcell.Style
+		expected string
+	}{
+		{name: "default", style: tcell.StyleDefault, expected: "\x1b[0m"},
+		{name: "bold-and-palette", style: tcell.StyleDefault.Bold(true).Foreground(tcell.ColorRed), expected: "\x1b[0;1;38;5;9m"},
+		{name: "rgb-background", style: tcell.StyleDefault.Background(tcell.NewHexColor(0x282a36)), expected: "\x1b[0;48;2;40;42;54m"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if sequence := sgr(scenario.style); sequence != scenario.expected {
+				t.Errorf("expected %q, got %q", scenario.expected, sequence)
+			}
+		})
+	}
+}
+
+func TestRenderHyperlinks(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	choices := newChoicesFromItems([]Item{{Label: "#1"}, {Label: "#2", URL: "https://example.com/2"}})
+	choicesByLine := render(screen, "question", choices, &config, &promptState{}, nil, "", true, 0, choices)
+	screen.Show()
+	var tty bytes.Buffer
+	renderHyperlinks(&tty, screen, choicesByLine, 0, 0, &config, &promptState{})
+	// The label of the second item is on the third line, after the prefix
+	expected := "\x1b7\x1b[3;4H\x1b]8;;https://example.com/2\x1b\\"
+	if output := tty.String(); !strings.HasPrefix(output, expected) || !strings.Contains(output, "#") || !strings.HasSuffix(output, "\x1b]8;;\x1b\\\x1b8") {
+		t.Errorf("expected a hyperlink starting with %q, got %q", expected, output)
+	}
+	if strings.Count(tty.String(), "\x1b]8;;https://") != 1 {
+		t.Errorf("expected only the item with a URL to be a hyperlink, got %q", tty.String())
+	}
+}
+
+func TestRenderHyperlinksWithControlCharacters(t *testing.T) {
+	scenarios := []struct {
+		name string
+		url  string
+	}{
+		{name: "escape", url: "https://example.com/\x1b\\\x1b[2J"},
+		{name: "bell", url: "https://example.com/\x07"},
+		{name: "newline", url: "https://example.com/\n"},
+		{name: "delete", url: "https://example.com/\x7f"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			choices := newChoicesFromItems([]Item{{Label: "#1", URL: scenario.url}})
+			choicesByLine := render(screen, "question", choices, &config, &promptState{}, nil, "", true, 0, choices)
+			screen.Show()
+			var tty bytes.Buffer
+			renderHyperlinks(&tty, screen, choicesByLine, 0, 0, &config, &promptState{})
+			if tty.Len() > 0 {
+				t.Errorf("expected the URL to be skipped, got %q", tty.String())
+			}
+		})
+	}
+}
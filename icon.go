@@ -0,0 +1,82 @@
+package gochoice
+
+import (
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// IconMode is the variant of the icons displayed before the choices
+type IconMode int
+
+const (
+	// IconsUnicode displays the Unicode variant of the icons, e.g. emoji, which most terminals can display
+	IconsUnicode IconMode = iota
+
+	// IconsNerdFont displays the Nerd Font variant of the icons, which requires a font patched by Nerd Fonts.
+	// Icons without a Nerd Font variant fall back to their Unicode variant.
+	IconsNerdFont
+
+	// IconsNone hides the icons
+	IconsNone
+)
+
+// iconColumnWidth is the number of columns the icons are padded to, so that the labels after them are aligned
+// whether the icons are wide characters, like most emoji, or not
+const iconColumnWidth = 2
+
+// Icon is a glyph displayed before the label of an item, in the variant set by Theme.Icons
+type Icon struct {
+	// Unicode is the variant displayed with IconsUnicode, e.g. "📁"
+	Unicode string
+
+	// NerdFont is the variant displayed with IconsNerdFont, e.g. "\uf07b"
+	NerdFont string
+}
+
+// IconFolder and IconFile are the icons of the directories and of the files of file pickers
+var (
+	IconFolder = Icon{Unicode: "📁", NerdFont: "\uf07b"}
+	IconFile   = Icon{Unicode: "📄", NerdFont: "\uf15b"}
+)
+
+// OptionIcons sets the variant of the icons displayed before the items that have one, e.g. IconsNerdFont
+// for terminals using a font patched by Nerd Fonts, or IconsNone to hide them
+func OptionIcons(mode IconMode) func(config *Config) {
+	return func(config *Config) {
+		config.Theme.Icons = mode
+	}
+}
+
+// glyph returns the variant of the icon displayed with the given mode, or an empty string if there is none
+func (icon Icon) glyph(mode IconMode) string {
+	switch mode {
+	case IconsNone:
+		return ""
+	case IconsNerdFont:
+		if len(icon.NerdFont) > 0 {
+			return icon.NerdFont
+		}
+	}
+	return icon.Unicode
+}
+
+// hasIcons reports whether any of the given choices has an icon displayed with the given mode
+func hasIcons(choices []*Choice, mode IconMode) bool {
+	for _, choice := range choices {
+		if len(choice.Icon.glyph(mode)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// iconPrefix returns the icon of the choice padded to iconColumnWidth and followed by a space, or spaces as wide
+// if it has none, so that the labels of choices with and without icons are aligned
+func iconPrefix(choice *Choice, config *Config) string {
+	glyph := choice.Icon.glyph(config.Theme.Icons)
+	if width := runewidth.StringWidth(glyph); width < iconColumnWidth {
+		glyph += strings.Repeat(" ", iconColumnWidth-width)
+	}
+	return glyph + " "
+}
@@ -0,0 +1,72 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestIcon_glyph(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		icon          Icon
+		mode          IconMode
+		expectedGlyph string
+	}{
+		{name: "unicode", icon: IconFolder, mode: IconsUnicode, expectedGlyph: "📁"},
+		{name: "nerd-font", icon: IconFolder, mode: IconsNerdFont, expectedGlyph: "\uf07b"},
+		{name: "nerd-font-without-variant", icon: Icon{Unicode: "★"}, mode: IconsNerdFont, expectedGlyph: "★"},
+		{name: "none", icon: IconFolder, mode: IconsNone, expectedGlyph: ""},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if glyph := scenario.icon.glyph(scenario.mode); glyph != scenario.expectedGlyph {
+				t.Errorf("expected %q, got %q", scenario.expectedGlyph, glyph)
+			}
+		})
+	}
+}
+
+func TestPickRichWithIcons(t *testing.T) {
+	items := []Item{{Label: "docs", Icon: IconFolder}, {Label: "README.md", Icon: IconFile}, {Label: "other"}}
+	scenarios := []struct {
+		name           string
+		mode           IconMode
+		expectedLabelX int
+	}{
+		// Emoji are two columns wide
+		{name: "unicode", mode: IconsUnicode, expectedLabelX: 6},
+		// Nerd Font glyphs are one column wide, but they are padded to the width of emoji
+		{name: "nerd-font", mode: IconsNerdFont, expectedLabelX: 6},
+		{name: "none", mode: IconsNone, expectedLabelX: 3},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionIcons(scenario.mode)(&config)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			go func() {
+				waitForText(t, screen, "README.md")
+				// The labels of the items with and without icons are aligned
+				for y, label := range []string{"docs", "README.md", "other"} {
+					mainc, _, _, _ := screen.GetContent(scenario.expectedLabelX, y+1)
+					if mainc != rune(label[0]) {
+						t.Errorf("expected %q to start at x=%d, got %q", label, scenario.expectedLabelX, mainc)
+					}
+				}
+				screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+			}()
+			item, _, err := pickRich("question", items, screen, &config)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if item.Icon != IconFolder {
+				t.Errorf("expected the icon of the item picked to be %v, got %v", IconFolder, item.Icon)
+			}
+		})
+	}
+}
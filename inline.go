@@ -0,0 +1,227 @@
+package gochoice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/gdamore/tcell/v2/terminfo"
+)
+
+const (
+	// cursorPositionTimeout is how long to wait for the terminal to report the position of the cursor
+	cursorPositionTimeout = time.Second
+
+	// inlineClear clears the lines of the inline screen, since the origin mode makes the home position its first line
+	inlineClear = "\x1b[H\x1b[J"
+)
+
+var (
+	// cursorPositionReportPattern matches the response of the terminal to a request for the position of the cursor
+	cursorPositionReportPattern = regexp.MustCompile(`\x1b\[(\d+);(\d+)R`)
+
+	// sgrMousePattern matches a mouse event reported with the SGR encoding, which tcell enables
+	sgrMousePattern = regexp.MustCompile(`\x1b\[<(\d+);(\d+);(\d+)([mM])`)
+
+	errCursorPositionNotReported = errors.New("the terminal didn't report the position of the cursor")
+)
+
+// inlineTty is a tcell.Tty on which the screen is displayed on the lines below the cursor, instead of on the
+// alternate screen. These lines are made the scrolling region of the terminal and the origin mode is enabled,
+// so that the positions written by tcell are relative to the first of them.
+type inlineTty struct {
+	tcell.Tty
+	// height is the number of lines of the screen, unless the terminal has fewer lines
+	height int
+	// top is the row of the terminal, starting from 1, of the first line of the screen
+	top int
+	// enterCA, exitCA and clear are the sequences written by tcell that would affect the whole terminal
+	enterCA, exitCA, clear string
+}
+
+// newInlineTty creates an inlineTty displaying the screen on the given number of lines of tty
+func newInlineTty(tty tcell.Tty, height int, ti *terminfo.Terminfo) *inlineTty {
+	return &inlineTty{Tty: tty, height: height, enterCA: ti.EnterCA, exitCA: ti.ExitCA, clear: ti.Clear}
+}
+
+// Start makes room for the screen below the cursor, scrolling the terminal if needed, and restricts the
+// drawing to these lines
+func (tty *inlineTty) Start() error {
+	if err := tty.Tty.Start(); err != nil {
+		return err
+	}
+	row, err := queryCursorRow(tty.Tty)
+	if err != nil {
+		_ = tty.Tty.Stop()
+		return err
+	}
+	_, height, err := tty.WindowSize()
+	if err != nil {
+		_ = tty.Tty.Stop()
+		return err
+	}
+	_, terminalHeight, _ := tty.Tty.WindowSize()
+	tty.top = row
+	if row+height-1 > terminalHeight {
+		tty.top = terminalHeight - height + 1
+	}
+	sequence := "\r" + strings.Repeat("\n", height-1)
+	if height > 1 {
+		sequence += fmt.Sprintf("\x1b[%dA", height-1)
+	}
+	sequence += fmt.Sprintf("\x1b[%d;%dr\x1b[?6h", tty.top, tty.top+height-1)
+	_, err = tty.Tty.Write([]byte(sequence))
+	return err
+}
+
+// Stop restores the scrolling region and the origin mode of the terminal, and leaves the cursor on the first line
+// of the screen, which tcell has already cleared
+func (tty *inlineTty) Stop() error {
+	_, _ = tty.Tty.Write([]byte(fmt.Sprintf("\x1b[?6l\x1b[r\x1b[%d;1H", tty.top)))
+	return tty.Tty.Stop()
+}
+
+// Write writes the output of tcell, leaving out the switch to the alternate screen and clearing only the lines
+// of the screen
+func (tty *inlineTty) Write(b []byte) (int, error) {
+	output := string(b)
+	for _, sequence := range []string{tty.enterCA, tty.exitCA} {
+		if len(sequence) > 0 {
+			output = strings.ReplaceAll(output, sequence, "")
+		}
+	}
+	if len(tty.clear) > 0 {
+		output = strings.ReplaceAll(output, tty.clear, inlineClear)
+	}
+	if _, err := tty.Tty.Write([]byte(output)); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// Read reads the input of the terminal, making the rows of the mouse events relative to the first line of the screen
+func (tty *inlineTty) Read(b []byte) (int, error) {
+	n, err := tty.Tty.Read(b)
+	if n == 0 || !sgrMousePattern.Match(b[:n]) {
+		return n, err
+	}
+	input := sgrMousePattern.ReplaceAllFunc(b[:n], func(event []byte) []byte {
+		groups := sgrMousePattern.FindSubmatch(event)
+		row, _ := strconv.Atoi(string(groups[3]))
+		// Rows above the screen are reported as row 0, which tcell turns into a negative position
+		row -= tty.top - 1
+		if row < 0 {
+			row = 0
+		}
+		return []byte(fmt.Sprintf("\x1b[<%s;%s;%d%s", groups[1], groups[2], row, groups[4]))
+	})
+	// Making the rows smaller never makes the input longer
+	return copy(b, input), err
+}
+
+// WindowSize returns the width of the terminal and the number of lines of the screen
+func (tty *inlineTty) WindowSize() (int, int, error) {
+	width, height, err := tty.Tty.WindowSize()
+	if err != nil {
+		return 0, 0, err
+	}
+	if tty.height < height {
+		height = tty.height
+	}
+	return width, height, nil
+}
+
+// queryCursorRow returns the row of the terminal, starting from 1, the cursor is on
+func queryCursorRow(tty tcell.Tty) (int, error) {
+	if _, err := tty.Write([]byte("\x1b[6n")); err != nil {
+		return 0, err
+	}
+	rows := make(chan int, 1)
+	go func() {
+		var input []byte
+		buffer := make([]byte, 64)
+		for {
+			n, err := tty.Read(buffer)
+			input = append(input, buffer[:n]...)
+			if groups := cursorPositionReportPattern.FindSubmatch(input); groups != nil {
+				row, _ := strconv.Atoi(string(groups[1]))
+				rows <- row
+				return
+			}
+			if err != nil {
+				rows <- 0
+				return
+			}
+		}
+	}()
+	select {
+	case row := <-rows:
+		if row == 0 {
+			return 0, errCursorPositionNotReported
+		}
+		return row, nil
+	case <-time.After(cursorPositionTimeout):
+		// Unblock the read, so that it doesn't consume the input meant for tcell
+		_ = tty.Drain()
+		<-rows
+		return 0, errCursorPositionNotReported
+	}
+}
+
+// createInlineScreen creates a screen displayed on the given number of lines below the cursor,
+// along with the terminal it is displayed on
+func createInlineScreen(height int) (tcell.Screen, io.Writer, error) {
+	tcell.SetEncodingFallback(tcell.EncodingFallbackASCII)
+	ti, err := terminfo.LookupTerminfo(os.Getenv("TERM"))
+	if err != nil {
+		return nil, nil, &screenInitError{err}
+	}
+	tty, err := openTty()
+	if err != nil {
+		return nil, nil, &screenInitError{err}
+	}
+	inlineTty := newInlineTty(tty, height, ti)
+	screen, err := tcell.NewTerminfoScreenFromTty(inlineTty)
+	if err != nil {
+		_ = tty.Close()
+		return nil, nil, &screenInitError{err}
+	}
+	if err := screen.Init(); err != nil {
+		_ = tty.Close()
+		return nil, nil, &screenInitError{err}
+	}
+	return screen, inlineTty, nil
+}
+
+// createScreenForConfig creates the screen on which the prompt is displayed, which is inline if OptionInline
+// is used and the terminal supports it. The terminal the screen is displayed on is kept in the config, if there is one.
+func createScreenForConfig(config *Config) (tcell.Screen, error) {
+	config.tty = nil
+	if config.InlineHeight > 0 {
+		if screen, tty, err := createInlineScreen(config.InlineHeight); err == nil {
+			config.tty = tty
+			return screen, nil
+		}
+	}
+	if screen, tty, err := createScreenWithTty(); err == nil {
+		config.tty = tty
+		return screen, nil
+	}
+	return createScreen()
+}
+
+// OptionInline displays the prompt on the given number of lines below the cursor, scrolling the terminal if needed,
+// instead of taking over the whole terminal. These lines are cleared once the prompt is closed.
+// The prompt is displayed on the whole terminal if the terminal doesn't report the position of the cursor,
+// which is always the case on Windows.
+func OptionInline(height int) func(config *Config) {
+	return func(config *Config) {
+		config.InlineHeight = height
+	}
+}
@@ -0,0 +1,107 @@
+package gochoice
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2/terminfo"
+)
+
+// fakeTty is a tcell.Tty whose input is the given text and whose output is recorded
+type fakeTty struct {
+	input         *strings.Reader
+	output        bytes.Buffer
+	width, height int
+	started       bool
+}
+
+func newFakeTty(input string, width, height int) *fakeTty {
+	return &fakeTty{input: strings.NewReader(input), width: width, height: height}
+}
+
+func (tty *fakeTty) Start() error                  { tty.started = true; return nil }
+func (tty *fakeTty) Stop() error                   { tty.started = false; return nil }
+func (tty *fakeTty) Drain() error                  { return nil }
+func (tty *fakeTty) NotifyResize(func())           {}
+func (tty *fakeTty) WindowSize() (int, int, error) { return tty.width, tty.height, nil }
+func (tty *fakeTty) Read(b []byte) (int, error)    { return tty.input.Read(b) }
+func (tty *fakeTty) Write(b []byte) (int, error)   { return tty.output.Write(b) }
+func (tty *fakeTty) Close() error                  { return nil }
+
+func newTestTerminfo() *terminfo.Terminfo {
+	return &terminfo.Terminfo{EnterCA: "\x1b[?1049h", ExitCA: "\x1b[?1049l", Clear: "\x1b[H\x1b[2J"}
+}
+
+func TestInlineTtyStart(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		cursorRow      string
+		expectedTop    int
+		expectedOutput string
+	}{
+		{
+			name:           "room-below-cursor",
+			cursorRow:      "5",
+			expectedTop:    5,
+			expectedOutput: "\x1b[6n\r\n\n\x1b[2A\x1b[5;7r\x1b[?6h",
+		},
+		{
+			name:           "cursor-at-bottom",
+			cursorRow:      "24",
+			expectedTop:    22,
+			expectedOutput: "\x1b[6n\r\n\n\x1b[2A\x1b[22;24r\x1b[?6h",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			tty := newFakeTty("\x1b["+scenario.cursorRow+";1R", 80, 24)
+			inline := newInlineTty(tty, 3, newTestTerminfo())
+			if err := inline.Start(); err != nil {
+				t.Fatal(err.Error())
+			}
+			if inline.top != scenario.expectedTop {
+				t.Errorf("expected top to be %d, got %d", scenario.expectedTop, inline.top)
+			}
+			if output := tty.output.String(); output != scenario.expectedOutput {
+				t.Errorf("expected output %q, got %q", scenario.expectedOutput, output)
+			}
+			if width, height, _ := inline.WindowSize(); width != 80 || height != 3 {
+				t.Errorf("expected a size of 80x3, got %dx%d", width, height)
+			}
+		})
+	}
+}
+
+func TestInlineTtyStartWithoutCursorPosition(t *testing.T) {
+	tty := newFakeTty("", 80, 24)
+	if err := newInlineTty(tty, 3, newTestTerminfo()).Start(); !errors.Is(err, errCursorPositionNotReported) {
+		t.Error("expected errCursorPositionNotReported, got", err)
+	}
+	if tty.started {
+		t.Error("expected the tty to be stopped")
+	}
+}
+
+func TestInlineTtyWrite(t *testing.T) {
+	tty := newFakeTty("", 80, 24)
+	inline := newInlineTty(tty, 3, newTestTerminfo())
+	if _, err := inline.Write([]byte("\x1b[?1049h\x1b[H\x1b[2J\x1b[1;1Hquestion\x1b[?1049l")); err != nil {
+		t.Fatal(err.Error())
+	}
+	if output, expected := tty.output.String(), "\x1b[H\x1b[J\x1b[1;1Hquestion"; output != expected {
+		t.Errorf("expected output %q, got %q", expected, output)
+	}
+}
+
+func TestInlineTtyRead(t *testing.T) {
+	tty := newFakeTty("a\x1b[<0;12;7M\x1b[<0;3;2m", 80, 24)
+	inline := newInlineTty(tty, 3, newTestTerminfo())
+	inline.top = 5
+	buffer := make([]byte, 64)
+	n, _ := inline.Read(buffer)
+	if input, expected := string(buffer[:n]), "a\x1b[<0;12;3M\x1b[<0;3;0m"; input != expected {
+		t.Errorf("expected input %q, got %q", expected, input)
+	}
+}
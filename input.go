@@ -0,0 +1,211 @@
+package gochoice
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"golang.org/x/term"
+)
+
+// inputPrefix is the text displayed before the text being typed
+const inputPrefix = " > "
+
+// ErrInputAborted is the error returned when the user quits the application without confirming the text typed,
+// e.g. with CTRL+C or the ESC key. Like the prompts picking choices, it matches ErrAborted with errors.Is.
+var ErrInputAborted error = &inputAbortedError{}
+
+// inputAbortedError is the type of ErrInputAborted, which matches ErrAborted
+type inputAbortedError struct{}
+
+func (e *inputAbortedError) Error() string {
+	return "input aborted"
+}
+
+func (e *inputAbortedError) Is(target error) bool {
+	return target == ErrAborted
+}
+
+// Password is like Input, but each character typed is displayed as the mask set with OptionMask,
+// which defaults to '*'. OptionDefaultValue is ignored.
+func Password(question string, options ...Option) (string, error) {
+	config := newConfig(options)
+	config.secret = true
+	config.DefaultValue = ""
+	return runInput(context.Background(), question, config)
+}
+
+// Input prompts the user to type a line of text, which is confirmed with the enter key.
+// The text is edited with the left and right arrow keys, home, end, backspace and delete.
+// OptionDefaultValue sets the text that is already typed when the prompt opens.
+// The prompt is aborted with the keys of KeyMap.Abort that don't edit the line, which are ESC and CTRL+C by default.
+func Input(question string, options ...Option) (string, error) {
+	return runInput(context.Background(), question, newConfig(options))
+}
+
+// runInput prompts the user to type a line of text, either on a newly created screen or,
+// if the fallback mode requires it, by reading a line from stdin.
+func runInput(ctx context.Context, question string, config *Config) (string, error) {
+	if config.useFallback() {
+		return inputWithFallback(ctx, question, config, os.Stdin, os.Stderr)
+	}
+	screen, err := createScreen()
+	if err != nil {
+		return "", err
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	return input(ctx, question, screen, config)
+}
+
+// input runs the event loop of the input prompt and returns the text typed
+func input(ctx context.Context, question string, screen tcell.Screen, config *Config) (string, error) {
+	events, stopListening := listenToEvents(screen)
+	defer stopListening()
+	defer screen.HideCursor()
+	editor := newLineEditor(config.DefaultValue)
+	for {
+		renderInput(screen, question, editor, config)
+		var ev tcell.Event
+		select {
+		case <-ctx.Done():
+			return "", &contextCanceledError{err: ctx.Err()}
+		case ev = <-events:
+		}
+		switch ev := ev.(type) {
+		case nil:
+			// The event channel is closed when the screen is finalized
+			return "", ErrInputAborted
+		case *tcell.EventKey:
+			if config.backAllowed && matchesAny(config.KeyMap.Back, ev) {
+				return "", errBack
+			}
+			switch ev.Key() {
+			case tcell.KeyEnter:
+				return editor.String(), nil
+			case tcell.KeyLeft:
+				editor.moveLeft()
+			case tcell.KeyRight:
+				editor.moveRight()
+			case tcell.KeyHome, tcell.KeyCtrlA:
+				editor.moveToStart()
+			case tcell.KeyEnd, tcell.KeyCtrlE:
+				editor.moveToEnd()
+			case tcell.KeyBackspace, tcell.KeyBackspace2:
+				editor.deleteBackward()
+			case tcell.KeyDelete:
+				editor.deleteForward()
+			case tcell.KeyRune:
+				editor.insert(ev.Rune())
+			default:
+				// The keys editing the line take precedence over KeyMap.Abort, e.g. the left arrow key bound to it
+				// by default moves the cursor, and rune keys are always typed
+				if config.KeyMap.actionFor(ev, false, false, false, true) == actionAbort {
+					return "", ErrInputAborted
+				}
+			}
+		case *tcell.EventResize:
+			screen.Sync()
+		}
+	}
+}
+
+// renderInput renders the question followed by the line being edited, or by the placeholder if it is empty.
+// If the line is too long to fit in the screen, its start is cut off so that the cursor remains visible.
+func renderInput(screen tcell.Screen, question string, editor *lineEditor, config *Config) {
+	screenWidth, screenHeight := screen.Size()
+	lineNumber := renderQuestion(screen, strings.Split(question, "\n"), config)
+	printText(screen, 0, lineNumber, inputPrefix, config.Theme.Item)
+	x := runewidth.StringWidth(inputPrefix)
+	if len(editor.runes) == 0 && len(config.Placeholder) > 0 {
+		printText(screen, x, lineNumber, config.Placeholder, config.Theme.Description)
+		screen.ShowCursor(x, lineNumber)
+	} else {
+		runes, cursor := editor.runes, editor.cursor
+		if config.secret {
+			runes = maskRunes(runes, config.Mask)
+			if len(runes) == 0 {
+				cursor = 0
+			}
+		}
+		start := 0
+		for start < cursor && x+runewidth.StringWidth(string(runes[start:cursor])) >= screenWidth {
+			start++
+		}
+		printText(screen, x, lineNumber, string(runes[start:]), config.Theme.Item)
+		screen.ShowCursor(x+runewidth.StringWidth(string(runes[start:cursor])), lineNumber)
+	}
+	lineNumber++
+	// HACK: Instead of using screen.Clear(), draw over the existing text
+	for i := lineNumber; i < screenHeight; i++ {
+		printText(screen, 1, i, "", config.Theme.background())
+	}
+	screen.Show()
+}
+
+// inputWithFallback prints the question to the given writer, then reads a line of text from the given reader.
+// If the line is empty, the default value is returned.
+func inputWithFallback(ctx context.Context, question string, config *Config, in io.Reader, out io.Writer) (string, error) {
+	fmt.Fprintln(out, question)
+	prompt := "> "
+	if len(config.DefaultValue) > 0 {
+		prompt = fmt.Sprintf("[%s] > ", config.DefaultValue)
+	}
+	fmt.Fprint(out, prompt)
+	if file, ok := in.(*os.File); ok && config.secret && term.IsTerminal(int(file.Fd())) {
+		// Prevent the terminal from echoing the text typed
+		password, err := term.ReadPassword(int(file.Fd()))
+		fmt.Fprintln(out)
+		if err != nil {
+			return "", ErrInputAborted
+		}
+		return string(password), nil
+	}
+	if ctx.Err() != nil {
+		return "", &contextCanceledError{err: ctx.Err()}
+	}
+	lines, stopScanning := scanLines(in)
+	defer stopScanning()
+	text, err := readLine(ctx, lines)
+	if err == io.EOF {
+		fmt.Fprintln(out)
+		return "", ErrInputAborted
+	} else if err != nil {
+		return "", err
+	}
+	if len(text) > 0 {
+		return text, nil
+	}
+	return config.DefaultValue, nil
+}
+
+// maskRunes returns a mask for each of the given runes, or no runes at all if the mask is 0
+func maskRunes(runes []rune, mask rune) []rune {
+	if mask == 0 {
+		return nil
+	}
+	masked := make([]rune, len(runes))
+	for i := range masked {
+		masked[i] = mask
+	}
+	return masked
+}
+
+// OptionMask sets the character displayed instead of each character typed in Password.
+// If the mask is 0, nothing is displayed at all, not even the number of characters typed.
+func OptionMask(mask rune) func(config *Config) {
+	return func(config *Config) {
+		config.Mask = mask
+	}
+}
+
+// OptionPlaceholder sets the text displayed by Input while nothing has been typed
+func OptionPlaceholder(placeholder string) func(config *Config) {
+	return func(config *Config) {
+		config.Placeholder = placeholder
+	}
+}
@@ -0,0 +1,216 @@
+package gochoice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestInput(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKeyBytes([]byte("helo"))
+	screen.InjectKey(tcell.KeyLeft, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'l', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	text, err := input(context.Background(), "question", screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if text != "hello" {
+		t.Error("expected hello, got", text)
+	}
+}
+
+func TestInputWithDefaultValue(t *testing.T) {
+	config := defaultConfig
+	OptionDefaultValue("world")(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyHome, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyDelete, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'W', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnd, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyBackspace, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	text, err := input(context.Background(), "question", screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if text != "Worl" {
+		t.Error("expected Worl, got", text)
+	}
+}
+
+func TestInputAborted(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyRune, 'a', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	if _, err = input(context.Background(), "question", screen, &config); err != ErrInputAborted {
+		t.Error("expected ErrInputAborted, got", err)
+	}
+	if !errors.Is(err, ErrAborted) {
+		t.Error("expected ErrInputAborted to match ErrAborted")
+	}
+}
+
+func TestInputAbortedWithKeyMap(t *testing.T) {
+	keyMap := DefaultKeyMap()
+	keyMap.Abort = []Key{{Key: tcell.KeyCtrlQ}, {Key: tcell.KeyRune, Rune: 'q'}}
+	scenarios := []struct {
+		name         string
+		key          tcell.Key
+		expectedText string
+		expectedErr  error
+	}{
+		{name: "key-bound-to-abort", key: tcell.KeyCtrlQ, expectedErr: ErrInputAborted},
+		{name: "escape-not-bound-to-abort", key: tcell.KeyEscape, expectedText: "aq"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionKeyMap(keyMap)(&config)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			// The rune bound to Abort is typed, like any other rune
+			screen.InjectKey(tcell.KeyRune, 'a', tcell.ModNone)
+			screen.InjectKey(tcell.KeyRune, 'q', tcell.ModNone)
+			screen.InjectKey(scenario.key, 0, tcell.ModNone)
+			screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+			text, err := input(context.Background(), "question", screen, &config)
+			if err != scenario.expectedErr {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if text != scenario.expectedText {
+				t.Errorf("expected %q, got %q", scenario.expectedText, text)
+			}
+		})
+	}
+}
+
+func TestRenderInputWithPlaceholder(t *testing.T) {
+	config := defaultConfig
+	OptionPlaceholder("name")(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 5)
+	renderInput(screen, "question", newLineEditor(""), &config)
+	if mainc, _, style, _ := screen.GetContent(3, 1); mainc != 'n' || style != config.Theme.Description {
+		t.Errorf("expected placeholder to be displayed with the description style, got %q with style %v", mainc, style)
+	}
+	renderInput(screen, "question", newLineEditor("abc"), &config)
+	if mainc, _, _, _ := screen.GetContent(3, 1); mainc != 'a' {
+		t.Errorf("expected text to be displayed instead of the placeholder, got %q", mainc)
+	}
+}
+
+func TestInputWithFallback(t *testing.T) {
+	config := defaultConfig
+	output := &bytes.Buffer{}
+	text, err := inputWithFallback(context.Background(), "question", &config, strings.NewReader("hello\n"), output)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if text != "hello" {
+		t.Error("expected hello, got", text)
+	}
+	if output.String() != "question\n> " {
+		t.Errorf("expected output %q, got %q", "question\n> ", output.String())
+	}
+	OptionDefaultValue("world")(&config)
+	if text, _ = inputWithFallback(context.Background(), "question", &config, strings.NewReader("\n"), &bytes.Buffer{}); text != "world" {
+		t.Error("expected world, got", text)
+	}
+	if _, err = inputWithFallback(context.Background(), "question", &config, strings.NewReader(""), &bytes.Buffer{}); err != ErrInputAborted {
+		t.Error("expected ErrInputAborted, got", err)
+	}
+}
+
+func TestInputWithFallbackContextCanceledWhileReading(t *testing.T) {
+	config := defaultConfig
+	ctx, cancel := context.WithCancel(context.Background())
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+	_, err := inputWithFallback(ctx, "question", &config, reader, &bytes.Buffer{})
+	if !errors.Is(err, ErrContextCanceled) || !errors.Is(err, context.Canceled) {
+		t.Error("expected ErrContextCanceled wrapping context.Canceled, got", err)
+	}
+}
+
+func TestRenderInputWithPassword(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		mask            rune
+		expectedLine    string
+		expectedCursorX int
+	}{
+		{name: "default-mask", mask: '*', expectedLine: " > ***", expectedCursorX: 6},
+		{name: "custom-mask", mask: '•', expectedLine: " > •••", expectedCursorX: 6},
+		{name: "hidden", mask: 0, expectedLine: " >", expectedCursorX: 3},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			config.secret = true
+			OptionMask(scenario.mask)(&config)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.SetSize(20, 5)
+			renderInput(screen, "question", newLineEditor("abc"), &config)
+			cells, width, _ := screen.GetContents()
+			var line []rune
+			for _, cell := range cells[width : 2*width] {
+				line = append(line, cell.Runes...)
+			}
+			if displayed := strings.TrimRight(string(line), " "); displayed != scenario.expectedLine {
+				t.Errorf("expected %q, got %q", scenario.expectedLine, displayed)
+			}
+			if x, y, _ := screen.GetCursor(); x != scenario.expectedCursorX || y != 1 {
+				t.Errorf("expected cursor at (%d, 1), got (%d, %d)", scenario.expectedCursorX, x, y)
+			}
+		})
+	}
+}
+
+func TestPasswordWithFallback(t *testing.T) {
+	config := defaultConfig
+	config.secret = true
+	text, err := inputWithFallback(context.Background(), "question", &config, strings.NewReader("s3cr3t\n"), &bytes.Buffer{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if text != "s3cr3t" {
+		t.Error("expected s3cr3t, got", text)
+	}
+}
@@ -0,0 +1,216 @@
+package gochoice
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Key is a key that can be bound to an action.
+// Rune is only used when Key is tcell.KeyRune.
+type Key struct {
+	Key  tcell.Key
+	Rune rune
+}
+
+// KeyMap defines the keys bound to each action.
+// An action with no keys bound to it is disabled.
+type KeyMap struct {
+	Up           []Key
+	Down         []Key
+	PageUp       []Key
+	PageDown     []Key
+	HalfPageUp   []Key
+	HalfPageDown []Key
+	Home         []Key
+	End          []Key
+	Confirm      []Key
+	Abort        []Key
+	DeleteChar   []Key
+	ToggleSelect []Key // Only used by PickMultiple
+	CheckAll     []Key // Only used by PickMultiple
+	UncheckAll   []Key // Only used by PickMultiple
+	InvertChecks []Key // Only used by PickMultiple
+	Search       []Key // Only used with OptionVimBindings
+	Back         []Key // Only used by Form
+	Expand       []Key // Only used by PickTree
+	Collapse     []Key // Only used by PickTree
+	Left         []Key // Only used with OptionColumns
+	Right        []Key // Only used with OptionColumns
+	ScrollLeft   []Key
+	ScrollRight  []Key
+	Copy         []Key
+	Help         []Key
+	Suspend      []Key // Only used with OptionSuspend
+}
+
+// DefaultKeyMap returns the KeyMap used unless another one is set with OptionKeyMap
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up:           []Key{{Key: tcell.KeyUp}},
+		Down:         []Key{{Key: tcell.KeyDown}},
+		PageUp:       []Key{{Key: tcell.KeyPgUp}},
+		PageDown:     []Key{{Key: tcell.KeyPgDn}},
+		Home:         []Key{{Key: tcell.KeyHome}},
+		End:          []Key{{Key: tcell.KeyEnd}},
+		Confirm:      []Key{{Key: tcell.KeyEnter}, {Key: tcell.KeyRight}},
+		Abort:        []Key{{Key: tcell.KeyEscape}, {Key: tcell.KeyCtrlC}, {Key: tcell.KeyLeft}},
+		DeleteChar:   []Key{{Key: tcell.KeyBackspace}, {Key: tcell.KeyBackspace2}},
+		ToggleSelect: []Key{{Key: tcell.KeyRune, Rune: ' '}},
+		CheckAll:     []Key{{Key: tcell.KeyCtrlA}},
+		UncheckAll:   []Key{{Key: tcell.KeyCtrlD}},
+		InvertChecks: []Key{{Key: tcell.KeyCtrlI}},
+		Back:         []Key{{Key: tcell.KeyBacktab}},
+		Expand:       []Key{{Key: tcell.KeyRight}},
+		Collapse:     []Key{{Key: tcell.KeyLeft}},
+		Left:         []Key{{Key: tcell.KeyLeft}},
+		Right:        []Key{{Key: tcell.KeyRight}},
+		ScrollLeft:   []Key{{Key: tcell.KeyCtrlB}},
+		ScrollRight:  []Key{{Key: tcell.KeyCtrlF}},
+		Copy:         []Key{{Key: tcell.KeyCtrlY}},
+		Help:         []Key{{Key: tcell.KeyF1}},
+		Suspend:      []Key{{Key: tcell.KeyCtrlZ}},
+	}
+}
+
+// VimKeyMap returns the DefaultKeyMap with the addition of vim-style navigation keys:
+// j/k to move down/up, g/G to go to the first/last choice, Ctrl-D/Ctrl-U to move half a page
+// down/up, / to start typing a search query and ? to show the help.
+// Since Ctrl-D moves half a page down, no key is bound to KeyMap.UncheckAll.
+func VimKeyMap() KeyMap {
+	keyMap := DefaultKeyMap()
+	keyMap.Up = append(keyMap.Up, Key{Key: tcell.KeyRune, Rune: 'k'})
+	keyMap.Down = append(keyMap.Down, Key{Key: tcell.KeyRune, Rune: 'j'})
+	keyMap.Home = append(keyMap.Home, Key{Key: tcell.KeyRune, Rune: 'g'})
+	keyMap.End = append(keyMap.End, Key{Key: tcell.KeyRune, Rune: 'G'})
+	keyMap.HalfPageUp = append(keyMap.HalfPageUp, Key{Key: tcell.KeyCtrlU})
+	keyMap.HalfPageDown = append(keyMap.HalfPageDown, Key{Key: tcell.KeyCtrlD})
+	keyMap.UncheckAll = nil
+	keyMap.Search = append(keyMap.Search, Key{Key: tcell.KeyRune, Rune: '/'})
+	keyMap.Help = append(keyMap.Help, Key{Key: tcell.KeyRune, Rune: '?'})
+	return keyMap
+}
+
+type action int
+
+const (
+	actionNone action = iota
+	actionUp
+	actionDown
+	actionPageUp
+	actionPageDown
+	actionHalfPageUp
+	actionHalfPageDown
+	actionHome
+	actionEnd
+	actionConfirm
+	actionAbort
+	actionDeleteChar
+	actionToggleSelect
+	actionCheckAll
+	actionUncheckAll
+	actionInvertChecks
+	actionSearch
+	actionBack
+	actionExpand
+	actionCollapse
+	actionLeft
+	actionRight
+	actionScrollLeft
+	actionScrollRight
+	actionCopy
+	actionHelp
+	actionSuspend
+)
+
+type keyBinding struct {
+	keys   []Key
+	action action
+}
+
+// actionFor returns the action bound to the key of the given event, or actionNone if there is none.
+// If typing is true, rune keys are never bound to an action so that they can be used as text.
+// If tree is true, the keys bound to expanding and collapsing nodes take precedence over other bindings,
+// and so do the keys bound to moving across the columns if grid is true.
+func (keyMap *KeyMap) actionFor(ev *tcell.EventKey, multiSelect, tree, grid, typing bool) action {
+	bindings := []keyBinding{
+		{keyMap.Up, actionUp},
+		{keyMap.Down, actionDown},
+		{keyMap.PageUp, actionPageUp},
+		{keyMap.PageDown, actionPageDown},
+		{keyMap.HalfPageUp, actionHalfPageUp},
+		{keyMap.HalfPageDown, actionHalfPageDown},
+		{keyMap.Home, actionHome},
+		{keyMap.End, actionEnd},
+		{keyMap.Confirm, actionConfirm},
+		{keyMap.Abort, actionAbort},
+		{keyMap.DeleteChar, actionDeleteChar},
+		{keyMap.Search, actionSearch},
+		{keyMap.Back, actionBack},
+		{keyMap.Help, actionHelp},
+		{keyMap.Suspend, actionSuspend},
+		{keyMap.ScrollLeft, actionScrollLeft},
+		{keyMap.ScrollRight, actionScrollRight},
+		{keyMap.Copy, actionCopy},
+	}
+	if multiSelect {
+		bindings = append(bindings,
+			keyBinding{keyMap.ToggleSelect, actionToggleSelect},
+			keyBinding{keyMap.CheckAll, actionCheckAll},
+			keyBinding{keyMap.UncheckAll, actionUncheckAll},
+			keyBinding{keyMap.InvertChecks, actionInvertChecks},
+		)
+	}
+	if tree {
+		bindings = append([]keyBinding{{keyMap.Expand, actionExpand}, {keyMap.Collapse, actionCollapse}}, bindings...)
+	}
+	if grid {
+		bindings = append([]keyBinding{{keyMap.Left, actionLeft}, {keyMap.Right, actionRight}}, bindings...)
+	}
+	for _, binding := range bindings {
+		for _, key := range binding.keys {
+			if typing && key.Key == tcell.KeyRune {
+				continue
+			}
+			if key.matches(ev) {
+				return binding.action
+			}
+		}
+	}
+	return actionNone
+}
+
+// matchesAny reports whether any of the keys corresponds to the key of the given event
+func matchesAny(keys []Key, ev *tcell.EventKey) bool {
+	for _, key := range keys {
+		if key.matches(ev) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the key corresponds to the key of the given event
+func (key Key) matches(ev *tcell.EventKey) bool {
+	if key.Key != ev.Key() {
+		return false
+	}
+	if key.Key == tcell.KeyRune {
+		return key.Rune == ev.Rune() && ev.Modifiers()&(tcell.ModAlt|tcell.ModCtrl) == 0
+	}
+	return true
+}
+
+// OptionKeyMap replaces the default key bindings by the given KeyMap
+func OptionKeyMap(keyMap KeyMap) func(config *Config) {
+	return func(config *Config) {
+		config.KeyMap = keyMap
+	}
+}
+
+// OptionVimBindings replaces the key bindings by VimKeyMap. Because letters are then used for
+// navigation, the search query can only be typed after pressing /, and typing ends with Enter or Escape.
+func OptionVimBindings() func(config *Config) {
+	return func(config *Config) {
+		config.KeyMap = VimKeyMap()
+		config.VimBindings = true
+	}
+}
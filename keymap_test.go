@@ -0,0 +1,157 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickWithKeyMapWithoutLeftArrowAbort(t *testing.T) {
+	config := defaultConfig
+	keyMap := DefaultKeyMap()
+	keyMap.Abort = []Key{{Key: tcell.KeyEscape}}
+	OptionKeyMap(keyMap)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyLeft, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+}
+
+func TestPickWithKeyMapWithCustomBindings(t *testing.T) {
+	config := defaultConfig
+	keyMap := DefaultKeyMap()
+	keyMap.Down = append(keyMap.Down, Key{Key: tcell.KeyCtrlN})
+	keyMap.Confirm = []Key{{Key: tcell.KeyTab}}
+	OptionKeyMap(keyMap)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyCtrlN, 0, tcell.ModCtrl)
+	screen.InjectKey(tcell.KeyCtrlN, 0, tcell.ModCtrl)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyTab, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" {
+		t.Error("expected C, got", choice)
+	}
+}
+
+func TestKeyMatches(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		key           Key
+		event         *tcell.EventKey
+		expectedMatch bool
+	}{
+		{name: "special-key", key: Key{Key: tcell.KeyUp}, event: tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone), expectedMatch: true},
+		{name: "different-special-key", key: Key{Key: tcell.KeyUp}, event: tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone), expectedMatch: false},
+		{name: "rune", key: Key{Key: tcell.KeyRune, Rune: 'j'}, event: tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModNone), expectedMatch: true},
+		{name: "different-rune", key: Key{Key: tcell.KeyRune, Rune: 'j'}, event: tcell.NewEventKey(tcell.KeyRune, 'k', tcell.ModNone), expectedMatch: false},
+		{name: "rune-with-alt", key: Key{Key: tcell.KeyRune, Rune: 'j'}, event: tcell.NewEventKey(tcell.KeyRune, 'j', tcell.ModAlt), expectedMatch: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if matched := scenario.key.matches(scenario.event); matched != scenario.expectedMatch {
+				t.Errorf("expected %v, got %v", scenario.expectedMatch, matched)
+			}
+		})
+	}
+}
+
+func TestPickWithVimBindings(t *testing.T) {
+	config := defaultConfig
+	OptionVimBindings()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, 'G', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'k', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'k', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'j', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := pick("question", []string{"A", "B", "C", "D"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" {
+		t.Error("expected C, got", choice)
+	}
+	if index != 2 {
+		t.Error("expected 2, got", index)
+	}
+}
+
+func TestPickWithVimBindingsAndSearch(t *testing.T) {
+	config := defaultConfig
+	OptionVimBindings()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, '/', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'j', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	// The search is over, so j moves down again
+	screen.InjectKey(tcell.KeyRune, 'j', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"john", "doe", "jane"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "jane" {
+		t.Error("expected jane, got", choice)
+	}
+}
+
+func TestPickWithVimBindingsHalfPage(t *testing.T) {
+	config := defaultConfig
+	OptionVimBindings()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(80, 10)
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	// The page size is 10 - 1 (question) - 1 (search bar) = 8, so half a page is 4 choices
+	screen.InjectKey(tcell.KeyCtrlD, 0, tcell.ModCtrl)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	_, index, err := pick("question", []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if index != 4 {
+		t.Error("expected 4, got", index)
+	}
+}
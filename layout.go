@@ -0,0 +1,160 @@
+package gochoice
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// searchQueryRoom is the number of columns left for the search query when the prompt is centered
+const searchQueryRoom = 20
+
+// regionScreen is a screen drawing in a region of another screen, so that the prompt can be rendered anywhere on it.
+// Positions are relative to the top left corner of the region, and cells outside the region are ignored.
+type regionScreen struct {
+	tcell.Screen
+	x, y, width, height int
+	// bordered is whether a border is drawn around the region
+	bordered bool
+	config   *Config
+	state    *promptState
+}
+
+func (screen *regionScreen) Size() (int, int) {
+	return screen.width, screen.height
+}
+
+func (screen *regionScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	if x < 0 || y < 0 || x >= screen.width || y >= screen.height {
+		return
+	}
+	screen.Screen.SetContent(screen.x+x, screen.y+y, mainc, combc, style)
+}
+
+func (screen *regionScreen) GetContent(x, y int) (rune, []rune, tcell.Style, int) {
+	return screen.Screen.GetContent(screen.x+x, screen.y+y)
+}
+
+// Show fills the cells outside the region, which may have been drawn on before the region moved,
+// draws the border around the region if there is one, and shows the screen
+func (screen *regionScreen) Show() {
+	background := screen.config.Theme.background()
+	screenWidth, screenHeight := screen.Screen.Size()
+	for y := 0; y < screenHeight; y++ {
+		for x := 0; x < screenWidth; x++ {
+			if x < screen.x || y < screen.y || x >= screen.x+screen.width || y >= screen.y+screen.height {
+				screen.Screen.SetContent(x, y, ' ', nil, background)
+			}
+		}
+	}
+	if screen.bordered {
+		renderBorder(screen.Screen, screen.x-1, screen.y-1, screen.width+2, screen.height+2, screen.config.BorderTitle, screen.config)
+	}
+	screen.Screen.Show()
+}
+
+// fit moves and resizes the region according to the padding, the centering and the border of the config
+func (screen *regionScreen) fit(question string, choices []*Choice) {
+	screenWidth, screenHeight := screen.Screen.Size()
+	screen.x, screen.y, screen.width, screen.height = computeRegion(screenWidth, screenHeight, question, choices, screen.config, screen.state)
+	// The border is left out if there is no room inside it
+	screen.bordered = screen.config.Border != BorderNone && screen.width > 2 && screen.height > 2
+	if screen.bordered {
+		screen.x, screen.y, screen.width, screen.height = screen.x+1, screen.y+1, screen.width-2, screen.height-2
+	}
+}
+
+// usesRegion reports whether the prompt is drawn in a region of the screen rather than on the whole screen
+func (config *Config) usesRegion() bool {
+	return config.PaddingTop > 0 || config.PaddingLeft > 0 || config.Centered || config.Border != BorderNone
+}
+
+// computeRegion returns the position and the size of the region of the screen in which the prompt is drawn,
+// including its border if it has one
+func computeRegion(screenWidth, screenHeight int, question string, choices []*Choice, config *Config, state *promptState) (int, int, int, int) {
+	x, y := config.PaddingLeft, config.PaddingTop
+	width, height := screenWidth-2*x, screenHeight-2*y
+	if width < 1 {
+		// The padding doesn't leave any room, so it is ignored
+		x, width = 0, screenWidth
+	}
+	if height < 1 {
+		y, height = 0, screenHeight
+	}
+	if config.Centered {
+		contentWidth, contentHeight := computeContentSize(question, choices, config, state)
+		if config.Border != BorderNone {
+			contentWidth, contentHeight = contentWidth+2, contentHeight+2
+		}
+		// The preview takes the room left by the options, so the prompt is only centered in the other direction
+		if contentWidth < width && (config.Preview == nil || config.PreviewPosition != PreviewRight) {
+			x, width = x+(width-contentWidth)/2, contentWidth
+		}
+		if contentHeight < height && (config.Preview == nil || config.PreviewPosition != PreviewBottom) {
+			y, height = y+(height-contentHeight)/2, contentHeight
+		}
+	}
+	return x, y, width, height
+}
+
+// computeContentSize returns the number of columns and lines needed to display all the choices without scrolling.
+// It doesn't depend on the search query, so that the prompt doesn't move while the user is typing.
+func computeContentSize(question string, choices []*Choice, config *Config, state *promptState) (int, int) {
+	width := 0
+	fit := func(lineWidth int) {
+		if lineWidth > width {
+			width = lineWidth
+		}
+	}
+	var lines []string
+	lines = append(lines, strings.Split(question, "\n")...)
+	lines = append(lines, textLines(config.Header)...)
+	lines = append(lines, config.footerLines()...)
+	for _, line := range lines {
+		fit(1 + runewidth.StringWidth(line))
+	}
+	_, unselectedPrefix := config.cursor()
+	if len(config.columnHeader) > 0 {
+		fit(1 + runewidth.StringWidth(lineNumberGutter(nil, config, state)+unselectedPrefix+config.columnHeader))
+	}
+	for _, choice := range choices {
+		text := choice.Value
+		if len(choice.Description) > 0 {
+			text += descriptionSeparator + choice.Description
+		}
+		// The last column is left for the scrollbar
+		fit(runewidth.StringWidth(choicePrefix(choice, config, state)+text) + 1)
+	}
+	if !config.WithoutSearch {
+		// Leave some room for the search query and the number of choices matching it
+		counter := fmt.Sprintf("%d/%d", len(choices), len(choices))
+		fit(1 + runewidth.StringWidth("Search: _  "+counter) + searchQueryRoom)
+	}
+	numberOfOptionLines := len(choices)
+	if config.PageSize > 0 && config.PageSize < numberOfOptionLines {
+		numberOfOptionLines = config.PageSize
+	}
+	height := len(lines) + config.breadcrumbHeight() + numberOfOptionLines + config.searchBarHeight()
+	if len(config.columnHeader) > 0 {
+		height++
+	}
+	return width, height
+}
+
+// OptionPadding leaves the given number of lines above and below the prompt,
+// and the given number of columns on its left and on its right
+func OptionPadding(top, left int) func(config *Config) {
+	return func(config *Config) {
+		config.PaddingTop, config.PaddingLeft = top, left
+	}
+}
+
+// OptionCentered centers the prompt horizontally and vertically on the screen instead of drawing it in its top left
+// corner. The prompt is as large as needed to display all the choices, within the padding set with OptionPadding.
+func OptionCentered() func(config *Config) {
+	return func(config *Config) {
+		config.Centered = true
+	}
+}
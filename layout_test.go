@@ -0,0 +1,113 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestComputeRegion(t *testing.T) {
+	scenarios := []struct {
+		name                                                string
+		options                                             []func(config *Config)
+		expectedX, expectedY, expectedWidth, expectedHeight int
+	}{
+		{
+			name:           "padding",
+			options:        []func(config *Config){OptionPadding(2, 4)},
+			expectedX:      4,
+			expectedY:      2,
+			expectedWidth:  72,
+			expectedHeight: 21,
+		},
+		{
+			name:           "padding-too-large",
+			options:        []func(config *Config){OptionPadding(20, 50)},
+			expectedX:      0,
+			expectedY:      0,
+			expectedWidth:  80,
+			expectedHeight: 25,
+		},
+		{
+			// The search bar leaves room for the search query, so it is the widest line
+			name:           "centered",
+			options:        []func(config *Config){OptionCentered()},
+			expectedX:      22,
+			expectedY:      10,
+			expectedWidth:  35,
+			expectedHeight: 5,
+		},
+		{
+			name:           "centered-with-preview-on-the-right",
+			options:        []func(config *Config){OptionCentered(), OptionPreview(func(string, int) string { return "" })},
+			expectedX:      0,
+			expectedY:      10,
+			expectedWidth:  80,
+			expectedHeight: 5,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			for _, option := range scenario.options {
+				option(&config)
+			}
+			x, y, width, height := computeRegion(80, 25, "question", newChoices([]string{"A", "B", "C"}), &config, &promptState{})
+			if x != scenario.expectedX || y != scenario.expectedY || width != scenario.expectedWidth || height != scenario.expectedHeight {
+				t.Errorf("expected region at (%d, %d) of size %dx%d, got (%d, %d) of size %dx%d", scenario.expectedX, scenario.expectedY, scenario.expectedWidth, scenario.expectedHeight, x, y, width, height)
+			}
+		})
+	}
+}
+
+func TestRegionScreen(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(10, 5)
+	printText(screen, 0, 0, "outside", tcell.StyleDefault)
+	config := defaultConfig
+	region := &regionScreen{Screen: screen, x: 2, y: 1, width: 4, height: 2, config: &config}
+	printText(region, 0, 0, "inside", tcell.StyleDefault)
+	region.Show()
+	scenarios := []struct {
+		x, y         int
+		expectedRune rune
+	}{
+		{x: 0, y: 0, expectedRune: ' '},
+		{x: 2, y: 1, expectedRune: 'i'},
+		{x: 5, y: 1, expectedRune: 'i'},
+		// The text is cut off at the edge of the region
+		{x: 6, y: 1, expectedRune: ' '},
+	}
+	for _, scenario := range scenarios {
+		if mainc, _, _, _ := screen.GetContent(scenario.x, scenario.y); mainc != scenario.expectedRune {
+			t.Errorf("expected %q at (%d, %d), got %q", scenario.expectedRune, scenario.x, scenario.y, mainc)
+		}
+	}
+}
+
+func TestPickWithCenteredPromptAndMouseClick(t *testing.T) {
+	config := defaultConfig
+	OptionCentered()(&config)
+	OptionMouse()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(80, 25)
+	// The prompt starts on the eleventh line, so the second choice is on the thirteenth line
+	screen.InjectMouse(25, 12, tcell.Button1, tcell.ModNone)
+	screen.InjectMouse(25, 12, tcell.ButtonNone, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+}
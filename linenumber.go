@@ -0,0 +1,48 @@
+package gochoice
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// computeLineNumberWidth returns the number of columns needed to display the line number of every choice
+func computeLineNumberWidth(choices []*Choice) int {
+	maxId := 0
+	for _, choice := range choices {
+		if choice.Id > maxId {
+			maxId = choice.Id
+		}
+	}
+	return len(strconv.Itoa(maxId + 1))
+}
+
+// lineNumberGutter returns the line number of the choice, right-aligned and followed by a space, or only spaces if
+// the choice is nil or has no index. It returns an empty string unless line numbers are displayed.
+func lineNumberGutter(choice *Choice, config *Config, state *promptState) string {
+	if !config.LineNumbers {
+		return ""
+	}
+	if choice == nil || choice.Id < 0 {
+		return strings.Repeat(" ", state.lineNumberWidth+1)
+	}
+	return fmt.Sprintf("%*d ", state.lineNumberWidth, choice.Id+1)
+}
+
+// styleLineNumber applies the style of the line numbers to the gutter of the line, which follows the first column
+func styleLineNumber(screen tcell.Screen, y int, config *Config, state *promptState) {
+	for x := 1; x <= state.lineNumberWidth; x++ {
+		mainc, combc, _, _ := screen.GetContent(x, y)
+		screen.SetContent(x, y, mainc, combc, config.Theme.LineNumber)
+	}
+}
+
+// OptionLineNumbers displays the number of each choice before it, which is its index in the choices plus one,
+// right-aligned in a gutter. The numbers are displayed with the LineNumber style of the theme.
+func OptionLineNumbers() func(config *Config) {
+	return func(config *Config) {
+		config.LineNumbers = true
+	}
+}
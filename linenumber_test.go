@@ -0,0 +1,66 @@
+package gochoice
+
+import (
+	"testing"
+)
+
+func TestRenderWithLineNumbers(t *testing.T) {
+	config := defaultConfig
+	OptionLineNumbers()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 14)
+	values := make([]string, 12)
+	for i := range values {
+		values[i] = string(rune('a' + i))
+	}
+	choices := newChoices(values)
+	state := &promptState{lineNumberWidth: computeLineNumberWidth(choices)}
+	render(screen, "question", choices, &config, state, choices[0], "", true, 0, choices)
+	screen.Show()
+	scenarios := []struct {
+		y            int
+		expectedLine string
+	}{
+		{y: 1, expectedLine: "  1 > a"},
+		{y: 2, expectedLine: "  2   b"},
+		{y: 11, expectedLine: " 11   k"},
+	}
+	for _, scenario := range scenarios {
+		var line []rune
+		for x := 0; x < len(scenario.expectedLine); x++ {
+			mainc, _, _, _ := screen.GetContent(x, scenario.y)
+			line = append(line, mainc)
+		}
+		if string(line) != scenario.expectedLine {
+			t.Errorf("expected line %d to be %q, got %q", scenario.y, scenario.expectedLine, string(line))
+		}
+	}
+	// The numbers have their own style, even on the line of the selected choice
+	for x := 1; x <= 2; x++ {
+		if _, _, style, _ := screen.GetContent(x, 1); style != config.Theme.LineNumber {
+			t.Errorf("expected the cell at x=%d to have the style of the line numbers, got %v", x, style)
+		}
+	}
+}
+
+func TestComputeLineNumberWidth(t *testing.T) {
+	scenarios := []struct {
+		numberOfChoices int
+		expectedWidth   int
+	}{
+		{numberOfChoices: 1, expectedWidth: 1},
+		{numberOfChoices: 9, expectedWidth: 1},
+		{numberOfChoices: 10, expectedWidth: 2},
+		{numberOfChoices: 100, expectedWidth: 3},
+	}
+	for _, scenario := range scenarios {
+		choices := newChoices(make([]string, scenario.numberOfChoices))
+		if width := computeLineNumberWidth(choices); width != scenario.expectedWidth {
+			t.Errorf("expected a width of %d for %d choices, got %d", scenario.expectedWidth, scenario.numberOfChoices, width)
+		}
+	}
+}
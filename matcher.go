@@ -0,0 +1,25 @@
+package gochoice
+
+// Matcher decides which choices match the search query, e.g. to search hidden metadata of the choices,
+// such as tags or IDs, which can be retrieved from the Data of the choices created from items.
+type Matcher interface {
+	// Match reports whether the choice matches the search query, which is never empty. It also returns the positions
+	// of the runes of the value of the choice to highlight, which may be nil, and a score. With a Matcher,
+	// the choices matching the search query are sorted by descending score.
+	Match(query string, choice Choice) (bool, []int, int)
+}
+
+// MatcherFunc is a function used as a Matcher
+type MatcherFunc func(query string, choice Choice) (bool, []int, int)
+
+// Match calls the function
+func (matcherFunc MatcherFunc) Match(query string, choice Choice) (bool, []int, int) {
+	return matcherFunc(query, choice)
+}
+
+// OptionMatcher replaces the built-in search by the given Matcher
+func OptionMatcher(matcher Matcher) func(config *Config) {
+	return func(config *Config) {
+		config.Matcher = matcher
+	}
+}
@@ -0,0 +1,32 @@
+package gochoice
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilterChoicesWithMatcher(t *testing.T) {
+	tags := map[string][]string{"web-1": {"nginx", "eu"}, "db-1": {"postgres", "eu"}, "web-2": {"nginx", "us"}}
+	config := defaultConfig
+	OptionMatcher(MatcherFunc(func(query string, choice Choice) (bool, []int, int) {
+		for _, tag := range tags[choice.Value] {
+			if tag == query {
+				// Rank the choices in the US first
+				if strings.Contains(strings.Join(tags[choice.Value], ","), "us") {
+					return true, nil, 1
+				}
+				return true, nil, 0
+			}
+		}
+		return false, nil, 0
+	}))(&config)
+	choices := newChoices([]string{"web-1", "db-1", "web-2"})
+	visibleChoices := filterChoices(choices, "nginx", &config, &promptState{})
+	if len(visibleChoices) != 2 || visibleChoices[0].Value != "web-2" || visibleChoices[1].Value != "web-1" {
+		t.Error("expected [web-2 web-1], got", visibleChoices)
+	}
+	// The matcher is not called without a search query
+	if visibleChoices := filterChoices(choices, "", &config, &promptState{}); len(visibleChoices) != 3 {
+		t.Errorf("expected all 3 choices to be visible, got %d", len(visibleChoices))
+	}
+}
@@ -0,0 +1,155 @@
+package gochoice
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// MenuItem is an item of the menu displayed by PickMenu. Picking an item with children opens a submenu listing them.
+type MenuItem struct {
+	// Label is the text displayed for the item
+	Label string
+
+	// Description is an optional secondary text displayed next to the label
+	Description string
+
+	// Children are the items of the submenu opened by picking this item
+	Children []*MenuItem
+
+	// Data is an optional value associated with the item
+	Data any
+}
+
+// PickMenu prompts the user to choose an item among the given items. Picking an item with children opens a submenu
+// listing them, whose question is followed by the labels of the items leading to it, e.g. "Menu ▸ Settings ▸ Display".
+// In a submenu, the keys bound to KeyMap.Back, as well as Esc and the left arrow key, go back to the parent menu,
+// where the item that opened the submenu is selected again. Esc and the left arrow key only abort the prompt from the
+// top-level menu, while the other keys bound to KeyMap.Abort, such as Ctrl+C, abort it from any menu.
+// It returns the item selected, which has no children, as well as the labels of the items leading to it.
+func PickMenu(question string, items []*MenuItem, options ...Option) (*MenuItem, []string, error) {
+	// Without the interactive screen, the menus are displayed through the fallback and cannot be gone back from
+	var shared *sharedScreen
+	if config := newConfig(options); !config.useFallback() {
+		screen, err := createScreenForConfig(config)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer screen.Fini()
+		shared = newSharedScreen(screen)
+		shared.tty = config.tty
+		defer shared.stopListening()
+	}
+	return runMenu(context.Background(), question, items, shared, options)
+}
+
+// runMenu displays the menu and its submenus on the given screen, or through the fallback if the screen is nil,
+// until an item without children is picked
+func runMenu(ctx context.Context, question string, items []*MenuItem, screen *sharedScreen, options []Option) (*MenuItem, []string, error) {
+	if len(items) == 0 {
+		return nil, nil, ErrNoChoice
+	}
+	// path holds the items that opened the submenus displayed, and openedIndices the index of each of them
+	var path []*MenuItem
+	var openedIndices []int
+	// selectedIndex is the index of the item to select when the menu opens, or -1 to select the default one
+	selectedIndex := -1
+	for {
+		menuItems := items
+		if len(path) > 0 {
+			menuItems = path[len(path)-1].Children
+		}
+		config := withoutCustomChoice(newConfig(options))
+		if selectedIndex >= 0 || len(path) > 0 {
+			// The default choice set with the options only applies to the top-level menu
+			config.DefaultIndex, config.DefaultValue = selectedIndex, ""
+		}
+		config.submenu = len(path) > 0
+		if config.submenu {
+			config.KeyMap = submenuKeyMap(config.KeyMap)
+		}
+		displayedQuestion := menuQuestion(question, path)
+		if len(config.Breadcrumbs) > 0 {
+			// The labels of the items leading to the submenu are displayed in the breadcrumb rather than in the question
+			breadcrumbs := append([]string{}, config.Breadcrumbs...)
+			for _, item := range path {
+				breadcrumbs = append(breadcrumbs, item.Label)
+			}
+			config.Breadcrumbs, displayedQuestion = breadcrumbs, question
+		}
+		selectedChoices, err := pickMenuItem(ctx, displayedQuestion, newChoicesFromMenuItems(menuItems), screen, config)
+		if err == errBack {
+			selectedIndex = openedIndices[len(openedIndices)-1]
+			path, openedIndices = path[:len(path)-1], openedIndices[:len(openedIndices)-1]
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		item := menuItems[selectedChoices[0].Id]
+		if len(item.Children) > 0 {
+			path, openedIndices = append(path, item), append(openedIndices, selectedChoices[0].Id)
+			selectedIndex = -1
+			continue
+		}
+		labels := make([]string, 0, len(path)+1)
+		for _, openedItem := range append(path, item) {
+			labels = append(labels, openedItem.Label)
+		}
+		return item, labels, nil
+	}
+}
+
+// pickMenuItem prompts the user to choose from the items of a menu on the given screen, or through the fallback
+// if the screen is nil
+func pickMenuItem(ctx context.Context, question string, choices []*Choice, screen *sharedScreen, config *Config) ([]*Choice, error) {
+	if screen == nil {
+		return pickWithFallback(ctx, question, choices, config, os.Stdin, os.Stderr)
+	}
+	config.tty = screen.tty
+	screen.SetStyle(config.Theme.background())
+	return pickChoices(ctx, question, choices, screen, config)
+}
+
+// submenuKeyMap returns a copy of the given KeyMap in which Esc and the left arrow key are bound to going back to
+// the parent menu rather than to aborting the prompt
+func submenuKeyMap(keyMap KeyMap) KeyMap {
+	backKeys := []Key{{Key: tcell.KeyEscape}, {Key: tcell.KeyLeft}}
+	isBackKey := func(key Key) bool {
+		return key == backKeys[0] || key == backKeys[1]
+	}
+	var abortKeys []Key
+	for _, key := range keyMap.Abort {
+		if !isBackKey(key) {
+			abortKeys = append(abortKeys, key)
+		}
+	}
+	for _, key := range keyMap.Back {
+		if !isBackKey(key) {
+			backKeys = append(backKeys, key)
+		}
+	}
+	keyMap.Abort, keyMap.Back = abortKeys, backKeys
+	return keyMap
+}
+
+// menuQuestion returns the question of the menu opened by the last of the given items, which is followed by the labels
+// of the items leading to it
+func menuQuestion(question string, path []*MenuItem) string {
+	parts := []string{question}
+	for _, item := range path {
+		parts = append(parts, item.Label)
+	}
+	return strings.Join(parts, breadcrumbSeparator)
+}
+
+// newChoicesFromMenuItems creates a choice for each item of a menu
+func newChoicesFromMenuItems(items []*MenuItem) []*Choice {
+	choices := make([]*Choice, 0, len(items))
+	for i, item := range items {
+		choices = append(choices, &Choice{Id: i, Value: item.Label, Description: item.Description, Data: item})
+	}
+	return choices
+}
@@ -0,0 +1,120 @@
+package gochoice
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func newTestMenu() []*MenuItem {
+	return []*MenuItem{
+		{Label: "Open"},
+		{Label: "Settings", Children: []*MenuItem{
+			{Label: "Display", Children: []*MenuItem{{Label: "Dark mode"}, {Label: "Light mode"}}},
+			{Label: "Sound"},
+		}},
+		{Label: "Quit"},
+	}
+}
+
+func TestRunMenu(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	SimulateKeys(screen, []tcell.Event{
+		// Open "Settings", then "Display"
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		// Go back to "Settings", where "Display" is selected again, and open it again
+		tcell.NewEventKey(tcell.KeyEscape, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		// Pick "Light mode"
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+	})
+	shared := newSharedScreen(screen)
+	defer shared.stopListening()
+	item, path, err := runMenu(context.Background(), "Menu", newTestMenu(), shared, nil)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "Light mode" {
+		t.Error("expected Light mode, got", item.Label)
+	}
+	if strings.Join(path, ",") != "Settings,Display,Light mode" {
+		t.Error("expected [Settings Display Light mode], got", path)
+	}
+}
+
+func TestRunMenuAbortedFromTopLevelMenu(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	SimulateKeys(screen, []tcell.Event{
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone),
+	})
+	shared := newSharedScreen(screen)
+	defer shared.stopListening()
+	_, _, err = runMenu(context.Background(), "Menu", newTestMenu(), shared, nil)
+	if !errors.Is(err, ErrAborted) {
+		t.Errorf("expected %v, got %v", ErrAborted, err)
+	}
+}
+
+func TestRunMenuAbortedFromSubmenu(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	SimulateKeys(screen, []tcell.Event{
+		// Open "Settings", then abort from it rather than going back to the top-level menu
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyCtrlC, 0, tcell.ModNone),
+	})
+	shared := newSharedScreen(screen)
+	defer shared.stopListening()
+	_, _, err = runMenu(context.Background(), "Menu", newTestMenu(), shared, nil)
+	if !errors.Is(err, ErrAborted) {
+		t.Errorf("expected %v, got %v", ErrAborted, err)
+	}
+}
+
+func TestSubmenuKeyMap(t *testing.T) {
+	defaultKeyMap := DefaultKeyMap()
+	keyMap := submenuKeyMap(defaultKeyMap)
+	expectedAbort := []Key{{Key: tcell.KeyCtrlC}}
+	if !reflect.DeepEqual(keyMap.Abort, expectedAbort) {
+		t.Errorf("expected %v, got %v", expectedAbort, keyMap.Abort)
+	}
+	expectedBack := []Key{{Key: tcell.KeyEscape}, {Key: tcell.KeyLeft}, {Key: tcell.KeyBacktab}}
+	if !reflect.DeepEqual(keyMap.Back, expectedBack) {
+		t.Errorf("expected %v, got %v", expectedBack, keyMap.Back)
+	}
+	if len(defaultKeyMap.Abort) != 3 || len(defaultKeyMap.Back) != 1 {
+		t.Error("expected the KeyMap given to be left unchanged, got", defaultKeyMap)
+	}
+}
+
+func TestMenuQuestion(t *testing.T) {
+	items := newTestMenu()
+	if question := menuQuestion("Menu", nil); question != "Menu" {
+		t.Error("expected Menu, got", question)
+	}
+	if question := menuQuestion("Menu", []*MenuItem{items[1], items[1].Children[0]}); question != "Menu ▸ Settings ▸ Display" {
+		t.Error("expected Menu ▸ Settings ▸ Display, got", question)
+	}
+}
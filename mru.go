@@ -0,0 +1,163 @@
+package gochoice
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// defaultMRULimit is the number of values remembered for each question by the stores created with a limit of 0
+const defaultMRULimit = 10
+
+// MRUStore stores the values most recently chosen for each question, so that they can be displayed first
+type MRUStore interface {
+	// Recent returns the values most recently chosen for the given question, starting with the most recent
+	Recent(question string) ([]string, error)
+	// Add records that the given value was chosen for the given question
+	Add(question, value string) error
+}
+
+// MemoryMRUStore is an MRUStore that keeps the values in memory. It is safe for concurrent use.
+type MemoryMRUStore struct {
+	mutex  sync.Mutex
+	values map[string][]string
+	limit  int
+}
+
+// NewMemoryMRUStore creates a MemoryMRUStore remembering up to limit values for each question,
+// or 10 if the limit is 0
+func NewMemoryMRUStore(limit int) *MemoryMRUStore {
+	if limit <= 0 {
+		limit = defaultMRULimit
+	}
+	return &MemoryMRUStore{values: make(map[string][]string), limit: limit}
+}
+
+// Recent returns the values most recently chosen for the given question, starting with the most recent
+func (store *MemoryMRUStore) Recent(question string) ([]string, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return append([]string(nil), store.values[question]...), nil
+}
+
+// Add records that the given value was chosen for the given question
+func (store *MemoryMRUStore) Add(question, value string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.values[question] = addRecentValue(store.values[question], value, store.limit)
+	return nil
+}
+
+// FileMRUStore is an MRUStore that keeps the values in a JSON file, so that they are remembered across runs
+// of the application. It is safe for concurrent use within a process.
+type FileMRUStore struct {
+	mutex sync.Mutex
+	path  string
+	limit int
+}
+
+// NewFileMRUStore creates a FileMRUStore keeping the values in the file at the given path, which is created
+// when a value is first added, and remembering up to limit values for each question, or 10 if the limit is 0
+func NewFileMRUStore(path string, limit int) *FileMRUStore {
+	if limit <= 0 {
+		limit = defaultMRULimit
+	}
+	return &FileMRUStore{path: path, limit: limit}
+}
+
+// Recent returns the values most recently chosen for the given question, starting with the most recent
+func (store *FileMRUStore) Recent(question string) ([]string, error) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	values, err := store.load()
+	if err != nil {
+		return nil, err
+	}
+	return values[question], nil
+}
+
+// Add records that the given value was chosen for the given question
+func (store *FileMRUStore) Add(question, value string) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	values, err := store.load()
+	if err != nil {
+		return err
+	}
+	values[question] = addRecentValue(values[question], value, store.limit)
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(store.path, data, 0600)
+}
+
+// load reads the values of every question from the file, which is considered empty if it doesn't exist
+func (store *FileMRUStore) load() (map[string][]string, error) {
+	values := make(map[string][]string)
+	data, err := os.ReadFile(store.path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// addRecentValue moves the value to the start of the recent values, dropping the oldest ones past the limit
+func addRecentValue(recentValues []string, value string, limit int) []string {
+	newRecentValues := []string{value}
+	for _, recentValue := range recentValues {
+		if recentValue != value && len(newRecentValues) < limit {
+			newRecentValues = append(newRecentValues, recentValue)
+		}
+	}
+	return newRecentValues
+}
+
+// sortByRecentUse moves the choices most recently chosen for the question to the top, starting with the most recent,
+// and keeps the other choices in their original order. Grouped choices and trees are left untouched,
+// since their choices can't be moved out of their group or away from their parent.
+func sortByRecentUse(question string, choices []*Choice, config *Config) []*Choice {
+	if config.tree {
+		return choices
+	}
+	for _, choice := range choices {
+		if choice.header {
+			return choices
+		}
+	}
+	recentValues, err := config.MRU.Recent(question)
+	if err != nil || len(recentValues) == 0 {
+		return choices
+	}
+	sortedChoices := make([]*Choice, 0, len(choices))
+	moved := make(map[*Choice]bool)
+	for _, recentValue := range recentValues {
+		for _, choice := range choices {
+			if choice.Value == recentValue && !moved[choice] {
+				sortedChoices = append(sortedChoices, choice)
+				moved[choice] = true
+				break
+			}
+		}
+	}
+	for _, choice := range choices {
+		if !moved[choice] {
+			sortedChoices = append(sortedChoices, choice)
+		}
+	}
+	return sortedChoices
+}
+
+// OptionMRU displays the choices most recently chosen for the question first, using the given store
+// to remember them. For instance, NewFileMRUStore remembers them across runs of the application.
+func OptionMRU(store MRUStore) func(config *Config) {
+	return func(config *Config) {
+		config.MRU = store
+	}
+}
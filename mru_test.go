@@ -0,0 +1,85 @@
+package gochoice
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickWithMRU(t *testing.T) {
+	store := NewMemoryMRUStore(0)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	choices := []string{"A", "B", "C"}
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	if choice, _, err := pick("question", choices, screen, newConfig([]Option{OptionMRU(store)})); err != nil || choice != "C" {
+		t.Fatalf("expected C, got %s with error %v", choice, err)
+	}
+	// The choice most recently chosen is displayed first, but keeps its index
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := pick("question", choices, screen, newConfig([]Option{OptionMRU(store)}))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" || index != 2 {
+		t.Errorf("expected C at index 2, got %s at index %d", choice, index)
+	}
+}
+
+func TestAddRecentValue(t *testing.T) {
+	scenarios := []struct {
+		name                 string
+		recentValues         []string
+		value                string
+		expectedRecentValues []string
+	}{
+		{name: "first", recentValues: nil, value: "A", expectedRecentValues: []string{"A"}},
+		{name: "new", recentValues: []string{"A", "B"}, value: "C", expectedRecentValues: []string{"C", "A", "B"}},
+		{name: "existing", recentValues: []string{"A", "B", "C"}, value: "B", expectedRecentValues: []string{"B", "A", "C"}},
+		{name: "limit", recentValues: []string{"A", "B", "C"}, value: "D", expectedRecentValues: []string{"D", "A", "B"}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if recentValues := addRecentValue(scenario.recentValues, scenario.value, 3); !reflect.DeepEqual(recentValues, scenario.expectedRecentValues) {
+				t.Errorf("expected %v, got %v", scenario.expectedRecentValues, recentValues)
+			}
+		})
+	}
+}
+
+func TestFileMRUStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mru.json")
+	if err := NewFileMRUStore(path, 0).Add("question", "A"); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := NewFileMRUStore(path, 0).Add("question", "B"); err != nil {
+		t.Fatal(err.Error())
+	}
+	recentValues, err := NewFileMRUStore(path, 0).Recent("question")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !reflect.DeepEqual(recentValues, []string{"B", "A"}) {
+		t.Error("expected [B A], got", recentValues)
+	}
+}
+
+func TestSortByRecentUse(t *testing.T) {
+	config := newConfig([]Option{OptionMRU(NewMemoryMRUStore(0))})
+	_ = config.MRU.Add("question", "B")
+	_ = config.MRU.Add("question", "D")
+	var values []string
+	for _, choice := range sortByRecentUse("question", newChoices([]string{"A", "B", "C", "D"}), config) {
+		values = append(values, choice.Value)
+	}
+	if !reflect.DeepEqual(values, []string{"D", "B", "A", "C"}) {
+		t.Error("expected [D B A C], got", values)
+	}
+}
@@ -0,0 +1,45 @@
+package gochoice
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// noColor reports whether the NO_COLOR environment variable is set to a non-empty value, which asks for text to be
+// displayed without colors, as described at https://no-color.org
+func noColor(getenv func(key string) string) bool {
+	return len(getenv("NO_COLOR")) > 0
+}
+
+// isDumbTerminal reports whether TERM is set to dumb, i.e. the terminal can neither move the cursor nor display colors
+func isDumbTerminal(getenv func(key string) string) bool {
+	return getenv("TERM") == "dumb"
+}
+
+// colorlessScreen is a screen drawing without colors, used when NO_COLOR is set. The theme is then replaced by
+// MonochromeTheme, but the styles of the choices, e.g. Item.Style, and the colors of OptionANSI may still have colors,
+// so the colors are removed from every style while the attributes, such as reverse for the selected choice, are kept.
+type colorlessScreen struct {
+	tcell.Screen
+}
+
+func (screen *colorlessScreen) SetContent(x, y int, mainc rune, combc []rune, style tcell.Style) {
+	screen.Screen.SetContent(x, y, mainc, combc, withoutColors(style))
+}
+
+func (screen *colorlessScreen) SetCell(x, y int, style tcell.Style, ch ...rune) {
+	screen.Screen.SetCell(x, y, withoutColors(style), ch...)
+}
+
+func (screen *colorlessScreen) Fill(r rune, style tcell.Style) {
+	screen.Screen.Fill(r, withoutColors(style))
+}
+
+func (screen *colorlessScreen) SetStyle(style tcell.Style) {
+	screen.Screen.SetStyle(withoutColors(style))
+}
+
+// withoutColors returns the style with the default colors of the terminal, but the same attributes
+func withoutColors(style tcell.Style) tcell.Style {
+	_, _, attributes := style.Decompose()
+	return tcell.StyleDefault.Attributes(attributes)
+}
@@ -0,0 +1,83 @@
+package gochoice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestNoColor(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		env      map[string]string
+		noColor  bool
+		dumbTerm bool
+	}{
+		{name: "unset", env: map[string]string{"TERM": "xterm-256color"}},
+		{name: "no-color", env: map[string]string{"NO_COLOR": "1"}, noColor: true},
+		{name: "empty-no-color", env: map[string]string{"NO_COLOR": ""}},
+		{name: "dumb", env: map[string]string{"TERM": "dumb"}, dumbTerm: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			getenv := func(key string) string {
+				return scenario.env[key]
+			}
+			if noColor(getenv) != scenario.noColor {
+				t.Errorf("expected noColor to be %v", scenario.noColor)
+			}
+			if isDumbTerminal(getenv) != scenario.dumbTerm {
+				t.Errorf("expected isDumbTerminal to be %v", scenario.dumbTerm)
+			}
+		})
+	}
+}
+
+func TestNewConfigWithNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+	config := newConfig([]Option{OptionTheme(DraculaTheme()), OptionCheckboxMarkers("◉", "◯")})
+	expectedTheme := MonochromeTheme()
+	expectedTheme.CheckedMarker, expectedTheme.UncheckedMarker = "◉", "◯"
+	if !config.noColor || !reflect.DeepEqual(config.Theme, expectedTheme) {
+		t.Error("expected the monochrome theme to be used")
+	}
+}
+
+func TestUseFallbackWithDumbTerminal(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	if config := newConfig(nil); !config.useFallback() {
+		t.Error("expected the fallback to be used")
+	}
+	if config := newConfig([]Option{OptionFallback(FallbackNever)}); config.useFallback() {
+		t.Error("expected the fallback not to be used with FallbackNever")
+	}
+}
+
+func TestPickRichWithNoColor(t *testing.T) {
+	config := defaultConfig
+	config.Theme, config.noColor = MonochromeTheme(), true
+	// The first item is not selected, so that it is drawn with its own style
+	config.DefaultIndex = 1
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatal("encountered error while creating simulation screen:", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	items := []Item{{Label: "danger", Style: tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true)}, {Label: "safe"}}
+	if _, _, err = pickRich("question", items, screen, &config); err != nil {
+		t.Fatal(err.Error())
+	}
+	width, height := screen.Size()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			_, _, style, _ := screen.GetContent(x, y)
+			if foreground, background, _ := style.Decompose(); foreground != tcell.ColorDefault || background != tcell.ColorDefault {
+				t.Fatalf("expected no colors at (%d, %d), got %v on %v", x, y, foreground, background)
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package gochoice
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// removeDiacritics returns the text without diacritics, e.g. "über" becomes "uber", whether the diacritics are
+// precomposed or combining characters. It also returns, for each rune of the text returned, the index of the rune
+// of the original text it comes from, so that matched positions can be mapped back to the original text.
+func removeDiacritics(text string) (string, []int) {
+	runes := make([]rune, 0, len(text))
+	origins := make([]int, 0, len(text))
+	index := 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			runes, origins = append(runes, r), append(origins, index)
+		} else {
+			// Decompose the rune into its base character followed by its combining marks, and drop the marks
+			for _, decomposedRune := range norm.NFD.String(string(r)) {
+				if !unicode.Is(unicode.Mn, decomposedRune) {
+					runes, origins = append(runes, decomposedRune), append(origins, index)
+				}
+			}
+		}
+		index++
+	}
+	return string(runes), origins
+}
+
+// matchWithoutDiacritics is like matchText, but diacritics are ignored in both the value and the search query
+func matchWithoutDiacritics(value, searchQuery string, config *Config, state *promptState) (bool, int, []int) {
+	normalizedValue, origins := removeDiacritics(value)
+	normalizedQuery, _ := removeDiacritics(searchQuery)
+	matched, score, positions := matchText(normalizedValue, normalizedQuery, config, state)
+	if !matched {
+		return false, 0, nil
+	}
+	originalPositions := make([]int, len(positions))
+	for i, position := range positions {
+		originalPositions[i] = origins[position]
+	}
+	return true, score, uniqueSortedPositions(originalPositions)
+}
+
+// OptionUnicodeNormalization makes the search ignore diacritics, so that typing "uber" matches "über",
+// and makes precomposed and decomposed forms of the same characters match each other
+func OptionUnicodeNormalization() func(config *Config) {
+	return func(config *Config) {
+		config.UnicodeNormalization = true
+	}
+}
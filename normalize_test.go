@@ -0,0 +1,59 @@
+package gochoice
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRemoveDiacritics(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		text            string
+		expectedText    string
+		expectedOrigins []int
+	}{
+		{name: "ascii", text: "uber", expectedText: "uber", expectedOrigins: []int{0, 1, 2, 3}},
+		{name: "precomposed", text: "\u00fcber", expectedText: "uber", expectedOrigins: []int{0, 1, 2, 3}},
+		{name: "decomposed", text: "u\u0308ber", expectedText: "uber", expectedOrigins: []int{0, 2, 3, 4}},
+		{name: "wide", text: "日本", expectedText: "日本", expectedOrigins: []int{0, 1}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			text, origins := removeDiacritics(scenario.text)
+			if text != scenario.expectedText {
+				t.Errorf("expected %q, got %q", scenario.expectedText, text)
+			}
+			if fmt.Sprint(origins) != fmt.Sprint(scenario.expectedOrigins) {
+				t.Errorf("expected origins %v, got %v", scenario.expectedOrigins, origins)
+			}
+		})
+	}
+}
+
+func TestMatchChoiceWithUnicodeNormalization(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		value             string
+		searchQuery       string
+		expectedMatch     bool
+		expectedPositions []int
+	}{
+		{name: "without-diacritics", value: "\u00fcber", searchQuery: "uber", expectedMatch: true, expectedPositions: []int{0, 1, 2, 3}},
+		{name: "precomposed-query", value: "u\u0308ber", searchQuery: "\u00fcb", expectedMatch: true, expectedPositions: []int{0, 2}},
+		{name: "decomposed-query", value: "caf\u00e9", searchQuery: "cafe\u0301", expectedMatch: true, expectedPositions: []int{0, 1, 2, 3}},
+		{name: "no-match", value: "\u00fcber", searchQuery: "ober", expectedMatch: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionUnicodeNormalization()(&config)
+			matched, _, positions := matchChoice(scenario.value, scenario.searchQuery, &config, &promptState{})
+			if matched != scenario.expectedMatch {
+				t.Errorf("expected %v, got %v", scenario.expectedMatch, matched)
+			}
+			if fmt.Sprint(positions) != fmt.Sprint(scenario.expectedPositions) {
+				t.Errorf("expected %v, got %v", scenario.expectedPositions, positions)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package gochoice
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Picker is a prompt that can be run repeatedly, e.g. as the main menu of an application.
+// Each run starts with the cursor on the choice that was selected when the previous run ended.
+// Its choices can be changed from another goroutine while it is open, e.g. to reflect resources
+// that are created or deleted while the user is choosing one of them.
+type Picker struct {
+	question string
+	options  []Option
+
+	mutex  sync.Mutex
+	values []string
+	// changed is notified when the choices change while the prompt is open, and is nil otherwise
+	changed chan struct{}
+	// status is displayed on the status line reserved with OptionStatusLine
+	status string
+	// statusChanged is notified when the status changes while the prompt is open, and is nil otherwise
+	statusChanged chan struct{}
+	// cursor is the choice selected when the last run ended, or nil if the picker has never been run
+	cursor *pickerCursor
+}
+
+// pickerCursor is the position of the cursor of a Picker
+type pickerCursor struct {
+	value string
+	index int
+}
+
+// New creates a Picker prompting the user to choose from the given choices
+func New(question string, choices []string, options ...Option) *Picker {
+	return &Picker{question: question, options: options, values: append([]string(nil), choices...)}
+}
+
+// Run prompts the user to choose from the current choices of the picker, like Pick.
+// The index returned is the index of the choice selected in the choices of the picker at that time.
+func (picker *Picker) Run() (string, int, error) {
+	return picker.RunContext(context.Background())
+}
+
+// RunContext is like Run, but the prompt is aborted with ErrContextCanceled as soon as the provided context is done
+func (picker *Picker) RunContext(ctx context.Context) (string, int, error) {
+	config := newConfig(picker.options)
+	choices, stop := picker.start(config)
+	defer stop()
+	return toValueAndIndex(runPicker(ctx, picker.question, choices, config))
+}
+
+func (picker *Picker) run(screen tcell.Screen, config *Config) (string, int, error) {
+	choices, stop := picker.start(config)
+	defer stop()
+	return toValueAndIndex(pickChoices(context.Background(), picker.question, choices, screen, config))
+}
+
+// SetQuestion replaces the question of the picker. It takes effect on the next run.
+func (picker *Picker) SetQuestion(question string) {
+	picker.question = question
+}
+
+// SetOptions replaces the options of the picker. It takes effect on the next run.
+func (picker *Picker) SetOptions(options ...Option) {
+	picker.options = options
+}
+
+// start prepares the config for a run of the picker and returns the choices to pick from,
+// as well as a function to call once the prompt is closed
+func (picker *Picker) start(config *Config) ([]*Choice, func()) {
+	values := picker.Choices()
+	picker.mutex.Lock()
+	cursor := picker.cursor
+	picker.mutex.Unlock()
+	if cursor != nil {
+		// Restore the cursor on the same choice, even if the choices have changed since the last run
+		if cursor.index >= 0 && cursor.index < len(values) && values[cursor.index] == cursor.value {
+			config.DefaultIndex, config.DefaultValue = cursor.index, ""
+		} else {
+			config.DefaultIndex, config.DefaultValue = 0, cursor.value
+		}
+	}
+	onChange := config.OnChange
+	config.OnChange = func(value string, index int) {
+		picker.mutex.Lock()
+		picker.cursor = &pickerCursor{value: value, index: index}
+		picker.mutex.Unlock()
+		if onChange != nil {
+			onChange(value, index)
+		}
+	}
+	updates, stop := picker.listen()
+	config.updates = updates
+	config.status = picker.Status
+	return newChoices(values), stop
+}
+
+// Choices returns the current choices of the picker
+func (picker *Picker) Choices() []string {
+	picker.mutex.Lock()
+	defer picker.mutex.Unlock()
+	return append([]string(nil), picker.values...)
+}
+
+// SetChoices replaces the choices of the picker. If the prompt is open, it is updated,
+// and the choice selected remains selected if it is still one of the choices.
+// It is safe to call from any goroutine.
+func (picker *Picker) SetChoices(choices []string) {
+	picker.mutex.Lock()
+	defer picker.mutex.Unlock()
+	picker.values = append([]string(nil), choices...)
+	picker.notifyChange()
+}
+
+// AppendChoice adds a choice after the existing choices of the picker. If the prompt is open, it is updated.
+// It is safe to call from any goroutine.
+func (picker *Picker) AppendChoice(choice string) {
+	picker.mutex.Lock()
+	defer picker.mutex.Unlock()
+	picker.values = append(picker.values, choice)
+	picker.notifyChange()
+}
+
+// Status returns the status of the picker, set with SetStatus
+func (picker *Picker) Status() string {
+	picker.mutex.Lock()
+	defer picker.mutex.Unlock()
+	return picker.status
+}
+
+// SetStatus sets the message displayed on the status line reserved with OptionStatusLine, e.g. "Reloading…".
+// If the prompt is open, it is updated. An empty status clears the status line.
+// It is safe to call from any goroutine.
+func (picker *Picker) SetStatus(status string) {
+	picker.mutex.Lock()
+	defer picker.mutex.Unlock()
+	picker.status = status
+	if picker.statusChanged == nil {
+		return
+	}
+	select {
+	case picker.statusChanged <- struct{}{}:
+	default:
+	}
+}
+
+// notifyChange notifies the open prompt, if any, that the choices have changed.
+// Notifications that haven't been handled yet are merged, since the prompt always uses the latest choices.
+func (picker *Picker) notifyChange() {
+	if picker.changed == nil {
+		return
+	}
+	select {
+	case picker.changed <- struct{}{}:
+	default:
+	}
+}
+
+// listen returns a channel of updates replacing the choices of the open prompt with the current choices
+// of the picker whenever they change, as well as a function to call once the prompt is closed
+func (picker *Picker) listen() (<-chan choiceUpdate, func()) {
+	picker.mutex.Lock()
+	changed, statusChanged := make(chan struct{}, 1), make(chan struct{}, 1)
+	picker.changed, picker.statusChanged = changed, statusChanged
+	picker.mutex.Unlock()
+	updates := make(chan choiceUpdate)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-changed:
+				select {
+				case updates <- replaceChoices(picker.Choices()):
+				case <-done:
+					return
+				}
+			case <-statusChanged:
+				// Leave the choices unchanged, so that the prompt is only rendered again with the new status
+				select {
+				case updates <- keepChoices:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return updates, func() {
+		picker.mutex.Lock()
+		picker.changed, picker.statusChanged = nil, nil
+		picker.mutex.Unlock()
+		close(done)
+	}
+}
+
+// replaceChoices returns an update replacing the choices with new choices for the given values.
+// If the value of the choice selected is still at the same index, or failing that anywhere in the
+// values, the corresponding new choice is selected.
+func replaceChoices(values []string) choiceUpdate {
+	return func(choices []*Choice) []*Choice {
+		newChoices := make([]*Choice, 0, len(values))
+		for i, value := range values {
+			newChoices = append(newChoices, &Choice{Id: i, Value: value})
+		}
+		for _, choice := range choices {
+			if !choice.Selected {
+				continue
+			}
+			if choice.Id < len(newChoices) && newChoices[choice.Id].Value == choice.Value {
+				newChoices[choice.Id].Selected = true
+				break
+			}
+			for _, newChoice := range newChoices {
+				if newChoice.Value == choice.Value {
+					newChoice.Selected = true
+					break
+				}
+			}
+			break
+		}
+		return newChoices
+	}
+}
+
+// keepChoices is an update leaving the choices unchanged
+func keepChoices(choices []*Choice) []*Choice {
+	return choices
+}
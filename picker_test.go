@@ -0,0 +1,113 @@
+package gochoice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickerSetChoicesWhileOpen(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	picker := New("question", []string{"A", "B", "C"})
+	go func() {
+		picker.SetChoices([]string{"X", "B"})
+		picker.AppendChoice("Z")
+		// Wait for the prompt to display the last choice before navigating
+		waitForText(t, screen, "Z")
+		screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}()
+	choice, index, err := picker.run(screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" || index != 1 {
+		t.Errorf("expected B at index 1, got %s at index %d", choice, index)
+	}
+	if choices := picker.Choices(); strings.Join(choices, ",") != "X,B,Z" {
+		t.Error("expected [X B Z], got", choices)
+	}
+}
+
+func TestReplaceChoices(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		values        []string
+		selectedIndex int
+		expectedValue string
+	}{
+		{name: "same-index", values: []string{"A", "B", "C", "D"}, selectedIndex: 1, expectedValue: "B"},
+		{name: "moved", values: []string{"B", "C"}, selectedIndex: 1, expectedValue: "B"},
+		{name: "removed", values: []string{"A", "C"}, selectedIndex: 1, expectedValue: ""},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			choices := newChoices([]string{"A", "B", "C"})
+			selectChoice(choices[0], choices[scenario.selectedIndex])
+			var selectedValue string
+			for _, choice := range replaceChoices(scenario.values)(choices) {
+				if choice.Selected {
+					selectedValue = choice.Value
+				}
+			}
+			if selectedValue != scenario.expectedValue {
+				t.Errorf("expected %q to be selected, got %q", scenario.expectedValue, selectedValue)
+			}
+		})
+	}
+}
+
+// waitForText waits until the given text is displayed anywhere on the screen
+func waitForText(t *testing.T, screen tcell.SimulationScreen, text string) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		width, height := screen.Size()
+		var content []rune
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				mainc, _, _, _ := screen.GetContent(x, y)
+				content = append(content, mainc)
+			}
+		}
+		if strings.Contains(string(content), text) {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Errorf("expected %q to be displayed", text)
+}
+
+func TestPickerRunRestoresCursor(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	picker := New("question", []string{"A", "B", "C"})
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	if _, _, err := picker.run(screen, newConfig(nil)); !errors.Is(err, ErrAborted) {
+		t.Fatal("expected ErrAborted, got", err)
+	}
+	// The cursor is where it was when the previous run was aborted, even though a choice was inserted before it
+	picker.SetChoices([]string{"Z", "A", "B", "C"})
+	picker.SetOptions(OptionDefaultIndex(0))
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := picker.run(screen, newConfig(picker.options))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" || index != 3 {
+		t.Errorf("expected C at index 3, got %s at index %d", choice, index)
+	}
+}
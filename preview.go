@@ -0,0 +1,107 @@
+package gochoice
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// previewDebounceDelay is the delay during which the selection must not change for the preview to be computed
+const previewDebounceDelay = 50 * time.Millisecond
+
+// PreviewPosition is the position of the preview pane relative to the choices
+type PreviewPosition int
+
+const (
+	// PreviewRight displays the preview on the right half of the screen
+	PreviewRight PreviewPosition = iota
+
+	// PreviewBottom displays the preview on the bottom third of the screen, above the search bar
+	PreviewBottom
+)
+
+// preview is the text computed by Config.Preview for a choice
+type preview struct {
+	choice *Choice
+	text   string
+	// panicValue is the value Config.Preview panicked with, if it did
+	panicValue any
+}
+
+// computePreview computes the preview of the given choice and sends it, unless done is closed first.
+// If Config.Preview panics, the panic is sent instead, so that the event loop can finalize the screen
+// before panicking, which wouldn't happen if the goroutine computing the preview crashed the program.
+func computePreview(choice *Choice, config *Config, previews chan<- preview, done <-chan struct{}) {
+	result := preview{choice: choice}
+	func() {
+		defer func() {
+			result.panicValue = recover()
+		}()
+		result.text = config.Preview(choice.Value, choice.Id)
+	}()
+	select {
+	case previews <- result:
+	case <-done:
+	}
+}
+
+// computeOptionsWidth returns the number of columns in which the options are displayed
+func computeOptionsWidth(screenWidth int, config *Config) int {
+	if config.Preview != nil && config.PreviewPosition == PreviewRight {
+		return screenWidth / 2
+	}
+	return screenWidth
+}
+
+// computeBottomPreviewHeight returns the number of lines of the preview when it is displayed below the options
+func computeBottomPreviewHeight(screenHeight int) int {
+	return screenHeight / 3
+}
+
+// renderPreview renders the given text in the preview pane, separated from the options by a line.
+// Lines that don't fit in the pane are cut off.
+func renderPreview(screen tcell.Screen, question, text string, config *Config) {
+	screenWidth, screenHeight := screen.Size()
+	lines := strings.Split(strings.ReplaceAll(text, "\t", "    "), "\n")
+	// The footer and the search bar are displayed below the preview
+	bottom := screenHeight - config.bottomSearchBarHeight() - len(config.footerLines())
+	if config.PreviewPosition == PreviewBottom {
+		y := bottom - computeBottomPreviewHeight(screenHeight) - 1
+		for x := 0; x < screenWidth; x++ {
+			screen.SetCell(x, y, config.Theme.Scrollbar, tcell.RuneHLine)
+		}
+		for i := 0; i < len(lines) && y+1+i < bottom; i++ {
+			printText(screen, 1, y+1+i, lines[i], config.Theme.Preview)
+		}
+		return
+	}
+	x := computeOptionsWidth(screenWidth, config)
+	// The question, the search bar if it is displayed below it and the header are displayed above the preview
+	y := questionHeight(question, config) + config.topSearchBarHeight() + len(textLines(config.Header))
+	for i := y; i < bottom; i++ {
+		eraseWideCharacterBefore(screen, x, i)
+		screen.SetCell(x, i, config.Theme.Scrollbar, tcell.RuneVLine)
+		// Clear whatever the options displayed on the right half of the line
+		printText(screen, x+1, i, "", config.Theme.background())
+	}
+	for i := 0; i < len(lines) && y+i < bottom; i++ {
+		printText(screen, x+2, y+i, lines[i], config.Theme.Preview)
+	}
+}
+
+// OptionPreview displays a pane with the text returned by the given function for the selected choice,
+// like a detailed view of it. The function is called in the background once the selection stops changing,
+// so it may be slow without making the prompt less responsive. It is never called concurrently.
+func OptionPreview(preview func(value string, index int) string) func(config *Config) {
+	return func(config *Config) {
+		config.Preview = preview
+	}
+}
+
+// OptionPreviewPosition sets where the preview pane set with OptionPreview is displayed. The default is PreviewRight.
+func OptionPreviewPosition(position PreviewPosition) func(config *Config) {
+	return func(config *Config) {
+		config.PreviewPosition = position
+	}
+}
@@ -0,0 +1,143 @@
+package gochoice
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickWithPreview(t *testing.T) {
+	config := defaultConfig
+	OptionPreview(func(value string, index int) string {
+		return fmt.Sprintf("preview of %s at %d", value, index)
+	})(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(60, 10)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	go func() {
+		waitForText(t, screen, "preview of B at 1")
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}()
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+}
+
+func TestPickWithSlowPreview(t *testing.T) {
+	config := defaultConfig
+	var calls, running int32
+	OptionPreview(func(value string, index int) string {
+		atomic.AddInt32(&calls, 1)
+		if atomic.AddInt32(&running, 1) > 1 {
+			t.Error("expected the preview not to be computed concurrently")
+		}
+		defer atomic.AddInt32(&running, -1)
+		time.Sleep(4 * previewDebounceDelay)
+		return "preview of " + value
+	})(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(60, 10)
+	go func() {
+		// The selection changes after each debounce delay while the preview of A is still being computed
+		for i := 0; i < 2; i++ {
+			time.Sleep(2 * previewDebounceDelay)
+			screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+		}
+		waitForText(t, screen, "preview of C")
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}()
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" {
+		t.Error("expected C, got", choice)
+	}
+	if calls := atomic.LoadInt32(&calls); calls > 2 {
+		t.Errorf("expected only the previews of A and C to be computed, got %d previews", calls)
+	}
+}
+
+func TestPickWithPanickingPreview(t *testing.T) {
+	config := defaultConfig
+	OptionPreview(func(value string, index int) string {
+		panic("preview failed")
+	})(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	defer func() {
+		// The panic must reach the goroutine running the prompt, so that the screen can be finalized
+		if r := recover(); r != "preview failed" {
+			t.Errorf("expected the panic of the preview, got %v", r)
+		}
+	}()
+	_, _, _ = pick("question", []string{"A", "B", "C"}, screen, &config)
+	t.Error("expected pick to panic")
+}
+
+func TestRenderPreview(t *testing.T) {
+	scenarios := []struct {
+		name      string
+		position  PreviewPosition
+		x, y      int
+		separator rune
+		sx, sy    int
+	}{
+		{name: "right", position: PreviewRight, x: 22, y: 1, separator: tcell.RuneVLine, sx: 20, sy: 1},
+		{name: "bottom", position: PreviewBottom, x: 1, y: 6, separator: tcell.RuneHLine, sx: 0, sy: 5},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionPreview(func(string, int) string { return "details" })(&config)
+			OptionPreviewPosition(scenario.position)(&config)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.SetSize(40, 10)
+			renderPreview(screen, "question", "details\nmore", &config)
+			if mainc, _, _, _ := screen.GetContent(scenario.x, scenario.y); mainc != 'd' {
+				t.Errorf("expected preview at (%d, %d), got %q", scenario.x, scenario.y, mainc)
+			}
+			if mainc, _, _, _ := screen.GetContent(scenario.sx, scenario.sy); mainc != scenario.separator {
+				t.Errorf("expected separator at (%d, %d), got %q", scenario.sx, scenario.sy, mainc)
+			}
+		})
+	}
+}
+
+func TestComputePageSizeWithBottomPreview(t *testing.T) {
+	config := defaultConfig
+	OptionPreview(func(string, int) string { return "" })(&config)
+	OptionPreviewPosition(PreviewBottom)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 12)
+	// 12 lines minus the question, the search bar, the separator and 4 lines of preview
+	if pageSize := computePageSize(screen, "question", &config); pageSize != 5 {
+		t.Error("expected 5, got", pageSize)
+	}
+}
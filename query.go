@@ -0,0 +1,121 @@
+package gochoice
+
+import (
+	"sort"
+	"strings"
+)
+
+// queryTerm is a term of a search query using the extended syntax of OptionExtendedSearch
+type queryTerm struct {
+	text string
+	// negated terms must not match
+	negated bool
+	// exact terms are matched as a substring, even with fuzzy search
+	exact bool
+	// prefix and suffix terms must match the start and the end of the value respectively
+	prefix bool
+	suffix bool
+}
+
+// parseExtendedQuery splits the search query into terms separated by spaces, each of which may use the following
+// operators: !term excludes the values matching the term, 'term matches the term exactly, ^term matches values
+// starting with the term and term$ matches values ending with the term. Terms made only of operators are ignored.
+func parseExtendedQuery(searchQuery string) []queryTerm {
+	var terms []queryTerm
+	for _, field := range strings.Fields(searchQuery) {
+		var term queryTerm
+		if strings.HasPrefix(field, "!") {
+			term.negated, field = true, field[1:]
+		}
+		if strings.HasPrefix(field, "'") {
+			term.exact, field = true, field[1:]
+		} else if strings.HasPrefix(field, "^") {
+			term.prefix, field = true, field[1:]
+		}
+		if strings.HasSuffix(field, "$") {
+			term.suffix, field = true, field[:len(field)-1]
+		}
+		if len(field) == 0 {
+			continue
+		}
+		term.text = field
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+// extendedMatch reports whether the value matches every term of the search query using the extended syntax,
+// as well as the sum of the scores of the terms and the positions of the runes matched by them
+func extendedMatch(value, searchQuery string, ignoreCase bool, config *Config) (bool, int, []int) {
+	totalScore := 0
+	var allPositions []int
+	for _, term := range parseExtendedQuery(searchQuery) {
+		matched, score, positions := term.match(value, ignoreCase, config)
+		if matched == term.negated {
+			return false, 0, nil
+		}
+		if term.negated {
+			continue
+		}
+		totalScore += score
+		allPositions = append(allPositions, positions...)
+	}
+	return true, totalScore, uniqueSortedPositions(allPositions)
+}
+
+// match reports whether the value matches the term, ignoring whether it is negated
+func (term queryTerm) match(value string, ignoreCase bool, config *Config) (bool, int, []int) {
+	if !term.prefix && !term.suffix {
+		if config.FuzzySearch && !term.exact {
+			return fuzzyMatch(value, term.text, ignoreCase)
+		}
+		return substringMatch(value, term.text, ignoreCase)
+	}
+	valueRunes, termRunes := []rune(value), []rune(term.text)
+	if ignoreCase {
+		valueRunes, termRunes = toLowerRunes(valueRunes), toLowerRunes(termRunes)
+	}
+	if len(termRunes) > len(valueRunes) || (term.prefix && term.suffix && len(termRunes) != len(valueRunes)) {
+		return false, 0, nil
+	}
+	start := 0
+	if term.suffix {
+		start = len(valueRunes) - len(termRunes)
+	}
+	if string(valueRunes[start:start+len(termRunes)]) != string(termRunes) {
+		return false, 0, nil
+	}
+	positions := make([]int, len(termRunes))
+	for i := range positions {
+		positions[i] = start + i
+	}
+	return true, 0, positions
+}
+
+// uniqueSortedPositions sorts the positions in ascending order and removes duplicates
+func uniqueSortedPositions(positions []int) []int {
+	if len(positions) == 0 {
+		return nil
+	}
+	sort.Ints(positions)
+	uniquePositions := positions[:1]
+	for _, position := range positions[1:] {
+		if position != uniquePositions[len(uniquePositions)-1] {
+			uniquePositions = append(uniquePositions, position)
+		}
+	}
+	return uniquePositions
+}
+
+// OptionExtendedSearch enables operators in the search query, similar to those of fzf.
+// Terms separated by spaces must all match, and each term may use the following operators:
+//
+//	!term   excludes the choices matching the term
+//	'term   matches the term exactly, even with fuzzy search
+//	^term   matches the choices starting with the term
+//	term$   matches the choices ending with the term
+func OptionExtendedSearch() func(config *Config) {
+	return func(config *Config) {
+		config.ExtendedSearch = true
+	}
+}
@@ -0,0 +1,66 @@
+package gochoice
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseExtendedQuery(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		searchQuery   string
+		expectedTerms []queryTerm
+	}{
+		{name: "and", searchQuery: "foo  bar", expectedTerms: []queryTerm{{text: "foo"}, {text: "bar"}}},
+		{name: "negated", searchQuery: "!baz", expectedTerms: []queryTerm{{text: "baz", negated: true}}},
+		{name: "exact", searchQuery: "'exact", expectedTerms: []queryTerm{{text: "exact", exact: true}}},
+		{name: "anchors", searchQuery: "^pre suf$ ^whole$", expectedTerms: []queryTerm{{text: "pre", prefix: true}, {text: "suf", suffix: true}, {text: "whole", prefix: true, suffix: true}}},
+		{name: "negated-prefix", searchQuery: "!^test", expectedTerms: []queryTerm{{text: "test", negated: true, prefix: true}}},
+		{name: "operators-only", searchQuery: "! ^ $", expectedTerms: nil},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if terms := parseExtendedQuery(scenario.searchQuery); !reflect.DeepEqual(terms, scenario.expectedTerms) {
+				t.Errorf("expected %+v, got %+v", scenario.expectedTerms, terms)
+			}
+		})
+	}
+}
+
+func TestMatchChoiceWithExtendedSearch(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		value             string
+		searchQuery       string
+		fuzzy             bool
+		expectedMatch     bool
+		expectedPositions []int
+	}{
+		{name: "and", value: "prod-eu-west", searchQuery: "eu prod", expectedMatch: true, expectedPositions: []int{0, 1, 2, 3, 5, 6}},
+		{name: "and-missing-term", value: "prod-eu-west", searchQuery: "eu us", expectedMatch: false},
+		{name: "negated", value: "prod-eu-west", searchQuery: "prod !us", expectedMatch: true, expectedPositions: []int{0, 1, 2, 3}},
+		{name: "negated-matching", value: "prod-us-west", searchQuery: "prod !us", expectedMatch: false},
+		{name: "prefix", value: "prod-eu-west", searchQuery: "^prod", expectedMatch: true, expectedPositions: []int{0, 1, 2, 3}},
+		{name: "prefix-in-middle", value: "eu-prod", searchQuery: "^prod", expectedMatch: false},
+		{name: "suffix", value: "prod-eu-west", searchQuery: "west$", expectedMatch: true, expectedPositions: []int{8, 9, 10, 11}},
+		{name: "whole", value: "prod", searchQuery: "^prod$", expectedMatch: true, expectedPositions: []int{0, 1, 2, 3}},
+		{name: "whole-longer-value", value: "prod-eu", searchQuery: "^prod$", expectedMatch: false},
+		{name: "fuzzy", value: "prod-eu-west", searchQuery: "pew", fuzzy: true, expectedMatch: true, expectedPositions: []int{0, 5, 8}},
+		{name: "exact-with-fuzzy", value: "prod-eu-west", searchQuery: "'pew", fuzzy: true, expectedMatch: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionExtendedSearch()(&config)
+			config.FuzzySearch = scenario.fuzzy
+			matched, _, positions := matchChoice(scenario.value, scenario.searchQuery, &config, &promptState{})
+			if matched != scenario.expectedMatch {
+				t.Errorf("expected %v, got %v", scenario.expectedMatch, matched)
+			}
+			if fmt.Sprint(positions) != fmt.Sprint(scenario.expectedPositions) {
+				t.Errorf("expected %v, got %v", scenario.expectedPositions, positions)
+			}
+		})
+	}
+}
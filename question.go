@@ -0,0 +1,77 @@
+package gochoice
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// Alignment is the horizontal position of a line of the question on the screen
+type Alignment int
+
+const (
+	// AlignLeft displays the line on the left of the screen
+	AlignLeft Alignment = iota
+
+	// AlignCenter displays the line in the middle of the screen
+	AlignCenter
+
+	// AlignRight displays the line on the right of the screen
+	AlignRight
+)
+
+// renderQuestion renders the lines of the question from the top of the screen, each with its alignment,
+// and returns the number of lines rendered
+func renderQuestion(screen tcell.Screen, questionLines []string, config *Config) int {
+	screenWidth, _ := screen.Size()
+	if config.breadcrumbHeight() > 0 {
+		renderBreadcrumb(screen, config)
+	}
+	for i, questionLine := range questionLines {
+		y := config.breadcrumbHeight() + i
+		x := 0
+		// The margin of one column is kept on both sides of the line
+		lineWidth := runewidth.StringWidth(questionLine) + 2
+		switch config.questionAlignment(i) {
+		case AlignCenter:
+			x = (screenWidth - lineWidth) / 2
+		case AlignRight:
+			x = screenWidth - lineWidth
+		}
+		if x < 0 {
+			x = 0
+		} else if x > 0 {
+			// printText only clears the rest of the line, so the columns before the line are cleared first
+			printText(screen, 0, y, "", config.Theme.Question)
+		}
+		printText(screen, x, y, " "+questionLine, config.Theme.Question)
+	}
+	return config.breadcrumbHeight() + len(questionLines)
+}
+
+// questionAlignment returns the alignment of the line of the question at the given index.
+// Lines with no alignment of their own have the alignment of the last line that has one.
+func (config *Config) questionAlignment(index int) Alignment {
+	if len(config.QuestionAlignment) == 0 {
+		return AlignLeft
+	}
+	if index >= len(config.QuestionAlignment) {
+		index = len(config.QuestionAlignment) - 1
+	}
+	return config.QuestionAlignment[index]
+}
+
+// OptionQuestionStyle adds the attributes of the given style to the question,
+// e.g. OptionQuestionStyle(Style{Bold: true, Underline: true})
+func OptionQuestionStyle(style Style) func(config *Config) {
+	return func(config *Config) {
+		config.Theme.Question = style.applyTo(config.Theme.Question)
+	}
+}
+
+// OptionQuestionAlignment sets the alignment of each line of the question, in order. The lines that follow the last
+// alignment given have that alignment, so OptionQuestionAlignment(AlignCenter) centers all lines of the question.
+func OptionQuestionAlignment(alignments ...Alignment) func(config *Config) {
+	return func(config *Config) {
+		config.QuestionAlignment = alignments
+	}
+}
@@ -0,0 +1,61 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRenderWithQuestionAlignment(t *testing.T) {
+	config := defaultConfig
+	OptionQuestionAlignment(AlignLeft, AlignCenter, AlignRight)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 6)
+	choices := newChoices([]string{"A", "B"})
+	render(screen, "Title\nab\ncd", choices, &config, &promptState{}, choices[0], "", true, 0, choices)
+	screen.Show()
+	scenarios := []struct {
+		x, y         int
+		expectedRune rune
+	}{
+		{x: 1, y: 0, expectedRune: 'T'},
+		{x: 8, y: 1, expectedRune: ' '},
+		{x: 9, y: 1, expectedRune: 'a'},
+		{x: 17, y: 2, expectedRune: 'c'},
+		{x: 18, y: 2, expectedRune: 'd'},
+	}
+	for _, scenario := range scenarios {
+		if mainc, _, _, _ := screen.GetContent(scenario.x, scenario.y); mainc != scenario.expectedRune {
+			t.Errorf("expected %q at (%d, %d), got %q", scenario.expectedRune, scenario.x, scenario.y, mainc)
+		}
+	}
+}
+
+func TestQuestionAlignment(t *testing.T) {
+	config := defaultConfig
+	if alignment := config.questionAlignment(0); alignment != AlignLeft {
+		t.Errorf("expected the question to be aligned on the left by default, got %v", alignment)
+	}
+	OptionQuestionAlignment(AlignRight, AlignCenter)(&config)
+	// The lines that follow the last alignment given have that alignment
+	for i, expectedAlignment := range []Alignment{AlignRight, AlignCenter, AlignCenter} {
+		if alignment := config.questionAlignment(i); alignment != expectedAlignment {
+			t.Errorf("expected line %d to have alignment %v, got %v", i, expectedAlignment, alignment)
+		}
+	}
+}
+
+func TestOptionQuestionStyle(t *testing.T) {
+	config := defaultConfig
+	OptionQuestionStyle(Style{Bold: true, Underline: true})(&config)
+	if _, _, attributes := config.Theme.Question.Decompose(); attributes&tcell.AttrBold == 0 || attributes&tcell.AttrUnderline == 0 {
+		t.Errorf("expected the question to be bold and underlined, got attributes %v", attributes)
+	}
+	if config.Theme.Item != defaultConfig.Theme.Item {
+		t.Error("expected the style of the choices to be unchanged")
+	}
+}
@@ -0,0 +1,46 @@
+package gochoice
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// StyledCell is a character displayed with its own style
+type StyledCell struct {
+	Rune  rune
+	Style tcell.Style
+}
+
+// ItemRenderer returns the cells to display on the line of a choice.
+// The width is the number of columns available on that line.
+type ItemRenderer func(choice Choice, selected bool, width int) []StyledCell
+
+// StyledText returns the cells of the given text, all with the same style
+func StyledText(text string, style tcell.Style) []StyledCell {
+	cells := make([]StyledCell, 0, len(text))
+	for _, character := range text {
+		cells = append(cells, StyledCell{Rune: character, Style: style})
+	}
+	return cells
+}
+
+// printCells prints the given cells on the given screen and fills the rest of the line with the background style
+func printCells(screen tcell.Screen, x, y int, cells []StyledCell, background tcell.Style) {
+	width, _ := screen.Size()
+	for _, cell := range cells {
+		if x >= width {
+			return
+		}
+		screen.SetCell(x, y, cell.Style, cell.Rune)
+		x += runewidth.RuneWidth(cell.Rune)
+	}
+	printText(screen, x, y, "", background)
+}
+
+// OptionItemRenderer replaces the default rendering of each choice by the given function,
+// allowing full control over the characters displayed and their style
+func OptionItemRenderer(itemRenderer ItemRenderer) func(config *Config) {
+	return func(config *Config) {
+		config.ItemRenderer = itemRenderer
+	}
+}
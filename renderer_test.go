@@ -0,0 +1,65 @@
+package gochoice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRenderWithItemRenderer(t *testing.T) {
+	config := defaultConfig
+	OptionItemRenderer(func(choice Choice, selected bool, width int) []StyledCell {
+		style := tcell.StyleDefault.Foreground(tcell.ColorBlue)
+		if selected {
+			style = style.Foreground(tcell.ColorRed)
+		}
+		label := "* " + strings.ToUpper(choice.Value)
+		return append(StyledText(label, style), StyledText(strings.Repeat(" ", width-len(label)-2)+"OK", style)...)
+	})(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 5)
+	choices := newChoices([]string{"a", "b"})
+	render(screen, "question", choices, &config, &promptState{}, choices[0], "", true, 0, choices)
+	expectedContent := map[[2]int]struct {
+		character rune
+		color     tcell.Color
+	}{
+		{2, 1}:  {'A', tcell.ColorRed},
+		{2, 2}:  {'B', tcell.ColorBlue},
+		{19, 2}: {'K', tcell.ColorBlue},
+	}
+	for position, expected := range expectedContent {
+		mainc, _, style, _ := screen.GetContent(position[0], position[1])
+		if fg, _, _ := style.Decompose(); mainc != expected.character || fg != expected.color {
+			t.Errorf("expected %c with color %v at %v, got %c with color %v", expected.character, expected.color, position, mainc, fg)
+		}
+	}
+}
+
+func TestPickWithItemRenderer(t *testing.T) {
+	config := defaultConfig
+	OptionItemRenderer(func(choice Choice, selected bool, width int) []StyledCell {
+		return StyledText(choice.Value, tcell.StyleDefault)
+	})(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+}
@@ -0,0 +1,62 @@
+package gochoice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// PickResult is the outcome of a prompt displayed by PickDetailed
+type PickResult struct {
+	// Value is the value of the choice selected, or an empty string if the prompt was aborted
+	Value string
+	// Index is the index of the choice selected, or -1 if the prompt was aborted
+	Index int
+	// Indices are the indices of all the choices with the value selected, which are displayed as one with
+	// OptionDedupe, starting with Index. It is nil if the prompt was aborted or the search query was selected.
+	Indices []int
+	// Query is the search query typed when the prompt was closed
+	Query string
+	// Aborted is true if the user closed the prompt without selecting a choice
+	Aborted bool
+	// Custom is true if the user selected the search query itself, as allowed by OptionAllowCustom
+	Custom bool
+}
+
+// PickDetailed is like Pick, but also returns the search query typed by the user, like fzf --print-query.
+// Aborting the prompt is not an error: the result is then marked as Aborted, so that the query is still available.
+func PickDetailed(question string, choicesToPickFrom []string, options ...Option) (PickResult, error) {
+	config := newConfig(options)
+	var query string
+	config.onClose = func(searchQuery string) {
+		query = searchQuery
+	}
+	selectedChoices, err := runPicker(context.Background(), question, newChoices(choicesToPickFrom), config)
+	return toPickResult(selectedChoices, err, query)
+}
+
+func pickDetailed(question string, choicesToPickFrom []string, screen tcell.Screen, config *Config) (PickResult, error) {
+	var query string
+	config.onClose = func(searchQuery string) {
+		query = searchQuery
+	}
+	selectedChoices, err := pickChoices(context.Background(), question, newChoices(choicesToPickFrom), screen, config)
+	return toPickResult(selectedChoices, err, query)
+}
+
+// toPickResult returns the result of a prompt that was closed with the given search query
+func toPickResult(selectedChoices []*Choice, err error, query string) (PickResult, error) {
+	if errors.Is(err, ErrAborted) {
+		return PickResult{Index: -1, Query: query, Aborted: true}, nil
+	}
+	if err != nil {
+		return PickResult{Index: -1, Query: query}, err
+	}
+	selectedChoice := selectedChoices[0]
+	result := PickResult{Value: selectedChoice.Value, Index: selectedChoice.Id, Query: query, Custom: selectedChoice.custom}
+	if !selectedChoice.custom {
+		result.Indices = selectedChoice.allIndices()
+	}
+	return result, nil
+}
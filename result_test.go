@@ -0,0 +1,58 @@
+package gochoice
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickDetailed(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		confirmKey     tcell.Key
+		expectedResult PickResult
+	}{
+		{name: "selected", confirmKey: tcell.KeyEnter, expectedResult: PickResult{Value: "staging", Index: 1, Indices: []int{1}, Query: "st"}},
+		{name: "aborted", confirmKey: tcell.KeyEscape, expectedResult: PickResult{Index: -1, Query: "st", Aborted: true}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.InjectKeyBytes([]byte("st"))
+			screen.InjectKey(scenario.confirmKey, 0, tcell.ModNone)
+			result, err := pickDetailed("question", []string{"production", "staging"}, screen, &config)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if !reflect.DeepEqual(result, scenario.expectedResult) {
+				t.Errorf("expected %+v, got %+v", scenario.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestPickDetailedWithDedupe(t *testing.T) {
+	config := defaultConfig
+	OptionDedupe()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	result, err := pickDetailed("question", []string{"git status", "git status", "git log", "git status"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expectedResult := PickResult{Value: "git log", Index: 2, Indices: []int{2}}
+	if !reflect.DeepEqual(result, expectedResult) {
+		t.Errorf("expected %+v, got %+v", expectedResult, result)
+	}
+}
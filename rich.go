@@ -0,0 +1,98 @@
+package gochoice
+
+import (
+	"context"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// Item is a choice with additional information to display alongside its label
+type Item struct {
+	// Label is the text displayed for the item
+	Label string
+
+	// Value is what the item stands for, e.g. an ID, when it differs from its label, so that the item picked can be
+	// identified without looking up its index. OptionDefaultValue selects the item with either that value or that label.
+	Value string
+
+	// Description is an optional secondary text displayed next to the label
+	Description string
+
+	// Disabled items are displayed, but cannot be selected
+	Disabled bool
+
+	// Style is applied to the label of the item on top of the style of the theme, e.g. to display it in red.
+	// Only the colors and attributes that are set are applied.
+	Style tcell.Style
+
+	// Hotkey is a rune picking the item when pressed with Alt, or alone when the search query isn't being typed,
+	// e.g. with vim bindings, which is useful for menus of commands. Its first occurrence in the label is underlined.
+	Hotkey rune
+
+	// Icon is displayed before the label, e.g. IconFolder, in the variant set by Theme.Icons
+	Icon Icon
+
+	// URL turns the label into a hyperlink to the URL on terminals supporting hyperlinks, e.g. to open a pull request
+	URL string
+
+	// Dangerous items, e.g. "Delete the cluster", must be confirmed by pressing Enter again once they have been picked,
+	// or canceled with Esc
+	Dangerous bool
+
+	// separator and static are true for the items created with Separator and StaticLabel respectively
+	separator bool
+	static    bool
+}
+
+// Separator returns an item for PickRich displayed as a horizontal rule between the items around it.
+// It cannot be selected, and it isn't counted in the index of the item selected.
+func Separator() Item {
+	return Item{separator: true, static: true}
+}
+
+// StaticLabel returns an item for PickRich displaying the given text like the header of a group, e.g. to introduce
+// the items that follow it. It cannot be selected, and it isn't counted in the index of the item selected.
+func StaticLabel(text string) Item {
+	return Item{Label: text, static: true}
+}
+
+// PickRich prompts the user to choose an item from a list of items.
+// Descriptions are only searched if OptionSearchDescriptions is used.
+// The index returned leaves out the items created with Separator and StaticLabel.
+func PickRich(question string, items []Item, options ...Option) (Item, int, error) {
+	selectedChoices, err := runPicker(context.Background(), question, newChoicesFromItems(items), withoutCustomChoice(newConfig(options)))
+	return toItemAndIndex(selectableItems(items), selectedChoices, err)
+}
+
+func pickRich(question string, items []Item, screen tcell.Screen, config *Config) (Item, int, error) {
+	selectedChoices, err := pickChoices(context.Background(), question, newChoicesFromItems(items), screen, withoutCustomChoice(config))
+	return toItemAndIndex(selectableItems(items), selectedChoices, err)
+}
+
+// newChoicesFromItems creates a choice for each item. The items created with Separator and StaticLabel become
+// headers, and the ids of the other choices are their indices among the items that aren't static.
+// Unlike newChoices, no choice is selected, since the first item may be disabled.
+func newChoicesFromItems(items []Item) []*Choice {
+	choices := make([]*Choice, 0, len(items))
+	id := 0
+	for _, item := range items {
+		if item.static {
+			choices = append(choices, &Choice{Id: -1, Value: item.Label, header: true, separator: item.separator, Data: item})
+			continue
+		}
+		choices = append(choices, &Choice{Id: id, Value: item.Label, value: item.Value, Description: item.Description, Disabled: item.Disabled, Style: item.Style, Hotkey: item.Hotkey, Icon: item.Icon, URL: item.URL, Dangerous: item.Dangerous, Data: item})
+		id++
+	}
+	return choices
+}
+
+// selectableItems returns the items that aren't static, which are those the ids of the choices refer to
+func selectableItems(items []Item) []Item {
+	selectable := make([]Item, 0, len(items))
+	for _, item := range items {
+		if !item.static {
+			selectable = append(selectable, item)
+		}
+	}
+	return selectable
+}
@@ -0,0 +1,267 @@
+package gochoice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickRich(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	items := []Item{
+		{Label: "production", Description: "us-east-1"},
+		{Label: "staging", Description: "us-west-2"},
+	}
+	item, index, err := pickRich("question", items, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item != items[1] {
+		t.Error("expected staging, got", item.Label)
+	}
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+}
+
+func TestPickRichDoesNotSearchDescriptionsByDefault(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, 'w', tcell.ModNone)
+	// Enter does nothing since no choices match the search query
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	_, _, err = pickRich("question", []Item{{Label: "production", Description: "us-east-1"}, {Label: "staging", Description: "us-west-2"}}, screen, &config)
+	if !errors.Is(err, ErrAborted) {
+		t.Error("expected ErrAborted, got", err)
+	}
+}
+
+func TestPickRichWithSearchDescriptions(t *testing.T) {
+	config := defaultConfig
+	OptionSearchDescriptions()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, 'w', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'e', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 's', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 't', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	item, index, err := pickRich("question", []Item{{Label: "production", Description: "us-east-1"}, {Label: "staging", Description: "us-west-2"}}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "staging" {
+		t.Error("expected staging, got", item.Label)
+	}
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+}
+
+func TestRenderDescription(t *testing.T) {
+	config := defaultConfig
+	OptionSearchDescriptions()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 5)
+	choices := newChoicesFromItems([]Item{{Label: "ab", Description: "cd"}})
+	selectChoice(nil, choices[0])
+	state := &promptState{}
+	visibleChoices := filterChoices(choices, "d", &config, state)
+	render(screen, "question", visibleChoices, &config, state, visibleChoices[0], "d", true, 0, choices)
+	// " > ab  cd": the description starts at x=7
+	expectedContent := map[int]struct {
+		character rune
+		style     tcell.Style
+	}{
+		3: {'a', config.Theme.Selected},
+		7: {'c', config.Theme.Description},
+		8: {'d', config.Theme.Match},
+	}
+	for x, expected := range expectedContent {
+		if mainc, _, style, _ := screen.GetContent(x, 1); mainc != expected.character || style != expected.style {
+			t.Errorf("expected %c with style %v at x=%d, got %c with style %v", expected.character, expected.style, x, mainc, style)
+		}
+	}
+}
+
+func TestPickRichSkipsDisabledItems(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	items := []Item{{Label: "A", Disabled: true}, {Label: "B"}, {Label: "C", Disabled: true}, {Label: "D"}}
+	item, index, err := pickRich("question", items, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "D" {
+		t.Error("expected D, got", item.Label)
+	}
+	if index != 3 {
+		t.Error("expected 3, got", index)
+	}
+}
+
+func TestPickRichWithDisabledDefaultIndex(t *testing.T) {
+	config := defaultConfig
+	OptionDefaultIndex(1)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	item, _, err := pickRich("question", []Item{{Label: "A"}, {Label: "B", Disabled: true}}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "A" {
+		t.Error("expected A, got", item.Label)
+	}
+}
+
+func TestPickRichWithOnlyDisabledItems(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	// Enter does nothing since no choices can be selected
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	_, _, err = pickRich("question", []Item{{Label: "A", Disabled: true}, {Label: "B", Disabled: true}}, screen, &config)
+	if !errors.Is(err, ErrAborted) {
+		t.Error("expected ErrAborted, got", err)
+	}
+}
+
+func TestPickRichSkipsSeparatorsAndStaticLabels(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	items := []Item{StaticLabel("Files"), {Label: "open"}, {Label: "save"}, Separator(), StaticLabel("Danger"), {Label: "delete"}}
+	item, index, err := pickRich("question", items, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "save" {
+		t.Error("expected save, got", item.Label)
+	}
+	// The index leaves out the static items
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+	scenarios := []struct {
+		x, y         int
+		expectedRune rune
+	}{
+		{x: 1, y: 1, expectedRune: 'F'},
+		{x: 1, y: 4, expectedRune: tcell.RuneHLine},
+		{x: 18, y: 4, expectedRune: tcell.RuneHLine},
+		{x: 1, y: 5, expectedRune: 'D'},
+	}
+	for _, scenario := range scenarios {
+		if mainc, _, _, _ := screen.GetContent(scenario.x, scenario.y); mainc != scenario.expectedRune {
+			t.Errorf("expected %q at (%d, %d), got %q", scenario.expectedRune, scenario.x, scenario.y, mainc)
+		}
+	}
+}
+
+func TestFilterChoicesWithSeparatorsAndStaticLabels(t *testing.T) {
+	config := defaultConfig
+	choices := newChoicesFromItems([]Item{{Label: "open"}, {Label: "save"}, Separator(), StaticLabel("Danger"), {Label: "delete"}})
+	visibleChoices := filterChoices(choices, "de", &config, &promptState{})
+	// The separator and the label are both displayed above the only choice matching the search query
+	if len(visibleChoices) != 3 || !visibleChoices[0].separator || visibleChoices[1].Value != "Danger" || visibleChoices[2].Value != "delete" {
+		t.Errorf("expected the separator, the label and delete, got %d choices", len(visibleChoices))
+	}
+	if visibleChoices := filterChoices(choices, "sa", &config, &promptState{}); len(visibleChoices) != 1 {
+		t.Errorf("expected the separator and the label to be hidden, got %d choices", len(visibleChoices))
+	}
+}
+
+func TestPickRichWithValues(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		defaultValue  string
+		expectedValue string
+		expectedIndex int
+	}{
+		{name: "default-value-matching-value", defaultValue: "prod-use1", expectedValue: "prod-use1", expectedIndex: 1},
+		{name: "default-value-matching-label", defaultValue: "Production (us-east-1)", expectedValue: "prod-use1", expectedIndex: 1},
+		{name: "no-default-value", expectedValue: "dev", expectedIndex: 0},
+	}
+	items := []Item{
+		{Label: "Development", Value: "dev"},
+		{Label: "Production (us-east-1)", Value: "prod-use1"},
+		{Label: "Staging", Value: "staging"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionDefaultValue(scenario.defaultValue)(&config)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatal("encountered error while creating simulation screen:", err)
+			}
+			defer screen.Fini()
+			screen.SetStyle(config.Theme.background())
+			screen.Show()
+			screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+			item, index, err := pickRich("question", items, screen, &config)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if item.Value != scenario.expectedValue {
+				t.Errorf("expected %s, got %s", scenario.expectedValue, item.Value)
+			}
+			if index != scenario.expectedIndex {
+				t.Errorf("expected %d, got %d", scenario.expectedIndex, index)
+			}
+		})
+	}
+}
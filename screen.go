@@ -2,86 +2,539 @@ package gochoice
 
 import (
 	"fmt"
-	"strconv"
+	"io"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+const (
+	// descriptionSeparator is the text displayed between the value of a choice and its description
+	descriptionSeparator = "  "
+
+	// defaultCursor is the marker displayed before the selected choice unless another one is set with OptionCursor
+	defaultCursor = "> "
+
+	// defaultCheckedMarker and defaultUncheckedMarker are displayed before the choices of PickMultiple,
+	// unless other markers are set in the theme
+	defaultCheckedMarker   = "[x]"
+	defaultUncheckedMarker = "[ ]"
+
+	// defaultEllipsis replaces the end of the texts that are too long to fit where they are displayed,
+	// such as the title of the border, unless they are choices truncated with OptionTruncate
+	defaultEllipsis = "…"
 )
 
 func createScreen() (tcell.Screen, error) {
 	tcell.SetEncodingFallback(tcell.EncodingFallbackASCII)
 	screen, err := tcell.NewScreen()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create new screen: %v", err)
+		return nil, &screenInitError{err}
 	}
 	if err := screen.Init(); err != nil {
-		return nil, fmt.Errorf("failed to initialize screen: %v", err)
+		return nil, &screenInitError{err}
 	}
 	return screen, nil
 }
 
-// render renders the question, options and the selected choice with the given configuration
-func render(screen tcell.Screen, question string, options []*Choice, config *Config, selectedChoice *Choice, searchQuery string) {
-	_, screenHeight := screen.Size()
-	lineNumber := 0
-	// Display question
-	questionLines := strings.Split(question, "\n")
-	for _, questionLine := range questionLines {
-		printText(screen, 0, lineNumber, fmt.Sprintf(" %s", questionLine), config.TextColor, config.BackgroundColor, config.SelectedTextBold)
-		lineNumber++
+// createScreenWithTty creates a screen like createScreen, along with the terminal it is displayed on, to which the
+// escape sequences tcell can't write are written, e.g. those of hyperlinks. It fails on platforms where tcell doesn't
+// display the screen on a tcell.Tty, such as Windows.
+func createScreenWithTty() (tcell.Screen, io.Writer, error) {
+	tcell.SetEncodingFallback(tcell.EncodingFallbackASCII)
+	tty, err := openTty()
+	if err != nil {
+		return nil, nil, &screenInitError{err}
 	}
-	selectedChoiceIndex := 0
-	numberOfOptionsNotHidden := 0
-	for _, option := range options {
-		if len(searchQuery) > 0 && !strings.Contains(strings.ToLower(option.Value), strings.ToLower(searchQuery)) {
-			option.hidden = true
-		} else {
-			option.hidden = false
-			if option.Selected {
-				selectedChoiceIndex = numberOfOptionsNotHidden
-			}
-			numberOfOptionsNotHidden++
+	screen, err := tcell.NewTerminfoScreenFromTty(tty)
+	if err != nil {
+		_ = tty.Close()
+		return nil, nil, &screenInitError{err}
+	}
+	if err := screen.Init(); err != nil {
+		_ = tty.Close()
+		return nil, nil, &screenInitError{err}
+	}
+	return screen, tty, nil
+}
+
+// screenInitError is the error returned when the screen can't be initialized.
+// It matches ErrScreenInit and wraps the error of tcell.
+type screenInitError struct {
+	err error
+}
+
+func (e *screenInitError) Error() string {
+	return ErrScreenInit.Error() + ": " + e.err.Error()
+}
+
+func (e *screenInitError) Is(target error) bool {
+	return target == ErrScreenInit
+}
+
+func (e *screenInitError) Unwrap() error {
+	return e.err
+}
+
+// sharedScreen is a screen on which several prompts are displayed one after the other.
+// Its events are received through a single channel, so that no event is lost between two prompts.
+type sharedScreen struct {
+	tcell.Screen
+	events chan tcell.Event
+	quit   chan struct{}
+	// tty is the terminal the screen is displayed on, if it is known, which is kept in the config of each prompt
+	tty io.Writer
+}
+
+func newSharedScreen(screen tcell.Screen) *sharedScreen {
+	shared := &sharedScreen{Screen: screen, events: make(chan tcell.Event), quit: make(chan struct{})}
+	go screen.ChannelEvents(shared.events, shared.quit)
+	return shared
+}
+
+// stopListening stops receiving the events of the screen
+func (screen *sharedScreen) stopListening() {
+	close(screen.quit)
+}
+
+// listenToEvents returns a channel on which the events of the screen are received,
+// as well as a function that must be called once the events are no longer needed
+func listenToEvents(screen tcell.Screen) (<-chan tcell.Event, func()) {
+	if shared, ok := screen.(*sharedScreen); ok {
+		return shared.events, func() {}
+	}
+	events := make(chan tcell.Event)
+	quit := make(chan struct{})
+	go screen.ChannelEvents(events, quit)
+	return events, func() { close(quit) }
+}
+
+// SimulateKeys posts the given events to the screen in order, so that they are processed by the next prompt
+// displayed on it with PickWithScreen. The events are posted in the background, which allows more events to be
+// queued than the screen can buffer, so every event should be consumed by the prompt.
+func SimulateKeys(screen tcell.Screen, events []tcell.Event) {
+	go func() {
+		for _, event := range events {
+			screen.PostEventWait(event)
 		}
+	}()
+}
+
+// render renders the question, the visible options and the selected choice with the given configuration.
+// Only the options starting from scrollOffset that fit in the screen are displayed.
+// The screen must be shown once everything else, such as the preview, has been rendered on top of it.
+// The options are the choices matching the search query, whose number is displayed next to the number of choices.
+// It returns the option displayed on each line of the screen, or nil for lines that display no option.
+func render(screen tcell.Screen, question string, options []*Choice, config *Config, state *promptState, selectedChoice *Choice, searchQuery string, searching bool, scrollOffset int, choices []*Choice) []*Choice {
+	screenWidth, screenHeight := screen.Size()
+	optionsWidth := computeOptionsWidth(screenWidth, config)
+	optionsByLine := make([]*Choice, screenHeight)
+	lineNumber := renderQuestion(screen, strings.Split(question, "\n"), config)
+	if config.PositionIndicator {
+		optionCount, _ := state.countOptionsAndChoices(options, choices)
+		if indicator := positionIndicator(options, selectedChoice, optionCount); len(indicator) > 0 {
+			printText(screen, screenWidth-runewidth.StringWidth(indicator)-1, config.breadcrumbHeight(), indicator, config.Theme.Counter)
+		}
+	}
+	if config.topSearchBarHeight() > 0 {
+		renderSearchBar(screen, lineNumber, options, choices, searchQuery, searching, config, state)
+		lineNumber++
+	}
+	for _, headerLine := range textLines(config.Header) {
+		printText(screen, 0, lineNumber, fmt.Sprintf(" %s", headerLine), config.Theme.HeaderBar)
+		lineNumber++
+	}
+	if len(config.columnHeader) > 0 {
+		// Align the header with the values of the options, which follow the selection marker
+		_, unselectedPrefix := config.cursor()
+		printText(screen, 0, lineNumber, " "+lineNumberGutter(nil, config, state)+unselectedPrefix+config.columnHeader, config.Theme.Header)
+		lineNumber++
 	}
 	// Display all options that can fit in the screen
-	min := selectedChoiceIndex + len(questionLines)
-	visibleOptionIndex := 0
-	for _, option := range options {
-		if option.hidden {
-			continue
-		}
-		visibleOptionIndex++
-		if visibleOptionIndex <= (min+2)-screenHeight && !(visibleOptionIndex > (min+2)-screenHeight) && visibleOptionIndex-screenHeight < min {
-			continue
+	pageSize := computePageSize(screen, question, config)
+	firstOptionLineNumber := lineNumber
+	i := scrollOffset
+	// The scrollbar represents the position of the page among the rows of options
+	scrollbarOffset, numberOfRows := scrollOffset, len(options)
+	if config.Grid {
+		columns, _ := computeGridColumns(options, optionsWidth-1, config, state)
+		scrollbarOffset, numberOfRows = scrollOffset/columns, (len(options)+columns-1)/columns
+		lineNumber, i = renderGrid(screen, lineNumber, firstOptionLineNumber+pageSize, options, scrollOffset, optionsWidth-1, config, state, optionsByLine)
+	} else {
+		for ; i < len(options) && lineNumber < firstOptionLineNumber+pageSize; i++ {
+			option := options[i]
+			if option.header {
+				if option.separator {
+					// The last column of the options is left for the scrollbar
+					printText(screen, 0, lineNumber, " "+strings.Repeat(string(tcell.RuneHLine), optionsWidth-2), config.Theme.Scrollbar)
+				} else {
+					printText(screen, 0, lineNumber, fmt.Sprintf(" %s", option.Value), config.Theme.Header)
+				}
+				optionsByLine[lineNumber] = option
+				lineNumber++
+				continue
+			}
+			if config.ItemRenderer != nil {
+				printCells(screen, 0, lineNumber, config.ItemRenderer(*option, option.Selected, optionsWidth), config.Theme.background())
+				optionsByLine[lineNumber] = option
+				lineNumber++
+				continue
+			}
+			prefix := choicePrefix(option, config, state)
+			style := choiceStyle(option, config, state)
+			if config.Wrap {
+				firstLineNumber := lineNumber
+				lineNumber = renderWrappedChoice(screen, lineNumber, firstOptionLineNumber+pageSize, option, prefix, style, optionsWidth-1, config, optionsByLine)
+				if config.LineNumbers {
+					// The line number is only displayed on the first line of the choice
+					styleLineNumber(screen, firstLineNumber, config, state)
+				}
+				continue
+			}
+			value, matchedPositions, ansiStyles := option.Value, option.matchedPositions, option.ansiStyles
+			if option.custom {
+				value = customChoiceText(option, config)
+			}
+			if state.horizontalOffset > 0 {
+				var valueScroll truncation
+				value, valueScroll = scrollText(value, state.horizontalOffset, defaultEllipsis)
+				matchedPositions = valueScroll.shiftPositions(matchedPositions)
+				ansiStyles = valueScroll.shiftStyles(ansiStyles)
+			}
+			if config.Truncate {
+				// The last column of the options is left for the scrollbar
+				var valueTruncation truncation
+				value, valueTruncation = truncateText(value, optionsWidth-1-runewidth.StringWidth(prefix), config.TruncatePosition, config.Ellipsis)
+				matchedPositions = valueTruncation.shiftPositions(matchedPositions)
+				ansiStyles = valueTruncation.shiftStyles(ansiStyles)
+			}
+			highlightedPositions := offsetPositions(matchedPositions, len([]rune(prefix)))
+			printHighlightedText(screen, 0, lineNumber, prefix+value, highlightedPositions, style, config.Theme.Match)
+			if ansiStyles != nil {
+				styleANSI(screen, runewidth.StringWidth(prefix), lineNumber, value, ansiStyles, style)
+			}
+			if config.LineNumbers {
+				styleLineNumber(screen, lineNumber, config, state)
+			}
+			if option.Hotkey != 0 {
+				underlineHotkey(screen, runewidth.StringWidth(prefix), lineNumber, value, option.Hotkey)
+			}
+			if len(option.Description) > 0 {
+				// Draw the description over the padding that follows the value
+				descriptionOffset := len([]rune(prefix + value + descriptionSeparator))
+				x := runewidth.StringWidth(prefix + value + descriptionSeparator)
+				printHighlightedText(screen, x, lineNumber, option.Description, offsetPositions(highlightedPositions, -descriptionOffset), config.Theme.Description, config.Theme.Match)
+			}
+			optionsByLine[lineNumber] = option
+			lineNumber++
 		}
-		if option.Selected {
-			printText(screen, 0, lineNumber, fmt.Sprintf(" > %s", option.Value), config.SelectedTextColor, config.BackgroundColor, config.SelectedTextBold)
+	}
+	if len(options) == 0 {
+		if state.loading && len(choices) == 0 {
+			printText(screen, 1, lineNumber, " "+state.spinner()+" "+config.LoadingMessage, config.Theme.Spinner)
 		} else {
-			printText(screen, 0, lineNumber, fmt.Sprintf("   %s", option.Value), config.TextColor, config.BackgroundColor, config.SelectedTextBold)
+			printText(screen, 1, lineNumber, " ! "+config.EmptyMessage, config.Theme.Description)
 		}
 		lineNumber++
 	}
-	if numberOfOptionsNotHidden == 0 {
-		printText(screen, 1, lineNumber, " ! There are no choices matching your search query", config.TextColor, config.BackgroundColor, config.SelectedTextBold)
-		lineNumber++
-	}
 	// HACK: Instead of using screen.Clear(), draw over the existing text
 	for i := lineNumber; i < screenHeight; i++ {
-		printText(screen, 1, i, "", config.TextColor, config.BackgroundColor, config.SelectedTextBold)
+		printText(screen, 1, i, "", config.Theme.background())
+	}
+	if scrollOffset > 0 || i < len(options) {
+		renderScrollbar(screen, optionsWidth-1, firstOptionLineNumber, pageSize, scrollbarOffset, numberOfRows, config)
+	}
+	footerLines := config.footerLines()
+	for i, footerLine := range footerLines {
+		printText(screen, 0, screenHeight-config.bottomSearchBarHeight()-len(footerLines)+i, fmt.Sprintf(" %s", footerLine), config.Theme.FooterBar)
+	}
+	if config.bottomSearchBarHeight() > 0 {
+		renderSearchBar(screen, screenHeight-1, options, choices, searchQuery, searching, config, state)
+	}
+	return optionsByLine
+}
+
+// countChoices returns the number of choices that can be picked among the given ones
+func countChoices(choices []*Choice) int {
+	count := 0
+	for _, choice := range choices {
+		if choice.counted() {
+			count++
+		}
+	}
+	return count
+}
+
+// countOptionsAndChoices returns the number of choices that can be picked among the options and among all the choices.
+// The options are counted while they are filtered, and the choices are only counted again once they have changed,
+// since counting millions of choices after every event would take longer than drawing the ones displayed.
+func (state *promptState) countOptionsAndChoices(options, choices []*Choice) (int, int) {
+	optionCount := 0
+	if job := state.filter; job != nil && sameChoices(job.visibleChoices, options) {
+		optionCount = job.optionCount
+	} else {
+		optionCount = countChoices(options)
+	}
+	if !sameChoices(state.countedChoices, choices) {
+		state.countedChoices, state.choiceCount = choices, countChoices(choices)
 	}
-	printText(screen, 1, screenHeight-1, "Search: "+searchQuery+"_", config.TextColor, config.BackgroundColor, config.SelectedTextBold)
-	screen.Show()
+	return optionCount, state.choiceCount
+}
+
+// selectableBounds returns the first and the last of the options that can be selected, or nil if none can be
+func (state *promptState) selectableBounds(options []*Choice) (*Choice, *Choice) {
+	if job := state.filter; job != nil && sameChoices(job.visibleChoices, options) {
+		return job.first, job.last
+	}
+	return firstSelectable(options, 1), firstSelectable(options, -1)
+}
+
+// sameChoices reports whether both slices are the same slice, rather than merely contain the same choices
+func sameChoices(a, b []*Choice) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// positionIndicator returns the position of the selected choice among the options that can be picked, e.g. "(12/87)",
+// given the number of these, or an empty string if none of them is selected
+func positionIndicator(options []*Choice, selectedChoice *Choice, optionCount int) string {
+	if selectedChoice == nil || !selectedChoice.counted() || !isDisplayed(options, selectedChoice) {
+		return ""
+	}
+	return fmt.Sprintf("(%d/%d)", selectedChoice.ordinal, optionCount)
+}
+
+// OptionPositionIndicator displays the position of the selected choice among the choices matching the search query,
+// e.g. "(12/87)", on the right of the first line of the question
+func OptionPositionIndicator() func(config *Config) {
+	return func(config *Config) {
+		config.PositionIndicator = true
+	}
+}
+
+// customChoiceText returns the text displayed for the choice created from the search query with OptionAllowCustom
+func customChoiceText(choice *Choice, config *Config) string {
+	return fmt.Sprintf("%s '%s'", config.CustomLabel, choice.Value)
+}
+
+// choicePrefix returns the text displayed before the value of the choice,
+// which marks whether it is selected and, if applicable, its depth in the tree and whether it is checked
+func choicePrefix(choice *Choice, config *Config, state *promptState) string {
+	cursor, unselectedPrefix := config.cursor()
+	prefix := " " + lineNumberGutter(choice, config, state) + unselectedPrefix
+	if choice.Selected {
+		prefix = " " + lineNumberGutter(choice, config, state) + cursor
+	}
+	if config.NumberShortcuts {
+		prefix += shortcutLabel(choice.shortcut)
+	}
+	if config.tree {
+		prefix += treePrefix(choice)
+	}
+	if config.multiSelect {
+		checkedMarker, uncheckedMarker := config.Theme.checkboxMarkers()
+		if choice.Checked {
+			prefix += checkedMarker + " "
+		} else {
+			prefix += uncheckedMarker + " "
+		}
+	}
+	if state.icons {
+		prefix += iconPrefix(choice, config)
+	}
+	return prefix
+}
+
+// cursor returns the marker displayed before the selected choice and the prefix displayed before the other choices,
+// which is made of spaces as wide as the marker unless it is set with OptionUnselectedPrefix
+func (config *Config) cursor() (string, string) {
+	cursor := config.Cursor
+	if len(cursor) == 0 {
+		cursor = defaultCursor
+	}
+	unselectedPrefix := config.UnselectedPrefix
+	if len(unselectedPrefix) == 0 {
+		unselectedPrefix = strings.Repeat(" ", runewidth.StringWidth(cursor))
+	}
+	return cursor, unselectedPrefix
+}
+
+// OptionCursor sets the marker displayed before the selected choice, e.g. "❯ ", instead of "> ".
+// The other choices are aligned with it, unless another prefix is set for them with OptionUnselectedPrefix.
+func OptionCursor(cursor string) func(config *Config) {
+	return func(config *Config) {
+		config.Cursor = cursor
+	}
+}
+
+// OptionUnselectedPrefix sets the prefix displayed before the choices that aren't selected,
+// which should be as wide as the marker set with OptionCursor
+func OptionUnselectedPrefix(prefix string) func(config *Config) {
+	return func(config *Config) {
+		config.UnselectedPrefix = prefix
+	}
+}
+
+// textLines returns the lines of the given text, or no lines at all if it is empty
+func textLines(text string) []string {
+	if len(text) == 0 {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// footerLines returns the lines displayed above the search bar: the footer, followed by the key hints and
+// the status line if enabled. The status line is left empty, as its text is rendered separately.
+func (config *Config) footerLines() []string {
+	lines := textLines(config.Footer)
+	if config.KeyHints {
+		lines = append(lines, keyHints(config))
+	}
+	if config.StatusLine {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// searchBarHeight returns the number of lines of the search bar, which is left out with OptionWithoutSearch
+func (config *Config) searchBarHeight() int {
+	if config.WithoutSearch {
+		return 0
+	}
+	return 1
+}
+
+// topSearchBarHeight returns the number of lines of the search bar displayed below the question
+func (config *Config) topSearchBarHeight() int {
+	if config.SearchBarPosition != SearchBarTop {
+		return 0
+	}
+	return config.searchBarHeight()
+}
+
+// bottomSearchBarHeight returns the number of lines of the search bar displayed at the bottom of the screen
+func (config *Config) bottomSearchBarHeight() int {
+	if config.SearchBarPosition != SearchBarBottom {
+		return 0
+	}
+	return config.searchBarHeight()
+}
+
+// renderScrollbar renders a vertical scrollbar of the given height, starting at y, whose thumb
+// represents the position of the page of options being displayed among all options
+func renderScrollbar(screen tcell.Screen, x, y, height, scrollOffset, numberOfOptions int, config *Config) {
+	if height <= 0 {
+		return
+	}
+	thumbSize := height * height / numberOfOptions
+	if thumbSize < 1 {
+		thumbSize = 1
+	}
+	thumbPosition := 0
+	if numberOfOptions > height {
+		thumbPosition = scrollOffset * (height - thumbSize) / (numberOfOptions - height)
+	}
+	style := config.Theme.Scrollbar
+	for i := 0; i < height; i++ {
+		eraseWideCharacterBefore(screen, x, y+i)
+		if i >= thumbPosition && i < thumbPosition+thumbSize {
+			screen.SetCell(x, y+i, style, tcell.RuneBlock)
+		} else {
+			screen.SetCell(x, y+i, style, tcell.RuneVLine)
+		}
+	}
+}
+
+// computeScrollOffset returns the index of the first option to display so that the option at selectedIndex is visible,
+// as well as margin options above and below it when possible, scrolling as little as possible from the previous
+// scroll offset
+func computeScrollOffset(previousScrollOffset, selectedIndex, pageSize, numberOfOptions, margin int) int {
+	margin = computeScrollMargin(margin, pageSize)
+	scrollOffset := previousScrollOffset
+	if selectedIndex-margin < scrollOffset {
+		scrollOffset = selectedIndex - margin
+	} else if selectedIndex+margin >= scrollOffset+pageSize {
+		scrollOffset = selectedIndex + margin - pageSize + 1
+	}
+	if scrollOffset > numberOfOptions-pageSize {
+		scrollOffset = numberOfOptions - pageSize
+	}
+	if scrollOffset < 0 {
+		scrollOffset = 0
+	}
+	return scrollOffset
+}
+
+// computeScrollMargin returns the number of options kept visible above and below the selected option, which is
+// the margin set with OptionScrollOffset, unless the page is too small for it
+func computeScrollMargin(margin, pageSize int) int {
+	if maxMargin := (pageSize - 1) / 2; margin > maxMargin {
+		return maxMargin
+	}
+	return margin
 }
 
 // printText prints text on the given screen
-func printText(screen tcell.Screen, x, y int, text string, fg, bg tcell.Color, bold bool) {
-	// Overwrite all existing characters on the line with the new text
+func printText(screen tcell.Screen, x, y int, text string, style tcell.Style) {
+	printHighlightedText(screen, x, y, text, nil, style, style)
+}
+
+// printHighlightedText prints text on the given screen, using the highlight style for the runes
+// at the given positions. The positions must be sorted in ascending order.
+// Characters are laid out by display width: wide characters, such as CJK characters and most emoji, take two columns,
+// and combining characters are drawn in the same cell as the character they modify.
+func printHighlightedText(screen tcell.Screen, x, y int, text string, highlightedPositions []int, style, highlightStyle tcell.Style) {
 	width, _ := screen.Size()
-	textWithSpaces := fmt.Sprintf("%-"+strconv.Itoa(width)+"s", text)
-	// Write all characters on the screen
-	for _, character := range textWithSpaces {
-		screen.SetCell(x, y, tcell.StyleDefault.Background(bg).Foreground(fg).Bold(bold), character)
-		x += runewidth.RuneWidth(character)
+	position := 0
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() && x < width {
+		runes := graphemes.Runes()
+		characterStyle := style
+		for len(highlightedPositions) > 0 && highlightedPositions[0] < position+len(runes) {
+			if highlightedPositions[0] >= position {
+				characterStyle = highlightStyle
+			}
+			highlightedPositions = highlightedPositions[1:]
+		}
+		position += len(runes)
+		characterWidth := runewidth.StringWidth(graphemes.Str())
+		if characterWidth == 0 {
+			// Control characters and combining characters without a base character can't be displayed
+			continue
+		}
+		if x+characterWidth > width {
+			// A wide character that doesn't fit in the last column is replaced by padding
+			break
+		}
+		screen.SetContent(x, y, runes[0], runes[1:], characterStyle)
+		x += characterWidth
+	}
+	// Overwrite all existing characters on the rest of the line
+	for ; x < width; x++ {
+		screen.SetContent(x, y, ' ', nil, style)
+	}
+}
+
+// eraseWideCharacterBefore replaces the character displayed right before the given position by a space
+// if it is a wide character overlapping the position, so that something else can be drawn at the position
+func eraseWideCharacterBefore(screen tcell.Screen, x, y int) {
+	if x <= 0 {
+		return
+	}
+	if _, _, style, width := screen.GetContent(x-1, y); width > 1 {
+		screen.SetContent(x-1, y, ' ', nil, style)
+	}
+}
+
+// offsetPositions returns a copy of the given positions, each shifted by the given offset
+func offsetPositions(positions []int, offset int) []int {
+	if len(positions) == 0 {
+		return nil
+	}
+	shiftedPositions := make([]int, len(positions))
+	for i, position := range positions {
+		shiftedPositions[i] = position + offset
 	}
+	return shiftedPositions
 }
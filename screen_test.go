@@ -0,0 +1,426 @@
+package gochoice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestRenderHighlightsMatchedCharacters(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 10)
+	choices := newChoices([]string{"john", "jane"})
+	state := &promptState{}
+	visibleChoices := filterChoices(choices, "an", &config, state)
+	render(screen, "question", visibleChoices, &config, state, visibleChoices[0], "an", true, 0, choices)
+	// The first line is the question, and the choice is prefixed by " > "
+	for x, expectedStyle := range map[int]tcell.Style{3: config.Theme.Selected, 4: config.Theme.Match, 5: config.Theme.Match, 6: config.Theme.Selected} {
+		if _, _, style, _ := screen.GetContent(x, 1); style != expectedStyle {
+			t.Errorf("expected cell at x=%d to have style %v, got %v", x, expectedStyle, style)
+		}
+	}
+}
+
+func TestComputeScrollOffset(t *testing.T) {
+	scenarios := []struct {
+		name                 string
+		previousScrollOffset int
+		selectedIndex        int
+		pageSize             int
+		numberOfOptions      int
+		margin               int
+		expectedScrollOffset int
+	}{
+		{name: "fits-in-page", previousScrollOffset: 0, selectedIndex: 2, pageSize: 5, numberOfOptions: 3, expectedScrollOffset: 0},
+		{name: "selected-in-page", previousScrollOffset: 3, selectedIndex: 5, pageSize: 5, numberOfOptions: 20, expectedScrollOffset: 3},
+		{name: "selected-below-page", previousScrollOffset: 0, selectedIndex: 7, pageSize: 5, numberOfOptions: 20, expectedScrollOffset: 3},
+		{name: "selected-above-page", previousScrollOffset: 10, selectedIndex: 4, pageSize: 5, numberOfOptions: 20, expectedScrollOffset: 4},
+		{name: "options-removed", previousScrollOffset: 10, selectedIndex: 0, pageSize: 5, numberOfOptions: 2, expectedScrollOffset: 0},
+		{name: "last-page", previousScrollOffset: 18, selectedIndex: 19, pageSize: 5, numberOfOptions: 20, expectedScrollOffset: 15},
+		{name: "margin-below", previousScrollOffset: 0, selectedIndex: 3, pageSize: 5, numberOfOptions: 20, margin: 2, expectedScrollOffset: 1},
+		{name: "margin-above", previousScrollOffset: 10, selectedIndex: 11, pageSize: 5, numberOfOptions: 20, margin: 2, expectedScrollOffset: 9},
+		{name: "margin-at-last-option", previousScrollOffset: 10, selectedIndex: 19, pageSize: 5, numberOfOptions: 20, margin: 2, expectedScrollOffset: 15},
+		{name: "margin-larger-than-page", previousScrollOffset: 0, selectedIndex: 4, pageSize: 5, numberOfOptions: 20, margin: 10, expectedScrollOffset: 2},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scrollOffset := computeScrollOffset(scenario.previousScrollOffset, scenario.selectedIndex, scenario.pageSize, scenario.numberOfOptions, scenario.margin); scrollOffset != scenario.expectedScrollOffset {
+				t.Errorf("expected %d, got %d", scenario.expectedScrollOffset, scrollOffset)
+			}
+		})
+	}
+}
+
+func TestRenderWithScrollOffset(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 5)
+	choices := newChoices([]string{"A", "B", "C", "D", "E", "F"})
+	selectChoice(choices[0], choices[4])
+	// The page size is 5 - 1 (question) - 1 (search bar) = 3
+	choicesByLine := render(screen, "question", choices, &config, &promptState{}, choices[4], "", true, 3, choices)
+	if choicesByLine[1] != choices[3] || choicesByLine[2] != choices[4] || choicesByLine[3] != choices[5] {
+		t.Error("expected choices D, E and F to be displayed")
+	}
+	if mainc, _, _, _ := screen.GetContent(3, 2); mainc != 'E' {
+		t.Errorf("expected E to be displayed on the third line, got %c", mainc)
+	}
+	// The scrollbar's thumb should be at the bottom, since the last page is displayed
+	if mainc, _, _, _ := screen.GetContent(19, 1); mainc != tcell.RuneVLine {
+		t.Errorf("expected scrollbar track at the top, got %c", mainc)
+	}
+	if mainc, _, _, _ := screen.GetContent(19, 3); mainc != tcell.RuneBlock {
+		t.Errorf("expected scrollbar thumb at the bottom, got %c", mainc)
+	}
+}
+
+func TestRenderWithHeaderAndFooter(t *testing.T) {
+	config := defaultConfig
+	OptionHeader("header")(&config)
+	OptionFooter("first\nsecond")(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 6)
+	choices := newChoices([]string{"a", "b", "c"})
+	choicesByLine := render(screen, "question", choices, &config, &promptState{}, choices[0], "", false, 0, choices)
+	screen.Show()
+	expectedLines := map[int]string{1: "header", 3: "first", 4: "second"}
+	for y, expectedText := range expectedLines {
+		var line []rune
+		for x := 0; x < 20; x++ {
+			mainc, _, _, _ := screen.GetContent(x, y)
+			line = append(line, mainc)
+		}
+		if text := strings.TrimSpace(string(line)); text != expectedText {
+			t.Errorf("expected %q on line %d, got %q", expectedText, y, text)
+		}
+	}
+	if _, _, style, _ := screen.GetContent(1, 3); style != config.Theme.FooterBar {
+		t.Errorf("expected footer to have style %v, got %v", config.Theme.FooterBar, style)
+	}
+	// The question, the header, the footer and the search bar leave a single line for the choices
+	if choicesByLine[2] != choices[0] || choicesByLine[3] != nil {
+		t.Error("expected only the first choice to be displayed, got", choicesByLine)
+	}
+}
+
+func TestRenderWideCharacters(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 5)
+	choices := newChoices([]string{"日本語", "cafe\u0301s", "🚀 go"})
+	state := &promptState{}
+	visibleChoices := filterChoices(choices, "本", &config, state)
+	render(screen, "question", choices, &config, state, choices[0], "本", true, 0, choices)
+	// Each wide character takes two columns
+	for x, expectedRune := range map[int]rune{3: '日', 5: '本', 7: '語', 9: ' '} {
+		if mainc, _, _, _ := screen.GetContent(x, 1); mainc != expectedRune {
+			t.Errorf("expected %q at x=%d, got %q", expectedRune, x, mainc)
+		}
+	}
+	if _, _, style, _ := screen.GetContent(5, 1); len(visibleChoices) != 1 || style != config.Theme.Match {
+		t.Errorf("expected the wide character matched to be highlighted, got %v", style)
+	}
+	// The combining accent is drawn in the same cell as the character it modifies
+	if mainc, combc, _, _ := screen.GetContent(6, 2); mainc != 'e' || len(combc) != 1 || combc[0] != '\u0301' {
+		t.Errorf("expected e with a combining accent at x=6, got %q and %q", mainc, combc)
+	}
+	if mainc, _, _, _ := screen.GetContent(7, 2); mainc != 's' {
+		t.Errorf("expected s at x=7, got %q", mainc)
+	}
+	if mainc, _, _, _ := screen.GetContent(6, 3); mainc != 'g' {
+		t.Errorf("expected g after the emoji at x=6, got %q", mainc)
+	}
+}
+
+func TestPrintTextWithWideCharacterInLastColumn(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(4, 1)
+	printText(screen, 0, 0, "abc日", tcell.StyleDefault)
+	if mainc, _, _, _ := screen.GetContent(3, 0); mainc != ' ' {
+		t.Errorf("expected the wide character that doesn't fit to be replaced by padding, got %q", mainc)
+	}
+}
+
+func TestRenderMatchCounter(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(30, 5)
+	choices := newChoices([]string{"john", "jane", "bob"})
+	state := &promptState{}
+	visibleChoices := filterChoices(choices, "j", &config, state)
+	render(screen, "question", visibleChoices, &config, state, visibleChoices[0], "j", true, 0, choices)
+	screen.Show()
+	// The search bar displays "Search: j_" followed by the counter
+	var counter []rune
+	for x := 13; x < 16; x++ {
+		mainc, _, style, _ := screen.GetContent(x, 4)
+		if style != config.Theme.Counter {
+			t.Errorf("expected cell at x=%d to have style %v, got %v", x, config.Theme.Counter, style)
+		}
+		counter = append(counter, mainc)
+	}
+	if string(counter) != "2/3" {
+		t.Errorf("expected 2/3, got %q", string(counter))
+	}
+}
+
+func TestRenderMatchCounterWithCheckedChoices(t *testing.T) {
+	config := defaultConfig
+	config.multiSelect = true
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 5)
+	choices := newChoices([]string{"john", "jane", "bob"})
+	choices[0].Checked, choices[2].Checked = true, true
+	state := &promptState{}
+	visibleChoices := filterChoices(choices, "j", &config, state)
+	render(screen, "question", visibleChoices, &config, state, visibleChoices[0], "j", true, 0, choices)
+	screen.Show()
+	var counter []rune
+	for x := 13; x < 29; x++ {
+		mainc, _, _, _ := screen.GetContent(x, 4)
+		counter = append(counter, mainc)
+	}
+	// Checked choices that don't match the search query are counted too
+	if string(counter) != "2/3 (2 selected)" {
+		t.Errorf("expected 2/3 (2 selected), got %q", string(counter))
+	}
+}
+
+func TestRenderWithEmptyMessage(t *testing.T) {
+	config := defaultConfig
+	OptionEmptyMessage("no results, press backspace")(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 4)
+	choices := newChoices([]string{"a", "b"})
+	state := &promptState{}
+	render(screen, "question", filterChoices(choices, "z", &config, state), &config, state, nil, "z", true, 0, choices)
+	screen.Show()
+	var line []rune
+	for x := 0; x < 40; x++ {
+		mainc, _, _, _ := screen.GetContent(x, 1)
+		line = append(line, mainc)
+	}
+	if text := strings.TrimSpace(string(line)); text != "! no results, press backspace" {
+		t.Errorf("expected the empty message, got %q", text)
+	}
+	if _, _, style, _ := screen.GetContent(4, 1); style != config.Theme.Description {
+		t.Errorf("expected the empty message to have style %v, got %v", config.Theme.Description, style)
+	}
+}
+
+func TestScreenInitError(t *testing.T) {
+	cause := errors.New("no terminal")
+	err := error(&screenInitError{cause})
+	if !errors.Is(err, ErrScreenInit) {
+		t.Error("expected the error to match ErrScreenInit")
+	}
+	if errors.Is(err, ErrNoChoiceSelected) {
+		t.Error("expected the error not to match ErrNoChoiceSelected")
+	}
+	if errors.Unwrap(err) != cause {
+		t.Error("expected the error to wrap the error of tcell")
+	}
+	if err.Error() != "failed to initialize screen: no terminal" {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+}
+
+func TestRenderWithCursor(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		options       []Option
+		expectedLines []string
+	}{
+		{
+			name:          "default",
+			expectedLines: []string{" > A", "   B"},
+		},
+		{
+			name:          "custom-cursor",
+			options:       []Option{OptionCursor("❯ ")},
+			expectedLines: []string{" ❯ A", "   B"},
+		},
+		{
+			name:          "wide-cursor",
+			options:       []Option{OptionCursor("-> ")},
+			expectedLines: []string{" -> A", "    B"},
+		},
+		{
+			name:          "custom-unselected-prefix",
+			options:       []Option{OptionCursor("-> "), OptionUnselectedPrefix(" - ")},
+			expectedLines: []string{" -> A", "  - B"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := newConfig(scenario.options)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.SetSize(20, 5)
+			choices := newChoices([]string{"A", "B"})
+			render(screen, "question", choices, config, &promptState{}, choices[0], "", true, 0, choices)
+			screen.Show()
+			for i, expectedLine := range scenario.expectedLines {
+				var line []rune
+				for x := 0; x < 20; x++ {
+					mainc, _, _, width := screen.GetContent(x, i+1)
+					line = append(line, mainc)
+					x += width - 1
+				}
+				if text := strings.TrimRight(string(line), " "); text != expectedLine {
+					t.Errorf("expected line %d to be %q, got %q", i+1, expectedLine, text)
+				}
+			}
+		})
+	}
+}
+
+func TestComputePageSizeWithPageSize(t *testing.T) {
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	scenarios := []struct {
+		name             string
+		pageSize         int
+		expectedPageSize int
+	}{
+		{name: "default", pageSize: 0, expectedPageSize: 8},
+		{name: "smaller-than-screen", pageSize: 5, expectedPageSize: 5},
+		{name: "larger-than-screen", pageSize: 50, expectedPageSize: 8},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionPageSize(scenario.pageSize)(&config)
+			if pageSize := computePageSize(screen, "question", &config); pageSize != scenario.expectedPageSize {
+				t.Errorf("expected %d, got %d", scenario.expectedPageSize, pageSize)
+			}
+		})
+	}
+}
+
+func TestPickWithScrollOffset(t *testing.T) {
+	config := defaultConfig
+	OptionScrollOffset(1)(&config)
+	OptionPageSize(3)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	// Moving to the third choice scrolls so that the fourth one is visible below it
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B", "C", "D", "E"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" {
+		t.Error("expected C, got", choice)
+	}
+	if mainc, _, _, _ := screen.GetContent(3, 1); mainc != 'B' {
+		t.Errorf("expected B to be the first choice displayed, got %q", mainc)
+	}
+}
+
+func TestRenderWithPositionIndicator(t *testing.T) {
+	config := defaultConfig
+	OptionPositionIndicator()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 6)
+	choices := newChoices([]string{"apple", "banana", "blueberry", "cherry"})
+	state := &promptState{}
+	visibleChoices := filterChoices(choices, "b", &config, state)
+	render(screen, "question", visibleChoices, &config, state, visibleChoices[1], "b", true, 0, choices)
+	screen.Show()
+	var line []rune
+	for x := 0; x < 20; x++ {
+		mainc, _, _, _ := screen.GetContent(x, 0)
+		line = append(line, mainc)
+	}
+	// The position is among the choices matching the search query
+	if string(line) != " question     (2/2) " {
+		t.Errorf("expected the position to be displayed on the right of the question, got %q", string(line))
+	}
+}
+
+func TestCountOptionsAndChoices(t *testing.T) {
+	config := defaultConfig
+	OptionDimFiltered()(&config)
+	choices := newChoices([]string{"apple", "banana", "cherry"})
+	state := &promptState{}
+	options := filterChoices(choices, "an", &config, state)
+	if optionCount, choiceCount := state.countOptionsAndChoices(options, choices); optionCount != 1 || choiceCount != 1 {
+		t.Errorf("expected 1/1, got %d/%d", optionCount, choiceCount)
+	}
+	// With OptionDimFiltered, the choices that can be picked change without the choices changing
+	options = filterChoices(choices, "", &config, state)
+	if optionCount, choiceCount := state.countOptionsAndChoices(options, choices); optionCount != 3 || choiceCount != 3 {
+		t.Errorf("expected 3/3, got %d/%d", optionCount, choiceCount)
+	}
+	choices = append(choices, &Choice{Id: 3, Value: "date"})
+	if optionCount, choiceCount := state.countOptionsAndChoices(options, choices); optionCount != 3 || choiceCount != 4 {
+		t.Errorf("expected 3/4, got %d/%d", optionCount, choiceCount)
+	}
+}
+
+func TestPositionIndicator(t *testing.T) {
+	choices := newChoicesFromItems([]Item{StaticLabel("Fruits"), {Label: "apple"}, {Label: "banana"}})
+	config := defaultConfig
+	state := &promptState{}
+	options := filterChoices(choices, "", &config, state)
+	optionCount, _ := state.countOptionsAndChoices(options, choices)
+	if indicator := positionIndicator(options, choices[2], optionCount); indicator != "(2/2)" {
+		t.Errorf("expected the label to be left out, got %q", indicator)
+	}
+	if indicator := positionIndicator(options, nil, optionCount); indicator != "" {
+		t.Errorf("expected no indicator without a choice selected, got %q", indicator)
+	}
+}
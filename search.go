@@ -0,0 +1,644 @@
+package gochoice
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SearchCase defines whether the case of the letters of the search query matters
+type SearchCase int
+
+const (
+	// SearchSmartCase ignores case unless the search query contains an uppercase letter
+	SearchSmartCase SearchCase = iota
+
+	// SearchIgnoreCase always ignores case
+	SearchIgnoreCase
+
+	// SearchCaseSensitive never ignores case
+	SearchCaseSensitive
+)
+
+const (
+	fuzzyScoreMatch             = 16
+	fuzzyScoreConsecutiveBonus  = 12
+	fuzzyScoreWordBoundaryBonus = 8
+	fuzzyScoreFirstCharBonus    = 4
+	fuzzyScoreGapPenalty        = 1
+	fuzzyScoreMaxLeadingPenalty = 3
+)
+
+// filterChunkSize is the number of choices filtered at once by the event loop, which handles the events in between,
+// so that the prompt stays responsive while millions of choices are filtered
+const filterChunkSize = 5000
+
+// filterChoices marks every choice that doesn't match the search query as hidden and returns the
+// remaining choices in the order in which they should be displayed. Hidden and filtered choices are deselected.
+// Headers are only displayed if at least one of the choices under them is displayed.
+func filterChoices(choices []*Choice, searchQuery string, config *Config, state *promptState) []*Choice {
+	state.startFiltering(choices, searchQuery, config)
+	return state.filter.run(len(choices)+1, config, state)
+}
+
+// filterJob filters the choices against a search query a chunk at a time. While it is running, the choices displayed
+// are the ones matching the search query among the choices filtered so far.
+type filterJob struct {
+	// choices are all the choices, whose number changes once choices are added to them
+	choices     []*Choice
+	searchQuery string
+	// pending are the choices left to filter, in order
+	pending        [][]*Choice
+	visibleChoices []*Choice
+	// headers are the consecutive headers displayed above the choices that follow them, e.g. a separator and a label
+	headers                []*Choice
+	previousChoiceIsHeader bool
+	// hasValue is true if one of the choices filtered has the search query as its value
+	hasValue bool
+	// optionCount is the number of visible choices that can be picked
+	optionCount int
+	// first and last are the first and the last visible choices that can be selected
+	first, last *Choice
+	finished    bool
+	// sorted is true if the visible choices were sorted by score once they had all been filtered
+	sorted bool
+	// custom is the choice created from the search query with OptionAllowCustom, if it is displayed
+	custom *Choice
+}
+
+// startFiltering starts filtering the choices against the search query, replacing the filtering in progress, if any.
+// Only the choices displayed for a search query it extends are filtered again, if possible. A tree is filtered
+// at once, since whether its choices are displayed depends on the choices after them.
+func (state *promptState) startFiltering(choices []*Choice, searchQuery string, config *Config) {
+	job := &filterJob{choices: choices, searchQuery: searchQuery}
+	state.filter = job
+	if config.tree {
+		job.visibleChoices, job.finished = filterTree(choices, searchQuery, config, state), true
+		job.number()
+		return
+	}
+	candidates := choices
+	if narrowsDown(config) {
+		if state.filterHistory == nil {
+			state.filterHistory = &filterHistory{}
+		}
+		candidates = state.filterHistory.candidates(choices, searchQuery)
+	}
+	job.pending = [][]*Choice{candidates}
+}
+
+// filtering reports whether the choices are still being filtered
+func (state *promptState) filtering() bool {
+	return state.filter != nil && !state.filter.finished
+}
+
+// canAddChoices reports whether the choices added at the end of the choices can be filtered after them with addChoices,
+// rather than all the choices being filtered again, which they must be if the choices displayed are sorted or if
+// the choice created from the search query is displayed after them
+func (state *promptState) canAddChoices(searchQuery string, config *Config) bool {
+	job := state.filter
+	return job != nil && !job.sorted && job.custom == nil && !config.tree && config.Sort == nil && job.searchQuery == searchQuery
+}
+
+// addChoices adds the choices at the end of the choices, which were filtered or are still being filtered,
+// to the choices to filter, and counts them
+func (state *promptState) addChoices(choices []*Choice) {
+	job := state.filter
+	addedChoices := choices[len(job.choices):]
+	if sameChoices(state.countedChoices, job.choices) {
+		state.countedChoices, state.choiceCount = choices, state.choiceCount+countChoices(addedChoices)
+	}
+	if state.checks != nil && sameChoices(state.checks.choices, job.choices) {
+		state.checks.add(choices, addedChoices)
+	}
+	job.choices, job.finished = choices, false
+	job.pending = append(job.pending, addedChoices)
+}
+
+// filterNextChunk filters the next filterChunkSize choices, and finishes the filtering once they have all been
+// filtered. It returns the choices displayed so far.
+func (state *promptState) filterNextChunk(config *Config) []*Choice {
+	return state.filter.run(filterChunkSize, config, state)
+}
+
+// run filters up to the given number of choices, then finishes the filtering if there are none left,
+// and returns the choices displayed so far
+func (job *filterJob) run(count int, config *Config, state *promptState) []*Choice {
+	filteredChange := 0
+	for count > 0 && len(job.pending) > 0 {
+		candidates := job.pending[0]
+		if len(candidates) > count {
+			candidates = candidates[:count]
+		}
+		for _, choice := range candidates {
+			filteredChange += job.filterChoice(choice, config, state)
+		}
+		count -= len(candidates)
+		if len(candidates) == len(job.pending[0]) {
+			job.pending = job.pending[1:]
+		} else {
+			job.pending[0] = job.pending[0][len(candidates):]
+		}
+	}
+	// With OptionDimFiltered, which choices can be picked changes while the choices stay the same
+	if sameChoices(state.countedChoices, job.choices) {
+		state.choiceCount -= filteredChange
+	}
+	if len(job.pending) == 0 && !job.finished {
+		job.finish(config, state)
+	}
+	return job.visibleChoices
+}
+
+// filterChoice matches the choice against the search query and displays it if it matches.
+// It returns 1 if the choice is now displayed as filtered and wasn't before, -1 if it is no longer, and 0 otherwise.
+func (job *filterJob) filterChoice(choice *Choice, config *Config, state *promptState) int {
+	if choice.header {
+		if !job.previousChoiceIsHeader {
+			job.headers = nil
+		}
+		job.headers = append(job.headers, choice)
+		choice.hidden, job.previousChoiceIsHeader = true, true
+		return 0
+	}
+	job.previousChoiceIsHeader = false
+	if choice.Value == job.searchQuery {
+		job.hasValue = true
+	}
+	if choice.duplicate {
+		choice.hidden = true
+		return 0
+	}
+	matched, score, positions := matchChoiceAndDescription(choice, job.searchQuery, config, state)
+	wasFiltered := choice.filtered
+	// With OptionDimFiltered, the choices that don't match stay displayed, but they can't be selected
+	choice.hidden, choice.filtered = !matched && !config.DimFiltered, !matched && config.DimFiltered
+	choice.score = score
+	choice.matchedPositions = positions
+	if choice.hidden || choice.filtered {
+		choice.Selected = false
+	}
+	if !choice.hidden {
+		for _, header := range job.headers {
+			if header.hidden {
+				header.hidden = false
+				job.show(header)
+			}
+		}
+		job.show(choice)
+	}
+	switch {
+	case choice.filtered && !wasFiltered:
+		return 1
+	case !choice.filtered && wasFiltered:
+		return -1
+	}
+	return 0
+}
+
+// show displays the choice after the choices displayed so far
+func (job *filterJob) show(choice *Choice) {
+	job.visibleChoices = append(job.visibleChoices, choice)
+	job.count(choice, len(job.visibleChoices)-1)
+}
+
+// count sets the position of the visible choice, which is at the given index, and counts it if it can be picked
+func (job *filterJob) count(choice *Choice, index int) {
+	choice.position = index
+	if choice.counted() {
+		job.optionCount++
+		choice.ordinal = job.optionCount
+	}
+	if choice.selectable() {
+		if job.first == nil {
+			job.first = choice
+		}
+		job.last = choice
+	}
+}
+
+// number sets the positions of all the visible choices and counts them again, once they have been reordered
+func (job *filterJob) number() {
+	job.optionCount, job.first, job.last = 0, nil, nil
+	for i, choice := range job.visibleChoices {
+		job.count(choice, i)
+	}
+}
+
+// finish records the choices displayed in the filter history, sorts them by score if needed and
+// adds the choice created from the search query with OptionAllowCustom
+func (job *filterJob) finish(config *Config, state *promptState) {
+	job.finished = true
+	if state.filterHistory != nil {
+		state.filterHistory.add(job.searchQuery, job.visibleChoices)
+	}
+	// The choices keep their order with OptionDimFiltered, so that they don't move around while the query is typed
+	if (config.FuzzySearch || config.Matcher != nil) && len(job.searchQuery) > 0 && !config.DimFiltered {
+		// The choices added to the history must keep their original order
+		job.visibleChoices = append([]*Choice(nil), job.visibleChoices...)
+		sortByScore(job.visibleChoices)
+		job.sorted = true
+		job.number()
+	}
+	if len(config.CustomLabel) > 0 && !config.multiSelect && len(job.searchQuery) > 0 && !job.hasValue {
+		job.custom = &Choice{Id: -1, Value: job.searchQuery, custom: true}
+		job.show(job.custom)
+	}
+}
+
+// filterHistory holds the choices displayed for each search query typed since the choices were filtered from scratch,
+// each query extending the one before, so that typing or deleting a character only matches the choices displayed for
+// the longest of these queries that the new query extends, rather than all of them, which are hidden already
+type filterHistory struct {
+	choices []*Choice
+	steps   []filterStep
+}
+
+// filterStep is a search query and the choices displayed for it, in their original order
+type filterStep struct {
+	searchQuery    string
+	visibleChoices []*Choice
+}
+
+// candidates returns the choices that may match the search query, dropping the steps of the queries it doesn't extend.
+// The history is cleared if the choices differ from the choices it was built from.
+func (history *filterHistory) candidates(choices []*Choice, searchQuery string) []*Choice {
+	if !sameChoices(history.choices, choices) {
+		history.choices, history.steps = choices, nil
+	}
+	for len(history.steps) > 0 {
+		if step := history.steps[len(history.steps)-1]; strings.HasPrefix(searchQuery, step.searchQuery) {
+			return step.visibleChoices
+		}
+		history.steps = history.steps[:len(history.steps)-1]
+	}
+	return choices
+}
+
+// add records the choices displayed for the search query, replacing the step of the same query if it is the last one
+func (history *filterHistory) add(searchQuery string, visibleChoices []*Choice) {
+	if last := len(history.steps) - 1; last >= 0 && history.steps[last].searchQuery == searchQuery {
+		history.steps = history.steps[:last]
+	}
+	history.steps = append(history.steps, filterStep{searchQuery: searchQuery, visibleChoices: visibleChoices})
+}
+
+// narrowsDown reports whether the choices matching a search query can only be among the choices matching the queries
+// it extends, which doesn't hold for regular expressions, extended search queries and custom matchers, e.g. "a|b"
+func narrowsDown(config *Config) bool {
+	return config.Matcher == nil && !config.RegexSearch && !config.ExtendedSearch
+}
+
+// withPositions sets the position of each of the visible choices to its index, and returns them
+func withPositions(visibleChoices []*Choice) []*Choice {
+	for i, choice := range visibleChoices {
+		choice.position = i
+	}
+	return visibleChoices
+}
+
+// uniqueMatch returns the only choice that can be picked among the visible choices, or nil if there are several
+// of them or none. Branches of a tree don't count as a match, since picking them expands them.
+func uniqueMatch(visibleChoices []*Choice) *Choice {
+	var match *Choice
+	for _, choice := range visibleChoices {
+		if !choice.selectable() {
+			continue
+		}
+		if match != nil || choice.branch {
+			return nil
+		}
+		match = choice
+	}
+	return match
+}
+
+// matchChoiceAndDescription matches the choice against the search query with the Matcher of the config if it has one,
+// or else matches its value and, if enabled, its description.
+// Positions are relative to the value followed by the separator and the description, as displayed.
+func matchChoiceAndDescription(choice *Choice, searchQuery string, config *Config, state *promptState) (bool, int, []int) {
+	if config.Matcher != nil {
+		if len(searchQuery) == 0 {
+			return true, 0, nil
+		}
+		matched, positions, score := config.Matcher.Match(searchQuery, *choice)
+		return matched, score, positions
+	}
+	matched, score, positions := matchChoice(choice.Value, searchQuery, config, state)
+	if !matched && config.SearchDescriptions && len(choice.Description) > 0 {
+		matched, score, positions = matchChoice(choice.Description, searchQuery, config, state)
+		positions = offsetPositions(positions, len([]rune(choice.Value+descriptionSeparator)))
+	}
+	return matched, score, positions
+}
+
+// sortByScore sorts the choices by descending score without moving them across headers,
+// so that each choice stays under the header of its group
+func sortByScore(choices []*Choice) {
+	start := 0
+	for end := 0; end <= len(choices); end++ {
+		if end == len(choices) || choices[end].header {
+			group := choices[start:end]
+			sort.SliceStable(group, func(i, j int) bool {
+				return group[i].score > group[j].score
+			})
+			start = end + 1
+		}
+	}
+}
+
+// matchChoice reports whether the value matches the search query as well as the score of the match
+// and the positions of the matched runes in the value. An empty search query matches every value.
+func matchChoice(value, searchQuery string, config *Config, state *promptState) (bool, int, []int) {
+	if len(searchQuery) == 0 {
+		return true, 0, nil
+	}
+	if config.UnicodeNormalization {
+		return matchWithoutDiacritics(value, searchQuery, config, state)
+	}
+	return matchText(value, searchQuery, config, state)
+}
+
+// matchText matches the value against a non-empty search query according to the search options of the config
+func matchText(value, searchQuery string, config *Config, state *promptState) (bool, int, []int) {
+	ignoreCase := config.ignoresCase(searchQuery)
+	if config.RegexSearch {
+		return regexpMatch(value, searchQuery, config, state)
+	}
+	if config.ExtendedSearch {
+		return extendedMatch(value, searchQuery, ignoreCase, config)
+	}
+	if config.FuzzySearch {
+		return fuzzyMatch(value, searchQuery, ignoreCase)
+	}
+	return substringMatch(value, searchQuery, ignoreCase)
+}
+
+// ignoresCase reports whether the case of the letters should be ignored when searching for the given query
+func (config *Config) ignoresCase(searchQuery string) bool {
+	switch config.SearchCase {
+	case SearchIgnoreCase:
+		return true
+	case SearchCaseSensitive:
+		return false
+	}
+	for _, r := range searchQuery {
+		if unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// substringMatch reports whether the value contains the search query, ignoring case if ignoreCase is true
+func substringMatch(value, searchQuery string, ignoreCase bool) (bool, int, []int) {
+	// The value is matched without being converted to runes, since every choice is matched on every keystroke
+	for offset, start := 0, 0; ; start++ {
+		if hasRunePrefix(value[offset:], searchQuery, ignoreCase) {
+			positions := make([]int, utf8.RuneCountInString(searchQuery))
+			for i := range positions {
+				positions[i] = start + i
+			}
+			return true, 0, positions
+		}
+		if offset == len(value) {
+			return false, 0, nil
+		}
+		_, size := utf8.DecodeRuneInString(value[offset:])
+		offset += size
+	}
+}
+
+// hasRunePrefix reports whether the text starts with the prefix, ignoring case if ignoreCase is true
+func hasRunePrefix(text, prefix string, ignoreCase bool) bool {
+	for len(prefix) > 0 {
+		if len(text) == 0 {
+			return false
+		}
+		if text[0] < utf8.RuneSelf && prefix[0] < utf8.RuneSelf {
+			// ASCII characters, which most values are made of, are compared without being decoded
+			if text[0] != prefix[0] && (!ignoreCase || toLowerASCII(text[0]) != toLowerASCII(prefix[0])) {
+				return false
+			}
+			text, prefix = text[1:], prefix[1:]
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(text)
+		prefixRune, prefixSize := utf8.DecodeRuneInString(prefix)
+		if r != prefixRune && (!ignoreCase || unicode.ToLower(r) != unicode.ToLower(prefixRune)) {
+			return false
+		}
+		text, prefix = text[size:], prefix[prefixSize:]
+	}
+	return true
+}
+
+// toLowerASCII returns the lowercase of an ASCII character
+func toLowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// regexpMatch reports whether the value matches the search query compiled as a regular expression.
+// While the search query is not a valid regular expression, every value matches it.
+func regexpMatch(value, searchQuery string, config *Config, state *promptState) (bool, int, []int) {
+	searchRegexp, err := state.compileSearchQuery(searchQuery, config)
+	if err != nil {
+		return true, 0, nil
+	}
+	location := searchRegexp.FindStringIndex(value)
+	if location == nil {
+		return false, 0, nil
+	}
+	// The location is in bytes, whereas the positions are in runes
+	start := utf8.RuneCountInString(value[:location[0]])
+	positions := make([]int, utf8.RuneCountInString(value[location[0]:location[1]]))
+	for i := range positions {
+		positions[i] = start + i
+	}
+	return true, 0, positions
+}
+
+// compileSearchQuery compiles the search query as a regular expression, reusing the last one compiled
+// if the search query hasn't changed since, as every choice is matched against the same search query
+func (state *promptState) compileSearchQuery(searchQuery string, config *Config) (*regexp.Regexp, error) {
+	if state.searchRegexp != nil && state.searchRegexp.query == searchQuery {
+		return state.searchRegexp.regexp, state.searchRegexp.err
+	}
+	pattern := searchQuery
+	if config.ignoresCase(searchQuery) {
+		pattern = "(?i)" + pattern
+	}
+	compiledRegexp, err := regexp.Compile(pattern)
+	if syntaxError, ok := err.(*syntax.Error); ok {
+		// The pattern is already displayed in the search bar
+		err = errors.New(string(syntaxError.Code))
+	}
+	state.searchRegexp = &compiledSearchQuery{query: searchQuery, regexp: compiledRegexp, err: err}
+	return compiledRegexp, err
+}
+
+// compiledSearchQuery is a search query compiled as a regular expression
+type compiledSearchQuery struct {
+	query  string
+	regexp *regexp.Regexp
+	err    error
+}
+
+// fuzzyMatch reports whether all runes of the search query appear in the value in the same order,
+// ignoring case if ignoreCase is true. The higher the score, the better the match: consecutive runes, runes
+// at the start of a word and matches close to the start of the value are rewarded, while gaps are penalized.
+func fuzzyMatch(value, searchQuery string, ignoreCase bool) (bool, int, []int) {
+	originalRunes := []rune(value)
+	valueRunes, queryRunes := originalRunes, []rune(searchQuery)
+	if ignoreCase {
+		valueRunes, queryRunes = toLowerRunes(valueRunes), toLowerRunes(queryRunes)
+	}
+	bestScore, bestPositions, matched := 0, []int(nil), false
+	// Try every possible position for the first rune of the query and keep the best score
+	for start := range valueRunes {
+		if valueRunes[start] != queryRunes[0] {
+			continue
+		}
+		score, positions, ok := fuzzyScoreFrom(valueRunes, originalRunes, queryRunes, start)
+		if ok && (!matched || score > bestScore) {
+			bestScore, bestPositions, matched = score, positions, true
+		}
+	}
+	return matched, bestScore, bestPositions
+}
+
+func fuzzyScoreFrom(valueRunes, originalRunes, queryRunes []rune, start int) (int, []int, bool) {
+	score := 0
+	previous := -1
+	positions := make([]int, 0, len(queryRunes))
+	for i := start; i < len(valueRunes) && len(positions) < len(queryRunes); i++ {
+		if valueRunes[i] != queryRunes[len(positions)] {
+			continue
+		}
+		score += fuzzyScoreMatch
+		if i == 0 {
+			score += fuzzyScoreFirstCharBonus
+		}
+		if isWordBoundary(originalRunes, i) {
+			score += fuzzyScoreWordBoundaryBonus
+		}
+		if previous >= 0 {
+			if i == previous+1 {
+				score += fuzzyScoreConsecutiveBonus
+			} else {
+				score -= (i - previous - 1) * fuzzyScoreGapPenalty
+			}
+		} else if i*fuzzyScoreGapPenalty < fuzzyScoreMaxLeadingPenalty {
+			score -= i * fuzzyScoreGapPenalty
+		} else {
+			score -= fuzzyScoreMaxLeadingPenalty
+		}
+		previous = i
+		positions = append(positions, i)
+	}
+	return score, positions, len(positions) == len(queryRunes)
+}
+
+func toLowerRunes(runes []rune) []rune {
+	lowerRunes := make([]rune, len(runes))
+	for i, r := range runes {
+		lowerRunes[i] = unicode.ToLower(r)
+	}
+	return lowerRunes
+}
+
+// isWordBoundary reports whether the rune at index i is the first rune of a word
+func isWordBoundary(runes []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	previous, current := runes[i-1], runes[i]
+	if !unicode.IsLetter(previous) && !unicode.IsDigit(previous) {
+		return true
+	}
+	return unicode.IsLower(previous) && unicode.IsUpper(current)
+}
+
+// OptionSearchCaseSensitive makes the search take the case of the letters into account.
+// By default, case is ignored unless the search query contains an uppercase letter.
+func OptionSearchCaseSensitive() func(config *Config) {
+	return func(config *Config) {
+		config.SearchCase = SearchCaseSensitive
+	}
+}
+
+// OptionSearchIgnoreCase makes the search ignore the case of the letters, even if the search query contains
+// an uppercase letter
+func OptionSearchIgnoreCase() func(config *Config) {
+	return func(config *Config) {
+		config.SearchCase = SearchIgnoreCase
+	}
+}
+
+// OptionRegexSearch makes the search query a regular expression, e.g. "^(prod|staging)-" matches the choices
+// starting with either prefix. While the search query is not a valid regular expression, the error is displayed
+// in the search bar and no choice is filtered out.
+func OptionRegexSearch() func(config *Config) {
+	return func(config *Config) {
+		config.RegexSearch = true
+	}
+}
+
+// OptionInitialQuery opens the prompt with the given search query already typed, so that the choices are already
+// filtered. The search query can be edited as usual.
+func OptionInitialQuery(query string) func(config *Config) {
+	return func(config *Config) {
+		config.InitialQuery = query
+	}
+}
+
+// OptionAllowCustom lets the user pick the search query itself when it isn't one of the choices, by selecting
+// an additional choice displayed after the choices matching it, e.g. Create 'query' if the label is "Create".
+// The index returned for that choice is -1. It has no effect on prompts allowing several choices to be selected,
+// nor on those returning one of the items given rather than its value, such as PickT, PickRich or PickMenu.
+func OptionAllowCustom(label string) func(config *Config) {
+	return func(config *Config) {
+		config.CustomLabel = label
+	}
+}
+
+// OptionEmptyMessage replaces the message displayed when no choices match the search query,
+// e.g. "No results, press backspace to edit the search query"
+func OptionEmptyMessage(message string) func(config *Config) {
+	return func(config *Config) {
+		config.EmptyMessage = message
+	}
+}
+
+// OptionDimFiltered keeps the choices that don't match the search query displayed, in the Disabled style of the theme,
+// instead of hiding them. They can't be selected, and the choices keep their order even with fuzzy search.
+func OptionDimFiltered() func(config *Config) {
+	return func(config *Config) {
+		config.DimFiltered = true
+	}
+}
+
+// OptionSelectOnUniqueMatch picks the choice matching the search query as soon as it is the only one left,
+// without having to press Enter. The choice created with OptionAllowCustom counts as a match, so that the search
+// query can still be picked. It has no effect on prompts allowing several choices to be selected.
+func OptionSelectOnUniqueMatch() func(config *Config) {
+	return func(config *Config) {
+		config.SelectOnUniqueMatch = true
+	}
+}
+
+// OptionWithoutSearch disables the search, so that typing a rune moves the cursor to the next choice starting with it,
+// or picks the choice with that rune as its hotkey, instead of filtering the choices. The search bar isn't displayed.
+// This is useful for menus whose choices are numbers, or whose keys are hotkeys or vim bindings.
+func OptionWithoutSearch() func(config *Config) {
+	return func(config *Config) {
+		config.WithoutSearch = true
+	}
+}
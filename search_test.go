@@ -0,0 +1,643 @@
+package gochoice
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	scenarios := []struct {
+		value         string
+		searchQuery   string
+		expectedMatch bool
+	}{
+		{value: "production", searchQuery: "prd", expectedMatch: true},
+		{value: "production", searchQuery: "PRD", expectedMatch: true},
+		{value: "production", searchQuery: "dp", expectedMatch: false},
+		{value: "staging", searchQuery: "staging", expectedMatch: true},
+		{value: "staging", searchQuery: "stagingg", expectedMatch: false},
+		{value: "über", searchQuery: "üb", expectedMatch: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.value+"_"+scenario.searchQuery, func(t *testing.T) {
+			if matched, _, _ := fuzzyMatch(scenario.value, scenario.searchQuery, true); matched != scenario.expectedMatch {
+				t.Errorf("expected %v, got %v", scenario.expectedMatch, matched)
+			}
+		})
+	}
+}
+
+func TestFuzzyMatchScore(t *testing.T) {
+	_, consecutiveScore, _ := fuzzyMatch("staging", "sta", true)
+	_, scatteredScore, _ := fuzzyMatch("system-test-area", "sta", true)
+	if consecutiveScore <= scatteredScore {
+		t.Errorf("expected consecutive match score (%d) to be higher than scattered match score (%d)", consecutiveScore, scatteredScore)
+	}
+	_, wordBoundaryScore, _ := fuzzyMatch("connect-to-production", "prod", true)
+	_, middleOfWordScore, _ := fuzzyMatch("reproduction", "prod", true)
+	if wordBoundaryScore <= middleOfWordScore {
+		t.Errorf("expected word boundary match score (%d) to be higher than middle of word match score (%d)", wordBoundaryScore, middleOfWordScore)
+	}
+}
+
+func TestFilterChoicesWithFuzzySearchRanksByScore(t *testing.T) {
+	config := defaultConfig
+	OptionFuzzySearch()(&config)
+	choices := newChoices([]string{"system-test-area", "john", "staging"})
+	visibleChoices := filterChoices(choices, "sta", &config, &promptState{})
+	if len(visibleChoices) != 2 {
+		t.Fatalf("expected 2 visible choices, got %d", len(visibleChoices))
+	}
+	if visibleChoices[0].Value != "staging" || visibleChoices[1].Value != "system-test-area" {
+		t.Error("expected [staging system-test-area], got", visibleChoices[0].Value, visibleChoices[1].Value)
+	}
+	if !choices[1].hidden {
+		t.Error("expected john to be hidden")
+	}
+}
+
+func TestPickWithFuzzySearch(t *testing.T) {
+	config := defaultConfig
+	OptionFuzzySearch()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, 's', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 't', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'g', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := pick("question", []string{"production", "system-test-config", "staging"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "staging" {
+		t.Error("expected staging, got", choice)
+	}
+	if index != 2 {
+		t.Error("expected 2, got", index)
+	}
+}
+
+func TestMatchPositions(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		value             string
+		searchQuery       string
+		fuzzy             bool
+		expectedPositions []int
+	}{
+		{name: "substring", value: "Connect to STAGING", searchQuery: "stag", expectedPositions: []int{11, 12, 13, 14}},
+		{name: "substring-unicode", value: "über uns", searchQuery: "uns", expectedPositions: []int{5, 6, 7}},
+		{name: "fuzzy", value: "production", searchQuery: "prd", fuzzy: true, expectedPositions: []int{0, 1, 3}},
+		{name: "fuzzy-word-boundary", value: "go-choice", searchQuery: "gc", fuzzy: true, expectedPositions: []int{0, 3}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			config.FuzzySearch = scenario.fuzzy
+			matched, _, positions := matchChoice(scenario.value, scenario.searchQuery, &config, &promptState{})
+			if !matched {
+				t.Fatal("expected match")
+			}
+			if fmt.Sprint(positions) != fmt.Sprint(scenario.expectedPositions) {
+				t.Errorf("expected %v, got %v", scenario.expectedPositions, positions)
+			}
+		})
+	}
+}
+
+func TestMatchChoiceWithSearchCase(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		searchCase    SearchCase
+		value         string
+		searchQuery   string
+		expectedMatch bool
+	}{
+		{name: "smart-case-lowercase-query", searchCase: SearchSmartCase, value: "Staging", searchQuery: "stag", expectedMatch: true},
+		{name: "smart-case-uppercase-query", searchCase: SearchSmartCase, value: "staging", searchQuery: "Stag", expectedMatch: false},
+		{name: "smart-case-same-case", searchCase: SearchSmartCase, value: "Staging", searchQuery: "Stag", expectedMatch: true},
+		{name: "ignore-case", searchCase: SearchIgnoreCase, value: "staging", searchQuery: "STAG", expectedMatch: true},
+		{name: "case-sensitive", searchCase: SearchCaseSensitive, value: "Staging", searchQuery: "stag", expectedMatch: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			for _, fuzzy := range []bool{false, true} {
+				config := defaultConfig
+				config.SearchCase = scenario.searchCase
+				config.FuzzySearch = fuzzy
+				if matched, _, _ := matchChoice(scenario.value, scenario.searchQuery, &config, &promptState{}); matched != scenario.expectedMatch {
+					t.Errorf("expected %v with fuzzy search %v, got %v", scenario.expectedMatch, fuzzy, matched)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchChoiceWithRegexSearch(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		value             string
+		searchQuery       string
+		expectedMatch     bool
+		expectedPositions []int
+	}{
+		{name: "alternation", value: "staging-eu", searchQuery: "^(prod|staging)-", expectedMatch: true, expectedPositions: []int{0, 1, 2, 3, 4, 5, 6, 7}},
+		{name: "no-match", value: "dev-eu", searchQuery: "^(prod|staging)-", expectedMatch: false},
+		{name: "unicode", value: "über-eu", searchQuery: "r-e", expectedMatch: true, expectedPositions: []int{3, 4, 5}},
+		{name: "smart-case", value: "ERROR: disk full", searchQuery: "error", expectedMatch: true, expectedPositions: []int{0, 1, 2, 3, 4}},
+		{name: "invalid-pattern", value: "anything", searchQuery: "foo(", expectedMatch: true},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionRegexSearch()(&config)
+			matched, _, positions := matchChoice(scenario.value, scenario.searchQuery, &config, &promptState{})
+			if matched != scenario.expectedMatch {
+				t.Errorf("expected %v, got %v", scenario.expectedMatch, matched)
+			}
+			if fmt.Sprint(positions) != fmt.Sprint(scenario.expectedPositions) {
+				t.Errorf("expected %v, got %v", scenario.expectedPositions, positions)
+			}
+		})
+	}
+}
+
+func TestRenderWithInvalidRegexSearch(t *testing.T) {
+	config := defaultConfig
+	OptionRegexSearch()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(60, 4)
+	choices := newChoices([]string{"a", "b"})
+	state := &promptState{}
+	render(screen, "question", filterChoices(choices, "a(", &config, state), &config, state, choices[0], "a(", true, 0, choices)
+	screen.Show()
+	var line []rune
+	for x := 0; x < 60; x++ {
+		mainc, _, _, _ := screen.GetContent(x, 3)
+		line = append(line, mainc)
+	}
+	if text := strings.TrimSpace(string(line)); text != "Search: a(_  2/2  (invalid pattern: missing closing ))" {
+		t.Errorf("expected the error to be displayed in the search bar, got %q", text)
+	}
+}
+
+func TestSubstringMatch(t *testing.T) {
+	scenarios := []struct {
+		value             string
+		searchQuery       string
+		ignoreCase        bool
+		expectedMatched   bool
+		expectedPositions []int
+	}{
+		{value: "hello world", searchQuery: "wor", ignoreCase: true, expectedMatched: true, expectedPositions: []int{6, 7, 8}},
+		{value: "Hello World", searchQuery: "world", ignoreCase: true, expectedMatched: true, expectedPositions: []int{6, 7, 8, 9, 10}},
+		{value: "Hello World", searchQuery: "world", ignoreCase: false, expectedMatched: false},
+		{value: "héllo wörld", searchQuery: "WÖR", ignoreCase: true, expectedMatched: true, expectedPositions: []int{6, 7, 8}},
+		{value: "273 \u212aelvin", searchQuery: "kelvin", ignoreCase: true, expectedMatched: true, expectedPositions: []int{4, 5, 6, 7, 8, 9}},
+		{value: "hello", searchQuery: "hello world", ignoreCase: true, expectedMatched: false},
+		{value: "", searchQuery: "", ignoreCase: true, expectedMatched: true, expectedPositions: []int{}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.value+"-"+scenario.searchQuery, func(t *testing.T) {
+			matched, _, positions := substringMatch(scenario.value, scenario.searchQuery, scenario.ignoreCase)
+			if matched != scenario.expectedMatched {
+				t.Errorf("expected matched to be %v, got %v", scenario.expectedMatched, matched)
+			}
+			if !reflect.DeepEqual(positions, scenario.expectedPositions) {
+				t.Errorf("expected positions %v, got %v", scenario.expectedPositions, positions)
+			}
+		})
+	}
+}
+
+func TestFilterChoicesIncrementally(t *testing.T) {
+	newGroupedChoices := func() []*Choice {
+		return newChoicesFromGroups([]Group{
+			{Name: "Fruits", Items: []Item{{Label: "apple"}, {Label: "apricot"}, {Label: "banana"}}},
+			{Name: "Vegetables", Items: []Item{{Label: "asparagus"}, {Label: "bean"}}},
+		})
+	}
+	for _, fuzzy := range []bool{false, true} {
+		t.Run(fmt.Sprintf("fuzzy-%v", fuzzy), func(t *testing.T) {
+			config := defaultConfig
+			config.FuzzySearch = fuzzy
+			choices := newGroupedChoices()
+			state := &promptState{}
+			// Each search query is typed or deleted from the previous one, and must be filtered like from scratch
+			for _, searchQuery := range []string{"", "a", "ap", "apr", "ap", "a", "as", "", "b", "be"} {
+				scratchConfig := defaultConfig
+				scratchConfig.FuzzySearch = fuzzy
+				var expectedValues, values []string
+				for _, choice := range filterChoices(newGroupedChoices(), searchQuery, &scratchConfig, &promptState{}) {
+					expectedValues = append(expectedValues, choice.Value)
+				}
+				displayed := make(map[*Choice]bool)
+				for _, choice := range filterChoices(choices, searchQuery, &config, state) {
+					values = append(values, choice.Value)
+					displayed[choice] = true
+				}
+				if !reflect.DeepEqual(values, expectedValues) {
+					t.Errorf("expected %v for %q, got %v", expectedValues, searchQuery, values)
+				}
+				for _, choice := range choices {
+					if choice.hidden == displayed[choice] {
+						t.Errorf("expected %s to be hidden only if it isn't displayed for %q", choice.Value, searchQuery)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPickWithInitialQuery(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		keys           []tcell.Key
+		expectedChoice string
+	}{
+		{name: "filtered", keys: []tcell.Key{tcell.KeyEnter}, expectedChoice: "development"},
+		{name: "edited", keys: []tcell.Key{tcell.KeyBackspace2, tcell.KeyEnter}, expectedChoice: "production"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionInitialQuery("de")(&config)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			for _, key := range scenario.keys {
+				screen.InjectKey(key, 0, tcell.ModNone)
+			}
+			choice, _, err := pick("question", []string{"production", "staging", "development"}, screen, &config)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if choice != scenario.expectedChoice {
+				t.Errorf("expected %s, got %s", scenario.expectedChoice, choice)
+			}
+		})
+	}
+}
+
+func TestPickWithAllowCustom(t *testing.T) {
+	config := defaultConfig
+	OptionAllowCustom("Create")(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKeyBytes([]byte("qa"))
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	result, err := pickDetailed("question", []string{"production", "staging"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if expectedResult := (PickResult{Value: "qa", Index: -1, Query: "qa", Custom: true}); !reflect.DeepEqual(result, expectedResult) {
+		t.Errorf("expected %+v, got %+v", expectedResult, result)
+	}
+}
+
+func TestFilterChoicesWithAllowCustom(t *testing.T) {
+	config := defaultConfig
+	OptionAllowCustom("Create")(&config)
+	choices := newChoices([]string{"staging", "staging-eu"})
+	scenarios := []struct {
+		searchQuery        string
+		expectedCustomRows int
+	}{
+		{searchQuery: "", expectedCustomRows: 0},
+		{searchQuery: "stag", expectedCustomRows: 1},
+		{searchQuery: "staging", expectedCustomRows: 0},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.searchQuery, func(t *testing.T) {
+			customRows := 0
+			for _, choice := range filterChoices(choices, scenario.searchQuery, &config, &promptState{}) {
+				if choice.custom {
+					customRows++
+				}
+			}
+			if customRows != scenario.expectedCustomRows {
+				t.Errorf("expected %d custom rows, got %d", scenario.expectedCustomRows, customRows)
+			}
+		})
+	}
+}
+
+func TestPickWithoutSearch(t *testing.T) {
+	config := defaultConfig
+	OptionWithoutSearch()(&config)
+	OptionInitialQuery("b")(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 4)
+	// Runes move the cursor instead of filtering the choices, and the initial query is ignored
+	screen.InjectKey(tcell.KeyRune, 'c', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"apple", "banana", "cherry"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "cherry" {
+		t.Error("expected cherry, got", choice)
+	}
+	// The search bar isn't displayed, so the last choice is displayed on the last line
+	var line []rune
+	for x := 0; x < 20; x++ {
+		mainc, _, _, _ := screen.GetContent(x, 3)
+		line = append(line, mainc)
+	}
+	if text := strings.TrimSpace(string(line)); text != "> cherry" {
+		t.Errorf("expected the last choice on the last line, got %q", text)
+	}
+}
+
+func TestFilterChoicesWithDimFiltered(t *testing.T) {
+	config := defaultConfig
+	OptionFuzzySearch()(&config)
+	OptionDimFiltered()(&config)
+	choices := newChoices([]string{"system-test-area", "john", "staging"})
+	visibleChoices := filterChoices(choices, "sta", &config, &promptState{})
+	if len(visibleChoices) != 3 {
+		t.Fatalf("expected 3 visible choices, got %d", len(visibleChoices))
+	}
+	for i, choice := range visibleChoices {
+		if choice != choices[i] {
+			t.Errorf("expected %s at index %d, got %s", choices[i].Value, i, choice.Value)
+		}
+	}
+	if !choices[1].filtered || choices[1].selectable() {
+		t.Error("expected john to be filtered and not selectable")
+	}
+	if choices[0].filtered || choices[2].filtered {
+		t.Error("expected the choices matching the search query not to be filtered")
+	}
+	if countChoices(visibleChoices) != 2 {
+		t.Errorf("expected 2 choices to be counted, got %d", countChoices(visibleChoices))
+	}
+	filterChoices(choices, "", &config, &promptState{})
+	if choices[1].filtered {
+		t.Error("expected john not to be filtered once the search query is cleared")
+	}
+}
+
+func TestPickWithDimFiltered(t *testing.T) {
+	config := defaultConfig
+	OptionDimFiltered()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, 's', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 't', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := pick("question", []string{"production", "system-test-config", "staging"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "system-test-config" {
+		t.Error("expected system-test-config, got", choice)
+	}
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+}
+
+func TestUniqueMatch(t *testing.T) {
+	config := defaultConfig
+	choices := newChoices([]string{"production", "preview", "staging"})
+	scenarios := []struct {
+		searchQuery   string
+		expectedMatch string
+	}{
+		{searchQuery: "", expectedMatch: ""},
+		{searchQuery: "pr", expectedMatch: ""},
+		{searchQuery: "prod", expectedMatch: "production"},
+		{searchQuery: "dev", expectedMatch: ""},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.searchQuery, func(t *testing.T) {
+			match := uniqueMatch(filterChoices(choices, scenario.searchQuery, &config, &promptState{}))
+			if len(scenario.expectedMatch) == 0 {
+				if match != nil {
+					t.Error("expected no unique match, got", match.Value)
+				}
+			} else if match == nil || match.Value != scenario.expectedMatch {
+				t.Errorf("expected %s to be the unique match, got %v", scenario.expectedMatch, match)
+			}
+		})
+	}
+}
+
+func TestFilterChoicesInChunks(t *testing.T) {
+	newManyChoices := func() []*Choice {
+		var groups []Group
+		for i := 0; i < 3; i++ {
+			var items []Item
+			for j := 0; j < filterChunkSize; j++ {
+				items = append(items, Item{Label: fmt.Sprintf("item %d-%d", i, j)})
+			}
+			groups = append(groups, Group{Name: fmt.Sprintf("group %d", i), Items: items})
+		}
+		return newChoicesFromGroups(groups)
+	}
+	for _, dimFiltered := range []bool{false, true} {
+		t.Run(fmt.Sprintf("dim-filtered-%v", dimFiltered), func(t *testing.T) {
+			config := defaultConfig
+			config.DimFiltered = dimFiltered
+			choices := newManyChoices()
+			state := &promptState{}
+			for _, searchQuery := range []string{"1-", "1-2", "", "2-1"} {
+				expectedChoices := filterChoices(newManyChoices(), searchQuery, &config, &promptState{})
+				state.startFiltering(choices, searchQuery, &config)
+				var visibleChoices []*Choice
+				chunks := 0
+				for state.filtering() {
+					// The choices are counted while they are displayed, as they would be by the search bar
+					state.countOptionsAndChoices(visibleChoices, choices)
+					visibleChoices = state.filterNextChunk(&config)
+					chunks++
+				}
+				if searchQuery == "1-" && chunks < 2 {
+					t.Errorf("expected the choices to be filtered in several chunks, got %d", chunks)
+				}
+				if len(visibleChoices) != len(expectedChoices) {
+					t.Fatalf("expected %d choices displayed for %q, got %d", len(expectedChoices), searchQuery, len(visibleChoices))
+				}
+				for i, choice := range visibleChoices {
+					if choice.Value != expectedChoices[i].Value || choice.position != i {
+						t.Fatalf("expected %s at %d for %q, got %s at %d", expectedChoices[i].Value, i, searchQuery, choice.Value, choice.position)
+					}
+				}
+				optionCount, choiceCount := state.countOptionsAndChoices(visibleChoices, choices)
+				if optionCount != countChoices(visibleChoices) || choiceCount != countChoices(choices) {
+					t.Errorf("expected %d/%d for %q, got %d/%d", countChoices(visibleChoices), countChoices(choices), searchQuery, optionCount, choiceCount)
+				}
+			}
+		})
+	}
+}
+
+func TestAddChoicesWhileFiltering(t *testing.T) {
+	config := defaultConfig
+	var values []string
+	for i := 0; i < 3*filterChunkSize; i++ {
+		values = append(values, fmt.Sprintf("choice %d", i))
+	}
+	choices := newChoices(values[:2*filterChunkSize])
+	state := &promptState{}
+	state.startFiltering(choices, "1", &config)
+	state.filterNextChunk(&config)
+	for _, value := range values[2*filterChunkSize:] {
+		choices = appendChoice(value)(choices)
+	}
+	state.addChoices(choices)
+	var visibleChoices []*Choice
+	for state.filtering() {
+		visibleChoices = state.filterNextChunk(&config)
+	}
+	expectedChoices := filterChoices(newChoices(values), "1", &config, &promptState{})
+	if len(visibleChoices) != len(expectedChoices) {
+		t.Fatalf("expected %d choices displayed, got %d", len(expectedChoices), len(visibleChoices))
+	}
+	for i, choice := range visibleChoices {
+		if choice.Value != expectedChoices[i].Value {
+			t.Fatalf("expected %s at %d, got %s", expectedChoices[i].Value, i, choice.Value)
+		}
+	}
+	if optionCount, choiceCount := state.countOptionsAndChoices(visibleChoices, choices); optionCount != len(expectedChoices) || choiceCount != len(values) {
+		t.Errorf("expected %d/%d, got %d/%d", len(expectedChoices), len(values), optionCount, choiceCount)
+	}
+}
+
+func TestPickWhileFiltering(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		options []Option
+		keys    []tcell.Key
+	}{
+		{
+			// Confirming the selection waits for all the choices to be filtered
+			name: "confirmed",
+			keys: []tcell.Key{tcell.KeyEnter},
+		},
+		{
+			name:    "unique-match",
+			options: []Option{OptionSelectOnUniqueMatch()},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := newConfig(scenario.options)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.SetStyle(config.Theme.background())
+			screen.Show()
+			// Only the last of the choices, which are filtered in several chunks, matches the search query
+			values := make([]string, 3*filterChunkSize)
+			for i := range values {
+				values[i] = fmt.Sprintf("choice %d", i)
+			}
+			values[len(values)-1] = "the last choice"
+			var events []tcell.Event
+			for _, r := range "last" {
+				events = append(events, tcell.NewEventKey(tcell.KeyRune, r, tcell.ModNone))
+			}
+			for _, key := range scenario.keys {
+				events = append(events, tcell.NewEventKey(key, 0, tcell.ModNone))
+			}
+			SimulateKeys(screen, events)
+			choice, index, err := pick("question", values, screen, config)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if choice != "the last choice" || index != len(values)-1 {
+				t.Errorf("expected the last choice, got %s at %d", choice, index)
+			}
+		})
+	}
+}
+
+func TestPickWithSelectOnUniqueMatch(t *testing.T) {
+	config := defaultConfig
+	OptionSelectOnUniqueMatch()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyRune, 'p', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'r', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'o', tcell.ModNone)
+	choice, index, err := pick("question", []string{"preview", "staging", "production"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "production" {
+		t.Error("expected production, got", choice)
+	}
+	if index != 2 {
+		t.Error("expected 2, got", index)
+	}
+}
+
+func BenchmarkFilterChoices(b *testing.B) {
+	config := defaultConfig
+	choices := newChoices(newBenchmarkValues())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Without the history, the choices are filtered from scratch, as they are once the first character is typed
+		filterChoices(choices, "9", &config, &promptState{})
+	}
+}
+
+func BenchmarkFilterChoicesWithFuzzySearch(b *testing.B) {
+	config := defaultConfig
+	OptionFuzzySearch()(&config)
+	choices := newChoices(newBenchmarkValues())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filterChoices(choices, "9", &config, &promptState{})
+	}
+}
+
+func BenchmarkFilterChoicesRefine(b *testing.B) {
+	config := defaultConfig
+	choices := newChoices(newBenchmarkValues())
+	state := &promptState{}
+	filterChoices(choices, "12345", &config, state)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// The choices matching "123456" are only searched among those matching "12345", as are those matching "12345"
+		// again once the last digit is deleted
+		filterChoices(choices, "123456", &config, state)
+		filterChoices(choices, "12345", &config, state)
+	}
+}
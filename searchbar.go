@@ -0,0 +1,88 @@
+package gochoice
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// SearchBarPosition is where the search bar is displayed
+type SearchBarPosition int
+
+const (
+	// SearchBarBottom displays the search bar on the last line of the screen
+	SearchBarBottom SearchBarPosition = iota
+
+	// SearchBarTop displays the search bar right below the question, above the choices
+	SearchBarTop
+)
+
+// searchPrompt returns the text displayed before the search query
+func searchPrompt(searching bool, config *Config) string {
+	if len(config.SearchPrompt) > 0 {
+		return config.SearchPrompt
+	}
+	if searching {
+		return "Search: "
+	}
+	return "Search (/): "
+}
+
+// renderSearchBar renders the search query on the given line, followed by the number of options matching it
+func renderSearchBar(screen tcell.Screen, y int, options, choices []*Choice, searchQuery string, searching bool, config *Config, state *promptState) {
+	searchBar := searchPrompt(searching, config) + searchQuery
+	if searching {
+		searchBar += "_"
+	}
+	printText(screen, 1, y, searchBar, config.Theme.SearchBar)
+	x := 1 + runewidth.StringWidth(searchBar)
+	if len(searchQuery) == 0 && len(config.SearchPlaceholder) > 0 {
+		printText(screen, x, y, config.SearchPlaceholder, config.Theme.Placeholder)
+		x += runewidth.StringWidth(config.SearchPlaceholder)
+	}
+	x += 2
+	optionCount, choiceCount := state.countOptionsAndChoices(options, choices)
+	counter := fmt.Sprintf("%d/%d", optionCount, choiceCount)
+	if config.multiSelect {
+		counter += fmt.Sprintf(" (%d selected)", state.checkedState(choices).count)
+	}
+	printText(screen, x, y, counter, config.Theme.Counter)
+	if config.RegexSearch && len(searchQuery) > 0 {
+		if _, err := state.compileSearchQuery(searchQuery, config); err != nil {
+			x += runewidth.StringWidth(counter) + 2
+			printText(screen, x, y, "(invalid pattern: "+err.Error()+")", config.Theme.SearchBar)
+		}
+	}
+}
+
+// searchBarLine returns the line on which the search bar is displayed, or would be if the search were enabled
+func searchBarLine(screen tcell.Screen, question string, config *Config) int {
+	if config.SearchBarPosition == SearchBarTop && !config.WithoutSearch {
+		return questionHeight(question, config)
+	}
+	_, screenHeight := screen.Size()
+	return screenHeight - 1
+}
+
+// OptionSearchBarPosition sets where the search bar is displayed. The default is SearchBarBottom.
+func OptionSearchBarPosition(position SearchBarPosition) func(config *Config) {
+	return func(config *Config) {
+		config.SearchBarPosition = position
+	}
+}
+
+// OptionSearchPrompt replaces the text displayed before the search query, e.g. "> " or "🔎 "
+func OptionSearchPrompt(prompt string) func(config *Config) {
+	return func(config *Config) {
+		config.SearchPrompt = prompt
+	}
+}
+
+// OptionSearchPlaceholder displays the given text in the search bar while the search query is empty,
+// e.g. "type to filter…", using the Placeholder style of the theme
+func OptionSearchPlaceholder(placeholder string) func(config *Config) {
+	return func(config *Config) {
+		config.SearchPlaceholder = placeholder
+	}
+}
@@ -0,0 +1,78 @@
+package gochoice
+
+import (
+	"testing"
+)
+
+func TestRenderSearchBar(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		options       []Option
+		searchQuery   string
+		expectedLines map[int]string
+	}{
+		{
+			name:          "bottom",
+			expectedLines: map[int]string{1: " > A", 9: " Search: _  2/2"},
+		},
+		{
+			name:          "top",
+			options:       []Option{OptionSearchBarPosition(SearchBarTop)},
+			expectedLines: map[int]string{1: " Search: _  2/2", 2: " > A", 9: "          "},
+		},
+		{
+			name:          "prompt-and-placeholder",
+			options:       []Option{OptionSearchPrompt("> "), OptionSearchPlaceholder("type to filter…")},
+			expectedLines: map[int]string{9: " > _type to filter…  2/2"},
+		},
+		{
+			name:          "placeholder-hidden-by-query",
+			options:       []Option{OptionSearchPlaceholder("type to filter…")},
+			searchQuery:   "a",
+			expectedLines: map[int]string{9: " Search: a_  1/2"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := newConfig(scenario.options)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			screen.SetSize(40, 10)
+			choices := newChoices([]string{"A", "B"})
+			state := &promptState{}
+			options := filterChoices(choices, scenario.searchQuery, config, state)
+			render(screen, "question", options, config, state, choices[0], scenario.searchQuery, true, 0, choices)
+			screen.Show()
+			for y, expectedLine := range scenario.expectedLines {
+				var line []rune
+				for x := 0; len(line) < len([]rune(expectedLine)); x++ {
+					mainc, _, _, _ := screen.GetContent(x, y)
+					line = append(line, mainc)
+				}
+				if string(line) != expectedLine {
+					t.Errorf("expected line %d to be %q, got %q", y, expectedLine, string(line))
+				}
+			}
+		})
+	}
+}
+
+func TestRenderSearchBarPlaceholderStyle(t *testing.T) {
+	config := newConfig([]Option{OptionSearchPlaceholder("type to filter…")})
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(40, 10)
+	choices := newChoices([]string{"A", "B"})
+	render(screen, "question", choices, config, &promptState{}, choices[0], "", true, 0, choices)
+	screen.Show()
+	// The placeholder follows " Search: _"
+	if mainc, _, style, _ := screen.GetContent(10, 9); mainc != 't' || style != config.Theme.Placeholder {
+		t.Errorf("expected the placeholder to start at x=10 with the Placeholder style, got %q with %v", mainc, style)
+	}
+}
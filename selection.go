@@ -0,0 +1,202 @@
+package gochoice
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// minCheckedEntries is how many more outdated entries than current ones a checkedState keeps before leaving them out
+const minCheckedEntries = 64
+
+// checkChoice checks the choice, unless config.MaxSelections choices are already checked. In that case, the choice
+// that was checked first is unchecked to make room for it if config.EvictOldestSelection is true, otherwise the choice
+// is left unchecked. It returns whether the choice was checked.
+func checkChoice(choices []*Choice, choice *Choice, config *Config, state *promptState) bool {
+	return state.checkedState(choices).check(choice, config)
+}
+
+// toggleChoice unchecks the choice if it is checked, or else checks it like checkChoice.
+// It returns false if the choice had to be checked, but couldn't be.
+func toggleChoice(choices []*Choice, choice *Choice, config *Config, state *promptState) bool {
+	if choice.Checked {
+		state.checkedState(choices).uncheck(choice)
+		return true
+	}
+	return checkChoice(choices, choice, config, state)
+}
+
+// setChecked sets whether each of the visible choices that can be selected is checked, based on whether it is
+// currently checked. It returns false if some of them couldn't be checked because of config.MaxSelections.
+func setChecked(choices, visibleChoices []*Choice, checked func(bool) bool, config *Config, state *promptState) bool {
+	checks := state.checkedState(choices)
+	allChecked := true
+	for _, choice := range visibleChoices {
+		if !choice.selectable() || checked(choice.Checked) == choice.Checked {
+			continue
+		}
+		if choice.Checked {
+			checks.uncheck(choice)
+		} else if !checks.check(choice, config) {
+			allChecked = false
+		}
+	}
+	return allChecked
+}
+
+// checkedState returns the checked state of the choices, which only goes through them again once they have changed,
+// rather than every time a choice is checked. The choices must only be checked and unchecked through it.
+func (state *promptState) checkedState(choices []*Choice) *checkedState {
+	if state.checks == nil || !sameChoices(state.checks.choices, choices) {
+		state.checks = newCheckedState(choices)
+	}
+	return state.checks
+}
+
+// checkedState keeps track of the checked choices while they are checked or unchecked
+type checkedState struct {
+	choices []*Choice
+	// checked are the choices in the order in which they were checked, some of which may have been unchecked since
+	checked        []checkedEntry
+	count          int
+	lastCheckOrder int
+}
+
+// checkedEntry is a choice as it was checked, which is outdated once the choice is unchecked, even if it is checked
+// again afterwards
+type checkedEntry struct {
+	choice     *Choice
+	checkOrder int
+}
+
+// current reports whether the choice is still checked since it was checked as recorded by the entry
+func (entry checkedEntry) current() bool {
+	return entry.choice.Checked && entry.choice.checkOrder == entry.checkOrder
+}
+
+func newCheckedState(choices []*Choice) *checkedState {
+	state := &checkedState{choices: choices}
+	state.add(choices, choices)
+	return state
+}
+
+// add records the checked choices among the given ones, which were added at the end of the choices
+func (state *checkedState) add(choices, addedChoices []*Choice) {
+	state.choices = choices
+	first := len(state.checked)
+	for _, choice := range checkedChoices(addedChoices) {
+		state.checked = append(state.checked, checkedEntry{choice: choice, checkOrder: choice.checkOrder})
+		if choice.checkOrder > state.lastCheckOrder {
+			state.lastCheckOrder = choice.checkOrder
+		}
+	}
+	if len(state.checked) == first {
+		return
+	}
+	state.count += len(state.checked) - first
+	sort.SliceStable(state.checked, func(i, j int) bool {
+		return state.checked[i].checkOrder < state.checked[j].checkOrder
+	})
+}
+
+// check checks the choice like checkChoice and returns whether it was checked
+func (state *checkedState) check(choice *Choice, config *Config) bool {
+	if config.MaxSelections > 0 && state.count >= config.MaxSelections {
+		if !config.EvictOldestSelection {
+			return false
+		}
+		state.uncheckOldest()
+	}
+	state.lastCheckOrder++
+	choice.Checked, choice.checkOrder = true, state.lastCheckOrder
+	state.checked = append(state.checked, checkedEntry{choice: choice, checkOrder: choice.checkOrder})
+	state.count++
+	if len(state.checked) > 2*state.count+minCheckedEntries {
+		// Leave out the outdated entries once they outnumber the others, so that checking and unchecking the choices
+		// again and again doesn't record more and more of them
+		current := state.checked[:0]
+		for _, entry := range state.checked {
+			if entry.current() {
+				current = append(current, entry)
+			}
+		}
+		state.checked = current
+	}
+	return true
+}
+
+func (state *checkedState) uncheck(choice *Choice) {
+	choice.Checked = false
+	state.count--
+}
+
+// uncheckOldest unchecks the choice that was checked first among those still checked
+func (state *checkedState) uncheckOldest() {
+	for len(state.checked) > 0 {
+		oldest := state.checked[0]
+		state.checked = state.checked[1:]
+		if oldest.current() {
+			state.uncheck(oldest.choice)
+			return
+		}
+	}
+}
+
+// selectionLimitsMessage returns the message explaining why the checked choices can't be confirmed,
+// or an empty string if their number is within the limits set by OptionSelectionLimits
+func selectionLimitsMessage(choices []*Choice, config *Config) string {
+	if !config.multiSelect {
+		return ""
+	}
+	numberOfCheckedChoices := len(checkedChoices(choices))
+	if numberOfCheckedChoices < config.MinSelections {
+		return fmt.Sprintf("Select at least %d choices", config.MinSelections)
+	}
+	if config.MaxSelections > 0 && numberOfCheckedChoices > config.MaxSelections {
+		return maxSelectionsMessage(config)
+	}
+	return ""
+}
+
+// maxSelectionsMessage returns the message explaining that no more choices can be checked
+func maxSelectionsMessage(config *Config) string {
+	return fmt.Sprintf("Select at most %d choices", config.MaxSelections)
+}
+
+// renderStatusMessage renders the message at the right of the search bar, which is on the given line
+func renderStatusMessage(screen tcell.Screen, y int, message string, style tcell.Style) {
+	screenWidth, _ := screen.Size()
+	text := " ! " + message
+	x := screenWidth - runewidth.StringWidth(text) - 1
+	if x < 0 {
+		x = 0
+	}
+	printText(screen, x, y, text, style)
+}
+
+// OptionSelectionLimits makes PickMultiple refuse to confirm the selection unless at least min and at most max
+// choices are checked. Once max choices are checked, checking another one is refused too, unless
+// OptionEvictOldestSelection is used. A max of 0 means that there is no maximum.
+func OptionSelectionLimits(min, max int) func(config *Config) {
+	return func(config *Config) {
+		config.MinSelections, config.MaxSelections = min, max
+	}
+}
+
+// OptionEvictOldestSelection makes checking a choice when the maximum set by OptionSelectionLimits has been reached
+// uncheck the choice that was checked first, instead of being refused
+func OptionEvictOldestSelection() func(config *Config) {
+	return func(config *Config) {
+		config.EvictOldestSelection = true
+	}
+}
+
+// OptionBell rings the terminal bell whenever an action is refused, e.g. confirming a selection
+// that doesn't satisfy OptionSelectionLimits
+func OptionBell() func(config *Config) {
+	return func(config *Config) {
+		config.Bell = true
+	}
+}
@@ -0,0 +1,171 @@
+package gochoice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestCheckChoice(t *testing.T) {
+	scenarios := []struct {
+		name                 string
+		evictOldestSelection bool
+		expectedChecked      bool
+		expectedValues       string
+	}{
+		{
+			name:            "blocked",
+			expectedChecked: false,
+			expectedValues:  "a,b",
+		},
+		{
+			name:                 "evict-oldest",
+			evictOldestSelection: true,
+			expectedChecked:      true,
+			expectedValues:       "a,c",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionSelectionLimits(0, 2)(&config)
+			if scenario.evictOldestSelection {
+				OptionEvictOldestSelection()(&config)
+			}
+			choices := newChoices([]string{"a", "b", "c"})
+			state := &promptState{}
+			// b is checked before a, so b is the oldest selection
+			checkChoice(choices, choices[1], &config, state)
+			checkChoice(choices, choices[0], &config, state)
+			if checked := checkChoice(choices, choices[2], &config, state); checked != scenario.expectedChecked {
+				t.Errorf("expected checkChoice to return %v, got %v", scenario.expectedChecked, checked)
+			}
+			var values []string
+			for _, choice := range checkedChoices(choices) {
+				values = append(values, choice.Value)
+			}
+			if got := strings.Join(values, ","); got != scenario.expectedValues {
+				t.Errorf("expected %s to be checked, got %s", scenario.expectedValues, got)
+			}
+		})
+	}
+}
+
+func TestSetChecked(t *testing.T) {
+	scenarios := []struct {
+		name                 string
+		evictOldestSelection bool
+		expectedAllChecked   bool
+		expectedValues       string
+	}{
+		{
+			name:               "blocked",
+			expectedAllChecked: false,
+			expectedValues:     "a,b,c",
+		},
+		{
+			name:                 "evict-oldest",
+			evictOldestSelection: true,
+			expectedAllChecked:   true,
+			expectedValues:       "c,d,e",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			OptionSelectionLimits(0, 3)(&config)
+			if scenario.evictOldestSelection {
+				OptionEvictOldestSelection()(&config)
+			}
+			choices := newChoices([]string{"a", "b", "c", "d", "e"})
+			state := &promptState{}
+			// b is checked before a, so b is evicted before a to make room for d and e
+			checkChoice(choices, choices[1], &config, state)
+			checkChoice(choices, choices[0], &config, state)
+			if allChecked := setChecked(choices, choices, func(bool) bool { return true }, &config, state); allChecked != scenario.expectedAllChecked {
+				t.Errorf("expected setChecked to return %v, got %v", scenario.expectedAllChecked, allChecked)
+			}
+			var values []string
+			for _, choice := range checkedChoices(choices) {
+				values = append(values, choice.Value)
+			}
+			if got := strings.Join(values, ","); got != scenario.expectedValues {
+				t.Errorf("expected %s to be checked, got %s", scenario.expectedValues, got)
+			}
+		})
+	}
+}
+
+func TestCheckChoiceEvictsOldestSelectionCheckedAgain(t *testing.T) {
+	config := defaultConfig
+	OptionSelectionLimits(0, 2)(&config)
+	OptionEvictOldestSelection()(&config)
+	choices := newChoices([]string{"a", "b", "c"})
+	state := &promptState{}
+	checkChoice(choices, choices[0], &config, state)
+	checkChoice(choices, choices[1], &config, state)
+	// a is checked again after b, so b is now the oldest selection
+	toggleChoice(choices, choices[0], &config, state)
+	toggleChoice(choices, choices[0], &config, state)
+	checkChoice(choices, choices[2], &config, state)
+	var values []string
+	for _, choice := range checkedChoices(choices) {
+		values = append(values, choice.Value)
+	}
+	if got := strings.Join(values, ","); got != "a,c" {
+		t.Errorf("expected a,c to be checked, got %s", got)
+	}
+}
+
+func BenchmarkSetChecked(b *testing.B) {
+	config := defaultConfig
+	config.multiSelect = true
+	choices := newChoices(newBenchmarkValues())
+	state := &promptState{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Inverting the selection checks all the choices, then unchecks them
+		setChecked(choices, choices, func(checked bool) bool { return !checked }, &config, state)
+	}
+}
+
+func TestPickMultipleWithSelectionLimits(t *testing.T) {
+	config := defaultConfig
+	OptionSelectionLimits(2, 2)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(60, 10)
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	type result struct {
+		values []string
+		err    error
+	}
+	results := make(chan result)
+	go func() {
+		values, _, err := pickMultiple("question", []string{"a", "b", "c"}, screen, &config)
+		results <- result{values, err}
+	}()
+	// Confirming a single checked choice is refused
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	waitForText(t, screen, "Select at least 2 choices")
+	// Checking a third choice is refused
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	waitForText(t, screen, "Select at most 2 choices")
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	r := <-results
+	if r.err != nil {
+		t.Fatal(r.err.Error())
+	}
+	if strings.Join(r.values, ",") != "a,b" {
+		t.Error("expected [a b], got", r.values)
+	}
+}
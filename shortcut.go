@@ -0,0 +1,148 @@
+package gochoice
+
+import (
+	"strconv"
+	"unicode"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// numberOfShortcuts is the number of choices that can be picked with a digit key, the tenth one being picked with 0
+const numberOfShortcuts = 10
+
+// assignShortcuts numbers the first choices displayed from the given scroll offset, leaving out the headers and
+// the choices that don't match the search query, so that they can be picked by pressing the key of their number
+func assignShortcuts(options []*Choice, scrollOffset int) {
+	number := 1
+	for i, option := range options {
+		option.shortcut = 0
+		if i >= scrollOffset && !option.header && !option.filtered && number <= numberOfShortcuts {
+			option.shortcut = number
+			number++
+		}
+	}
+}
+
+// shortcutLabel returns the text displayed before the value of a choice with the given shortcut,
+// which is as wide for every choice so that they stay aligned
+func shortcutLabel(shortcut int) string {
+	if shortcut == 0 {
+		return "   "
+	}
+	return strconv.Itoa(shortcut%numberOfShortcuts) + ". "
+}
+
+// shortcutNumber returns the number of the choice to pick for the key pressed, or 0 if the key is not a shortcut.
+// Digits are typed in the search query rather than used as shortcuts once the user has started typing it.
+func shortcutNumber(ev *tcell.EventKey, searchQuery string, searching bool, config *Config) int {
+	if !config.NumberShortcuts || ev.Key() != tcell.KeyRune || ev.Rune() < '0' || ev.Rune() > '9' {
+		return 0
+	}
+	// With vim bindings, the user presses the search key before typing the search query
+	if searching && (config.VimBindings || len(searchQuery) > 0) {
+		return 0
+	}
+	if ev.Rune() == '0' {
+		return numberOfShortcuts
+	}
+	return int(ev.Rune() - '0')
+}
+
+// shortcutChoice returns the choice displayed with the given shortcut, or nil if no choice displayed has it
+func shortcutChoice(choicesByLine []*Choice, shortcut int) *Choice {
+	for _, choice := range choicesByLine {
+		if choice != nil && choice.shortcut == shortcut {
+			return choice
+		}
+	}
+	return nil
+}
+
+// hotkeyChoice returns the first choice that can be selected among the given ones whose hotkey is the given rune,
+// ignoring case, or nil if there is none
+func hotkeyChoice(choices []*Choice, hotkey rune) *Choice {
+	for _, choice := range choices {
+		if choice.Hotkey != 0 && unicode.ToLower(choice.Hotkey) == unicode.ToLower(hotkey) && choice.selectable() {
+			return choice
+		}
+	}
+	return nil
+}
+
+// keyShortcutChoice returns the choice to pick for the key pressed, and whether the key is a shortcut at all.
+// Digits pick the choices displayed with OptionNumberShortcuts, while Alt with a rune, or the rune alone when
+// the search query isn't being typed, picks the choice with that rune as its hotkey.
+// The choice returned is nil if no choice has the shortcut of the key.
+func keyShortcutChoice(ev *tcell.EventKey, visibleChoices, choicesByLine []*Choice, searchQuery string, searching bool, config *Config) (*Choice, bool) {
+	if shortcut := shortcutNumber(ev, searchQuery, searching, config); shortcut > 0 {
+		return shortcutChoice(choicesByLine, shortcut), true
+	}
+	if ev.Key() != tcell.KeyRune || !hasHotkeys(visibleChoices) {
+		return nil, false
+	}
+	if ev.Modifiers()&tcell.ModAlt != 0 {
+		return hotkeyChoice(visibleChoices, ev.Rune()), true
+	}
+	if !searching {
+		// Runes that aren't hotkeys move the cursor to the next choice starting with them instead, see jumpToPrefix
+		if choice := hotkeyChoice(visibleChoices, ev.Rune()); choice != nil {
+			return choice, true
+		}
+	}
+	return nil, false
+}
+
+// hasHotkeys reports whether any of the given choices has a hotkey
+func hasHotkeys(choices []*Choice) bool {
+	for _, choice := range choices {
+		if choice.Hotkey != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// jumpToPrefix returns the first choice that can be selected after the selected choice whose value starts with
+// the given rune, ignoring case, going back to the first choice after the last one so that pressing the same rune
+// repeatedly cycles through the choices starting with it. It returns nil if no choice starts with the rune.
+func jumpToPrefix(choices []*Choice, selectedChoice *Choice, r rune) *Choice {
+	start := 0
+	if i := indexOf(choices, selectedChoice); len(choices) > 0 && choices[i] == selectedChoice {
+		start = i + 1
+	}
+	for i := range choices {
+		choice := choices[(start+i)%len(choices)]
+		if !choice.selectable() {
+			continue
+		}
+		for _, firstRune := range choice.Value {
+			if unicode.ToLower(firstRune) == unicode.ToLower(r) {
+				return choice
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// underlineHotkey underlines the first occurrence of the hotkey in the text displayed at (x, y), ignoring case
+func underlineHotkey(screen tcell.Screen, x, y int, text string, hotkey rune) {
+	for _, r := range text {
+		if unicode.ToLower(r) == unicode.ToLower(hotkey) {
+			mainc, combc, style, _ := screen.GetContent(x, y)
+			screen.SetContent(x, y, mainc, combc, style.Underline(true))
+			return
+		}
+		x += runewidth.RuneWidth(r)
+	}
+}
+
+// OptionNumberShortcuts numbers the first ten choices displayed from 1 to 9, then 0, and makes pressing the key
+// of their number pick them immediately, or check them with PickMultiple. Once the search query isn't empty anymore,
+// digits are typed in it instead.
+func OptionNumberShortcuts() func(config *Config) {
+	return func(config *Config) {
+		config.NumberShortcuts = true
+	}
+}
@@ -0,0 +1,204 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickWithNumberShortcuts(t *testing.T) {
+	config := defaultConfig
+	OptionNumberShortcuts()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	screen.InjectKey(tcell.KeyRune, '3', tcell.ModNone)
+	choice, index, err := pick("question", []string{"A", "B", "C", "D"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" || index != 2 {
+		t.Errorf("expected C at index 2, got %s at index %d", choice, index)
+	}
+	// The number of each choice is displayed after the selection marker
+	var line []rune
+	for x := 0; x < 8; x++ {
+		mainc, _, _, _ := screen.GetContent(x, 2)
+		line = append(line, mainc)
+	}
+	if string(line) != "   2. B " {
+		t.Errorf("expected the second choice to be numbered, got %q", string(line))
+	}
+}
+
+func TestPickWithNumberShortcutsAfterTypingSearchQuery(t *testing.T) {
+	config := defaultConfig
+	OptionNumberShortcuts()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	// Once the search query isn't empty, digits are typed in it
+	screen.InjectKeyBytes([]byte("v2"))
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"v1", "v2", "v3"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "v2" {
+		t.Error("expected v2, got", choice)
+	}
+}
+
+func TestPickMultipleWithNumberShortcuts(t *testing.T) {
+	config := defaultConfig
+	OptionNumberShortcuts()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	screen.InjectKey(tcell.KeyRune, '1', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, '3', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	values, _, err := pickMultiple("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if len(values) != 2 || values[0] != "A" || values[1] != "C" {
+		t.Error("expected [A C], got", values)
+	}
+}
+
+func TestAssignShortcuts(t *testing.T) {
+	values := make([]string, 15)
+	for i := range values {
+		values[i] = string(rune('a' + i))
+	}
+	choices := newChoices(values)
+	assignShortcuts(choices, 2)
+	scenarios := []struct {
+		index         int
+		expectedLabel string
+	}{
+		{index: 1, expectedLabel: "   "},
+		{index: 2, expectedLabel: "1. "},
+		{index: 10, expectedLabel: "9. "},
+		{index: 11, expectedLabel: "0. "},
+		{index: 12, expectedLabel: "   "},
+	}
+	for _, scenario := range scenarios {
+		if label := shortcutLabel(choices[scenario.index].shortcut); label != scenario.expectedLabel {
+			t.Errorf("expected choice %d to have label %q, got %q", scenario.index, scenario.expectedLabel, label)
+		}
+	}
+}
+
+func TestPickRichWithHotkey(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	screen.InjectKey(tcell.KeyRune, 'd', tcell.ModAlt)
+	items := []Item{{Label: "Open", Hotkey: 'o'}, {Label: "Save", Hotkey: 's'}, {Label: "Delete", Hotkey: 'D'}}
+	item, index, err := pickRich("question", items, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "Delete" || index != 2 {
+		t.Errorf("expected Delete at index 2, got %s at index %d", item.Label, index)
+	}
+	// The hotkey is underlined in the label
+	if _, _, style, _ := screen.GetContent(3, 3); !hasAttribute(style, tcell.AttrUnderline) {
+		t.Error("expected the hotkey of Delete to be underlined")
+	}
+	if _, _, style, _ := screen.GetContent(4, 3); hasAttribute(style, tcell.AttrUnderline) {
+		t.Error("expected only the hotkey of Delete to be underlined")
+	}
+}
+
+func TestPickRichWithHotkeyWithoutAltWhenNotSearching(t *testing.T) {
+	config := defaultConfig
+	OptionVimBindings()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	screen.InjectKey(tcell.KeyRune, 's', tcell.ModNone)
+	items := []Item{{Label: "Open", Hotkey: 'o'}, {Label: "Save", Hotkey: 's'}}
+	item, _, err := pickRich("question", items, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if item.Label != "Save" {
+		t.Error("expected Save, got", item.Label)
+	}
+}
+
+func hasAttribute(style tcell.Style, attribute tcell.AttrMask) bool {
+	_, _, attributes := style.Decompose()
+	return attributes&attribute != 0
+}
+
+func TestJumpToPrefix(t *testing.T) {
+	choices := newChoicesFromItems([]Item{{Label: "apple"}, {Label: "Banana"}, {Label: "blueberry", Disabled: true}, {Label: "cherry"}, {Label: "blackberry"}})
+	scenarios := []struct {
+		name           string
+		selectedIndex  int
+		r              rune
+		expectedIndex  int
+		expectedChoice bool
+	}{
+		{name: "next-choice-ignoring-case", selectedIndex: 0, r: 'b', expectedIndex: 1, expectedChoice: true},
+		{name: "skips-disabled-choices", selectedIndex: 1, r: 'b', expectedIndex: 4, expectedChoice: true},
+		{name: "cycles-back-to-first-choice", selectedIndex: 4, r: 'b', expectedIndex: 1, expectedChoice: true},
+		{name: "selected-choice-is-the-only-match", selectedIndex: 3, r: 'c', expectedIndex: 3, expectedChoice: true},
+		{name: "no-match", selectedIndex: 0, r: 'z', expectedChoice: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			choice := jumpToPrefix(choices, choices[scenario.selectedIndex], scenario.r)
+			if !scenario.expectedChoice {
+				if choice != nil {
+					t.Errorf("expected no choice, got %s", choice.Value)
+				}
+				return
+			}
+			if choice != choices[scenario.expectedIndex] {
+				t.Errorf("expected %s, got %v", choices[scenario.expectedIndex].Value, choice)
+			}
+		})
+	}
+}
+
+func TestPickWithJumpToPrefix(t *testing.T) {
+	config := defaultConfig
+	OptionVimBindings()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	// The search query isn't being typed, so pressing a rune repeatedly cycles through the choices starting with it
+	screen.InjectKeyBytes([]byte("bb"))
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"apple", "banana", "cherry", "blackberry"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "blackberry" {
+		t.Error("expected blackberry, got", choice)
+	}
+}
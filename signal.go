@@ -0,0 +1,27 @@
+package gochoice
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// listenToSignals returns a channel receiving SIGINT and SIGTERM if OptionSignalHandling is used,
+// or nil otherwise, as well as a function to stop listening to them
+func listenToSignals(config *Config) (<-chan os.Signal, func()) {
+	if !config.SignalHandling {
+		return nil, func() {}
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	return signals, func() { signal.Stop(signals) }
+}
+
+// OptionSignalHandling makes the prompt abort with ErrAborted when the process receives SIGINT or SIGTERM,
+// instead of letting the signal terminate the process while the terminal is still in raw mode.
+// The screen is finalized before the error is returned, unless it was provided with PickWithScreen.
+func OptionSignalHandling() func(config *Config) {
+	return func(config *Config) {
+		config.SignalHandling = true
+	}
+}
@@ -0,0 +1,36 @@
+//go:build !windows
+
+package gochoice
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+)
+
+func TestPickWithSignalHandling(t *testing.T) {
+	for _, sig := range []os.Signal{os.Interrupt, syscall.SIGTERM} {
+		t.Run(sig.String(), func(t *testing.T) {
+			config := defaultConfig
+			OptionSignalHandling()(&config)
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			errs := make(chan error)
+			go func() {
+				_, _, err := pick("question", []string{"A", "B"}, screen, &config)
+				errs <- err
+			}()
+			waitForText(t, screen, "question")
+			if err := syscall.Kill(os.Getpid(), sig.(syscall.Signal)); err != nil {
+				t.Fatal(err.Error())
+			}
+			if err := <-errs; !errors.Is(err, ErrAborted) {
+				t.Error("expected ErrAborted, got", err)
+			}
+		})
+	}
+}
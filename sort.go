@@ -0,0 +1,106 @@
+package gochoice
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// SortAlphabetical orders the values alphabetically, ignoring case.
+// It is meant to be passed to OptionSort.
+func SortAlphabetical(a, b string) bool {
+	lowerA, lowerB := strings.ToLower(a), strings.ToLower(b)
+	if lowerA != lowerB {
+		return lowerA < lowerB
+	}
+	return a < b
+}
+
+// SortNatural orders the values alphabetically, ignoring case, except that the numbers they contain are compared
+// by their value, e.g. "file2" comes before "file10". It is meant to be passed to OptionSort.
+func SortNatural(a, b string) bool {
+	chunksA, chunksB := naturalChunks(a), naturalChunks(b)
+	for i := 0; i < len(chunksA) && i < len(chunksB); i++ {
+		chunkA, chunkB := chunksA[i], chunksB[i]
+		if isDigits(chunkA) && isDigits(chunkB) {
+			// Leading zeros don't change the value of the number, so the longest number is the largest
+			trimmedA, trimmedB := strings.TrimLeft(chunkA, "0"), strings.TrimLeft(chunkB, "0")
+			if len(trimmedA) != len(trimmedB) {
+				return len(trimmedA) < len(trimmedB)
+			}
+			if trimmedA != trimmedB {
+				return trimmedA < trimmedB
+			}
+			continue
+		}
+		if lowerA, lowerB := strings.ToLower(chunkA), strings.ToLower(chunkB); lowerA != lowerB {
+			return lowerA < lowerB
+		}
+	}
+	if len(chunksA) != len(chunksB) {
+		return len(chunksA) < len(chunksB)
+	}
+	return a < b
+}
+
+// SortByLength orders the values from the shortest to the longest, as displayed.
+// It is meant to be passed to OptionSort.
+func SortByLength(a, b string) bool {
+	return runewidth.StringWidth(a) < runewidth.StringWidth(b)
+}
+
+// naturalChunks splits the text into chunks that are either made only of digits or contain none
+func naturalChunks(text string) []string {
+	var chunks []string
+	start := 0
+	for i, r := range text {
+		if i > 0 && unicode.IsDigit(r) != isDigits(text[start:i]) {
+			chunks = append(chunks, text[start:i])
+			start = i
+		}
+	}
+	if start < len(text) {
+		chunks = append(chunks, text[start:])
+	}
+	return chunks
+}
+
+// isDigits reports whether the chunk starts with a digit, which, for a chunk returned by naturalChunks,
+// means that it is only made of digits
+func isDigits(chunk string) bool {
+	for _, r := range chunk {
+		return unicode.IsDigit(r)
+	}
+	return false
+}
+
+// sortChoices sorts the choices with the comparison function of OptionSort, if any, without moving them across
+// headers, so that each choice stays under the header of its group. Choices that compare equal keep their order,
+// and the choices of a tree aren't sorted, since children must stay under their parent.
+func sortChoices(choices []*Choice, config *Config) []*Choice {
+	if config.Sort == nil || config.tree {
+		return choices
+	}
+	start := 0
+	for end := 0; end <= len(choices); end++ {
+		if end == len(choices) || choices[end].header {
+			group := choices[start:end]
+			sort.SliceStable(group, func(i, j int) bool {
+				return config.Sort(group[i].Value, group[j].Value)
+			})
+			start = end + 1
+		}
+	}
+	return choices
+}
+
+// OptionSort sorts the choices before they are displayed with the given function, which reports whether the value a
+// comes before the value b, e.g. SortAlphabetical, SortNatural or SortByLength. Choices that compare equal keep their
+// order, and the index returned is still the index of the choice in the list passed to the prompt.
+func OptionSort(less func(a, b string) bool) func(config *Config) {
+	return func(config *Config) {
+		config.Sort = less
+	}
+}
@@ -0,0 +1,88 @@
+package gochoice
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestSortFunctions(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		less     func(a, b string) bool
+		values   []string
+		expected []string
+	}{
+		{
+			name:     "alphabetical",
+			less:     SortAlphabetical,
+			values:   []string{"banana", "Cherry", "apple", "Banana"},
+			expected: []string{"apple", "Banana", "banana", "Cherry"},
+		},
+		{
+			name:     "natural",
+			less:     SortNatural,
+			values:   []string{"file10", "file2", "File1", "file02b", "file", "v1.10", "v1.9"},
+			expected: []string{"file", "File1", "file2", "file02b", "file10", "v1.9", "v1.10"},
+		},
+		{
+			name:     "length",
+			less:     SortByLength,
+			values:   []string{"ccc", "a", "bb", "d"},
+			expected: []string{"a", "d", "bb", "ccc"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			values := append([]string(nil), scenario.values...)
+			sort.SliceStable(values, func(i, j int) bool {
+				return scenario.less(values[i], values[j])
+			})
+			if strings.Join(values, ",") != strings.Join(scenario.expected, ",") {
+				t.Errorf("expected %v, got %v", scenario.expected, values)
+			}
+		})
+	}
+}
+
+func TestSortChoicesWithinGroups(t *testing.T) {
+	config := defaultConfig
+	OptionSort(SortAlphabetical)(&config)
+	choices := sortChoices(newChoicesFromGroups([]Group{
+		{Name: "Fruits", Items: []Item{{Label: "pear"}, {Label: "apple"}}},
+		{Name: "Vegetables", Items: []Item{{Label: "leek"}, {Label: "carrot"}}},
+	}), &config)
+	var values []string
+	for _, choice := range choices {
+		values = append(values, choice.Value)
+	}
+	if expected := "Fruits,apple,pear,Vegetables,carrot,leek"; strings.Join(values, ",") != expected {
+		t.Errorf("expected %s, got %s", expected, strings.Join(values, ","))
+	}
+}
+
+func TestPickWithSort(t *testing.T) {
+	config := defaultConfig
+	OptionSort(SortNatural)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Errorf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := pick("question", []string{"node10", "node2", "node1"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "node2" {
+		t.Error("expected node2, got", choice)
+	}
+	if index != 1 {
+		t.Error("expected 1, got", index)
+	}
+}
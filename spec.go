@@ -0,0 +1,236 @@
+package gochoice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gdamore/tcell/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the format of a document read by PickFromSpec
+type Format int
+
+const (
+	// FormatJSON reads the document as JSON
+	FormatJSON Format = iota
+
+	// FormatYAML reads the document as YAML
+	FormatYAML
+)
+
+// Spec is a prompt defined by a document read by PickFromSpec, e.g. in YAML:
+//
+//	question: Where do you want to deploy?
+//	groups:
+//	  - name: Europe
+//	    items:
+//	      - label: Paris
+//	        value: eu-west-3
+//	        description: Closest to the office
+//	  - name: America
+//	    items:
+//	      - label: Ohio
+//	        value: us-east-2
+//	        style: {foreground: yellow, bold: true}
+//	options:
+//	  fuzzy: true
+//
+// The choices are either the items or the items of the groups, each group being displayed under a header.
+type Spec struct {
+	Question string      `json:"question" yaml:"question"`
+	Items    []SpecItem  `json:"items,omitempty" yaml:"items,omitempty"`
+	Groups   []SpecGroup `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Options  SpecOptions `json:"options,omitempty" yaml:"options,omitempty"`
+}
+
+// SpecGroup is a group of items of a Spec, displayed under a header with its name
+type SpecGroup struct {
+	Name  string     `json:"name" yaml:"name"`
+	Items []SpecItem `json:"items" yaml:"items"`
+}
+
+// SpecItem is an item of a Spec. Its value, which defaults to its label, is what the item stands for, e.g. an ID,
+// while its label is the text displayed.
+type SpecItem struct {
+	Label       string    `json:"label" yaml:"label"`
+	Value       string    `json:"value,omitempty" yaml:"value,omitempty"`
+	Description string    `json:"description,omitempty" yaml:"description,omitempty"`
+	Disabled    bool      `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	Dangerous   bool      `json:"dangerous,omitempty" yaml:"dangerous,omitempty"`
+	URL         string    `json:"url,omitempty" yaml:"url,omitempty"`
+	Style       SpecStyle `json:"style,omitempty" yaml:"style,omitempty"`
+}
+
+// SpecStyle is the style of the label of a SpecItem. Colors are either names, e.g. "red", or hex codes, e.g. "#ff0000".
+type SpecStyle struct {
+	Foreground string `json:"foreground,omitempty" yaml:"foreground,omitempty"`
+	Background string `json:"background,omitempty" yaml:"background,omitempty"`
+	Bold       bool   `json:"bold,omitempty" yaml:"bold,omitempty"`
+	Italic     bool   `json:"italic,omitempty" yaml:"italic,omitempty"`
+	Underline  bool   `json:"underline,omitempty" yaml:"underline,omitempty"`
+}
+
+// SpecOptions are the options of a Spec, each of which corresponds to an Option
+type SpecOptions struct {
+	// Default is the value of the item selected when the prompt opens, like OptionDefaultValue
+	Default string `json:"default,omitempty" yaml:"default,omitempty"`
+	// Query is the search query typed when the prompt opens, like OptionInitialQuery
+	Query string `json:"query,omitempty" yaml:"query,omitempty"`
+	// Placeholder is displayed in the search bar while the query is empty, like OptionSearchPlaceholder
+	Placeholder string `json:"placeholder,omitempty" yaml:"placeholder,omitempty"`
+	Header      string `json:"header,omitempty" yaml:"header,omitempty"`
+	Footer      string `json:"footer,omitempty" yaml:"footer,omitempty"`
+	PageSize    int    `json:"pageSize,omitempty" yaml:"pageSize,omitempty"`
+	Fuzzy       bool   `json:"fuzzy,omitempty" yaml:"fuzzy,omitempty"`
+	Wrap        bool   `json:"wrap,omitempty" yaml:"wrap,omitempty"`
+	Mouse       bool   `json:"mouse,omitempty" yaml:"mouse,omitempty"`
+}
+
+// PickFromSpec prompts the user to choose one of the items defined by the document read from the reader, which is
+// a Spec in the given format. The options given are applied after the options of the document, so they take
+// precedence over them. It returns the item selected and its index among the items of the document, counting the
+// items of the groups one after the other.
+func PickFromSpec(reader io.Reader, format Format, options ...Option) (SpecItem, int, error) {
+	spec, err := readSpec(reader, format)
+	if err != nil {
+		return SpecItem{}, 0, err
+	}
+	config := withoutCustomChoice(newConfig(append(spec.Options.options(), options...)))
+	return spec.toSpecItem(runPicker(context.Background(), spec.Question, spec.choices(), config))
+}
+
+func pickFromSpec(reader io.Reader, format Format, screen tcell.Screen, config *Config) (SpecItem, int, error) {
+	spec, err := readSpec(reader, format)
+	if err != nil {
+		return SpecItem{}, 0, err
+	}
+	for _, option := range spec.Options.options() {
+		option(config)
+	}
+	return spec.toSpecItem(pickChoices(context.Background(), spec.Question, spec.choices(), screen, withoutCustomChoice(config)))
+}
+
+// readSpec reads a Spec in the given format from the reader. Unknown fields are reported, as they are likely typos.
+func readSpec(reader io.Reader, format Format) (*Spec, error) {
+	var spec Spec
+	var err error
+	switch format {
+	case FormatJSON:
+		decoder := json.NewDecoder(reader)
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&spec)
+	case FormatYAML:
+		decoder := yaml.NewDecoder(reader)
+		decoder.KnownFields(true)
+		err = decoder.Decode(&spec)
+	default:
+		err = fmt.Errorf("unknown format %d", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	if len(spec.Items) > 0 && len(spec.Groups) > 0 {
+		return nil, errors.New("invalid spec: it can't have both items and groups")
+	}
+	return &spec, nil
+}
+
+// items returns the items of the spec, followed by the items of its groups
+func (spec *Spec) items() []SpecItem {
+	items := spec.Items
+	for _, group := range spec.Groups {
+		items = append(items, group.Items...)
+	}
+	return items
+}
+
+// choices creates the choices of the prompt defined by the spec, whose indices are the indices of the items
+// returned by items
+func (spec *Spec) choices() []*Choice {
+	if len(spec.Groups) == 0 {
+		return newChoicesFromItems(toItems(spec.Items))
+	}
+	groups := make([]Group, 0, len(spec.Groups))
+	for _, group := range spec.Groups {
+		groups = append(groups, Group{Name: group.Name, Items: toItems(group.Items)})
+	}
+	return newChoicesFromGroups(groups)
+}
+
+// toSpecItem returns the item of the spec corresponding to the first choice selected, and its index
+func (spec *Spec) toSpecItem(selectedChoices []*Choice, err error) (SpecItem, int, error) {
+	if err != nil {
+		return SpecItem{}, 0, err
+	}
+	item := spec.items()[selectedChoices[0].Id]
+	if len(item.Value) == 0 {
+		item.Value = item.Label
+	}
+	return item, selectedChoices[0].Id, nil
+}
+
+// toItems converts the items of a spec into the items displayed by the prompt
+func toItems(specItems []SpecItem) []Item {
+	items := make([]Item, 0, len(specItems))
+	for _, specItem := range specItems {
+		items = append(items, Item{
+			Label:       specItem.Label,
+			Value:       specItem.Value,
+			Description: specItem.Description,
+			Disabled:    specItem.Disabled,
+			Dangerous:   specItem.Dangerous,
+			URL:         specItem.URL,
+			Style:       specItem.Style.style(),
+		})
+	}
+	return items
+}
+
+// style returns the tcell.Style with the colors and the attributes that are set
+func (specStyle SpecStyle) style() tcell.Style {
+	style := tcell.StyleDefault
+	if len(specStyle.Foreground) > 0 {
+		style = style.Foreground(tcell.GetColor(specStyle.Foreground))
+	}
+	if len(specStyle.Background) > 0 {
+		style = style.Background(tcell.GetColor(specStyle.Background))
+	}
+	return style.Bold(specStyle.Bold).Italic(specStyle.Italic).Underline(specStyle.Underline)
+}
+
+// options returns the Option corresponding to each of the options that are set
+func (specOptions SpecOptions) options() []Option {
+	var options []Option
+	if len(specOptions.Default) > 0 {
+		options = append(options, OptionDefaultValue(specOptions.Default))
+	}
+	if len(specOptions.Query) > 0 {
+		options = append(options, OptionInitialQuery(specOptions.Query))
+	}
+	if len(specOptions.Placeholder) > 0 {
+		options = append(options, OptionSearchPlaceholder(specOptions.Placeholder))
+	}
+	if len(specOptions.Header) > 0 {
+		options = append(options, OptionHeader(specOptions.Header))
+	}
+	if len(specOptions.Footer) > 0 {
+		options = append(options, OptionFooter(specOptions.Footer))
+	}
+	if specOptions.PageSize > 0 {
+		options = append(options, OptionPageSize(specOptions.PageSize))
+	}
+	if specOptions.Fuzzy {
+		options = append(options, OptionFuzzySearch())
+	}
+	if specOptions.Wrap {
+		options = append(options, OptionWrap())
+	}
+	if specOptions.Mouse {
+		options = append(options, OptionMouse())
+	}
+	return options
+}
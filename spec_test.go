@@ -0,0 +1,126 @@
+package gochoice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+const testJSONSpec = `{
+	"question": "Where do you want to deploy?",
+	"items": [
+		{"label": "Paris", "value": "eu-west-3", "description": "Closest to the office"},
+		{"label": "Ohio", "style": {"foreground": "yellow", "bold": true}}
+	],
+	"options": {"default": "Ohio"}
+}`
+
+const testYAMLSpec = `
+question: Where do you want to deploy?
+groups:
+  - name: Europe
+    items:
+      - label: Paris
+        value: eu-west-3
+  - name: America
+    items:
+      - label: Ohio
+        value: us-east-2
+      - label: Oregon
+        value: us-west-2
+options:
+  query: o
+`
+
+func TestPickFromSpec(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		document      string
+		format        Format
+		keys          []tcell.Key
+		expectedItem  SpecItem
+		expectedIndex int
+	}{
+		{
+			name:          "json",
+			document:      testJSONSpec,
+			format:        FormatJSON,
+			keys:          []tcell.Key{tcell.KeyUp, tcell.KeyEnter},
+			expectedItem:  SpecItem{Label: "Paris", Value: "eu-west-3", Description: "Closest to the office"},
+			expectedIndex: 0,
+		},
+		{
+			// The default value is selected, and the value of an item defaults to its label
+			name:          "json-default",
+			document:      testJSONSpec,
+			format:        FormatJSON,
+			keys:          []tcell.Key{tcell.KeyEnter},
+			expectedItem:  SpecItem{Label: "Ohio", Value: "Ohio", Style: SpecStyle{Foreground: "yellow", Bold: true}},
+			expectedIndex: 1,
+		},
+		{
+			// The initial query filters out Paris, and the index counts the items of the groups one after the other
+			name:          "yaml",
+			document:      testYAMLSpec,
+			format:        FormatYAML,
+			keys:          []tcell.Key{tcell.KeyDown, tcell.KeyEnter},
+			expectedItem:  SpecItem{Label: "Oregon", Value: "us-west-2"},
+			expectedIndex: 2,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config := defaultConfig
+			screen, err := createSimulationScreen()
+			if err != nil {
+				t.Fatalf("encountered error while creating simulation screen: %v", err)
+			}
+			defer screen.Fini()
+			for _, key := range scenario.keys {
+				screen.InjectKey(key, 0, tcell.ModNone)
+			}
+			item, index, err := pickFromSpec(strings.NewReader(scenario.document), scenario.format, screen, &config)
+			if err != nil {
+				t.Fatal(err.Error())
+			}
+			if item != scenario.expectedItem {
+				t.Errorf("expected %+v, got %+v", scenario.expectedItem, item)
+			}
+			if index != scenario.expectedIndex {
+				t.Errorf("expected %d, got %d", scenario.expectedIndex, index)
+			}
+		})
+	}
+}
+
+func TestReadSpecWithInvalidDocument(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		document string
+		format   Format
+	}{
+		{name: "unknown-json-field", document: `{"question": "?", "itemz": []}`, format: FormatJSON},
+		{name: "unknown-yaml-field", document: "question: '?'\nitemz: []\n", format: FormatYAML},
+		{name: "items-and-groups", document: `{"items": [{"label": "a"}], "groups": [{"name": "b"}]}`, format: FormatJSON},
+		{name: "unknown-format", document: `{}`, format: Format(42)},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if _, err := readSpec(strings.NewReader(scenario.document), scenario.format); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestSpecStyle(t *testing.T) {
+	style := SpecStyle{Foreground: "#ff0000", Background: "black", Underline: true}.style()
+	foreground, background, attributes := style.Decompose()
+	if foreground != tcell.NewHexColor(0xff0000) || background != tcell.ColorBlack {
+		t.Errorf("expected a red foreground on a black background, got %v on %v", foreground, background)
+	}
+	if attributes&tcell.AttrUnderline == 0 || attributes&tcell.AttrBold != 0 {
+		t.Error("expected the style to only be underlined")
+	}
+}
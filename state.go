@@ -0,0 +1,99 @@
+package gochoice
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+// PickerState remembers, for each question, the choice that was selected and the search query that was typed
+// when the prompt was last closed, so that prompts asking the same question reopen where the user left off.
+// It is safe for concurrent use.
+type PickerState struct {
+	mutex   sync.Mutex
+	entries map[string]pickerStateEntry
+	// path is the file the state is saved to whenever it changes, if any
+	path string
+}
+
+// pickerStateEntry is the state of the prompt asking a question
+type pickerStateEntry struct {
+	Value string `json:"value"`
+	Index int    `json:"index"`
+	Query string `json:"query,omitempty"`
+}
+
+// NewPickerState creates an empty PickerState, kept in memory only
+func NewPickerState() *PickerState {
+	return &PickerState{entries: make(map[string]pickerStateEntry)}
+}
+
+// LoadPickerState creates a PickerState from the file at the given path, which is created if it doesn't exist.
+// The state is saved to that file whenever a prompt using it is closed.
+func LoadPickerState(path string) (*PickerState, error) {
+	state := NewPickerState()
+	state.path = path
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &state.entries); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// restore configures the prompt asking the given question to open where it was left off
+func (state *PickerState) restore(question string, config *Config) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	entry, ok := state.entries[question]
+	if !ok {
+		return
+	}
+	config.DefaultIndex, config.DefaultValue = entry.Index, entry.Value
+	config.InitialQuery = entry.Query
+}
+
+// save remembers the choice selected and the search query of the prompt asking the given question,
+// and writes the state to its file, if any. Failing to write the file is not worth failing the prompt over,
+// so the error is ignored.
+func (state *PickerState) save(question string, selectedChoice *Choice, query string) {
+	state.mutex.Lock()
+	defer state.mutex.Unlock()
+	entry := pickerStateEntry{Query: query}
+	if selectedChoice != nil {
+		entry.Value, entry.Index = selectedChoice.Value, selectedChoice.Id
+	}
+	state.entries[question] = entry
+	if len(state.path) == 0 {
+		return
+	}
+	if data, err := json.MarshalIndent(state.entries, "", "  "); err == nil {
+		_ = os.WriteFile(state.path, data, 0600)
+	}
+}
+
+// OptionState makes the prompt reopen with the choice that was selected and the search query that was typed
+// when a prompt asking the same question was last closed
+func OptionState(state *PickerState) func(config *Config) {
+	return func(config *Config) {
+		config.State = state
+	}
+}
+
+// OptionStateFile is like OptionState, but the state is loaded from and saved to the file at the given path.
+// If the file can't be read, the prompt opens as if it had never been opened before.
+func OptionStateFile(path string) func(config *Config) {
+	return func(config *Config) {
+		state, err := LoadPickerState(path)
+		if err != nil {
+			state = NewPickerState()
+			state.path = path
+		}
+		config.State = state
+	}
+}
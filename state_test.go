@@ -0,0 +1,57 @@
+package gochoice
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickWithState(t *testing.T) {
+	state := NewPickerState()
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	choices := []string{"apple", "banana", "blueberry"}
+	screen.InjectKey(tcell.KeyRune, 'b', tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	if _, _, err := pick("question", choices, screen, newConfig([]Option{OptionState(state)})); !errors.Is(err, ErrAborted) {
+		t.Fatal("expected ErrAborted, got", err)
+	}
+	// The prompt reopens with the same search query and the same choice selected
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, index, err := pick("question", choices, screen, newConfig([]Option{OptionState(state)}))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "blueberry" || index != 2 {
+		t.Errorf("expected blueberry at index 2, got %s at index %d", choice, index)
+	}
+	// Prompts asking other questions are not affected
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	if choice, _, _ := pick("other question", choices, screen, newConfig([]Option{OptionState(state)})); choice != "apple" {
+		t.Error("expected apple, got", choice)
+	}
+}
+
+func TestLoadPickerState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	state, err := LoadPickerState(path)
+	if err != nil {
+		t.Fatal("expected no error for a file that doesn't exist, got", err)
+	}
+	state.save("question", &Choice{Id: 1, Value: "B"}, "b")
+	state, err = LoadPickerState(path)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	config := newConfig(nil)
+	state.restore("question", config)
+	if config.DefaultIndex != 1 || config.DefaultValue != "B" || config.InitialQuery != "b" {
+		t.Errorf("expected B at index 1 with query b, got %s at index %d with query %s", config.DefaultValue, config.DefaultIndex, config.InitialQuery)
+	}
+}
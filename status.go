@@ -0,0 +1,28 @@
+package gochoice
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// statusText returns the text of the status line: the reason why the last action was refused if there is one,
+// or else the status set with Picker.SetStatus
+func statusText(statusMessage string, config *Config) string {
+	if len(statusMessage) == 0 && config.status != nil {
+		return config.status()
+	}
+	return statusMessage
+}
+
+// renderStatusLine renders the text on the line reserved with OptionStatusLine, which is the last line of the footer
+func renderStatusLine(screen tcell.Screen, text string, style tcell.Style, config *Config) {
+	_, screenHeight := screen.Size()
+	printText(screen, 0, screenHeight-config.bottomSearchBarHeight()-1, " "+text, style)
+}
+
+// OptionStatusLine reserves a line above the search bar for messages, such as the status set with Picker.SetStatus
+// and the reason why the last action was refused, e.g. because too many choices are checked
+func OptionStatusLine() func(config *Config) {
+	return func(config *Config) {
+		config.StatusLine = true
+	}
+}
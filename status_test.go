@@ -0,0 +1,68 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickerSetStatusWhileOpen(t *testing.T) {
+	config := defaultConfig
+	OptionStatusLine()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	picker := New("question", []string{"A", "B", "C"})
+	go func() {
+		waitForText(t, screen, "question")
+		picker.SetStatus("Reloading")
+		waitForText(t, screen, "Reloading")
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}()
+	choice, _, err := picker.run(screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "A" {
+		t.Error("expected A, got", choice)
+	}
+	// The status line is right above the search bar
+	if mainc, _, _, _ := screen.GetContent(1, 8); mainc != 'R' {
+		t.Errorf("expected the status to be displayed above the search bar, got %q", mainc)
+	}
+	if status := picker.Status(); status != "Reloading" {
+		t.Errorf("expected the status to be Reloading, got %q", status)
+	}
+}
+
+func TestStatusText(t *testing.T) {
+	config := defaultConfig
+	config.status = func() string {
+		return "3 items loaded"
+	}
+	if text := statusText("", &config); text != "3 items loaded" {
+		t.Errorf("expected the status of the picker, got %q", text)
+	}
+	// The reason why an action was refused takes precedence over the status
+	if text := statusText("Select at most 2 choices", &config); text != "Select at most 2 choices" {
+		t.Errorf("expected the reason why the action was refused, got %q", text)
+	}
+}
+
+func TestComputePageSizeWithStatusLine(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(20, 10)
+	pageSize := computePageSize(screen, "question", &config)
+	OptionStatusLine()(&config)
+	if pageSizeWithStatusLine := computePageSize(screen, "question", &config); pageSizeWithStatusLine != pageSize-1 {
+		t.Errorf("expected the status line to take one line, got a page size of %d instead of %d", pageSizeWithStatusLine, pageSize)
+	}
+}
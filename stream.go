@@ -0,0 +1,160 @@
+package gochoice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// spinnerInterval is the delay between two frames of the spinner displayed while choices are loading
+const spinnerInterval = 100 * time.Millisecond
+
+// spinnerFrames are the runes displayed one after the other by the spinner
+var spinnerFrames = []rune{'|', '/', '-', '\\'}
+
+// choiceUpdate modifies the choices of a prompt that is already open and returns the resulting choices.
+// Updates are applied by the event loop, so they never run concurrently with the filtering or the navigation.
+// They must not change the choices they are given, but may append choices to them or return other choices,
+// so that only the choices appended are gone through, and nothing is if the choices are returned as they are.
+type choiceUpdate func(choices []*Choice) []*Choice
+
+// PickFromChannel is like Pick, but the choices are received from the given channel while the prompt is
+// already open. A spinner is displayed until the channel is closed, and the index returned is the order in
+// which the choice selected was received. If the channel is closed without sending any value, ErrAborted
+// is returned once the user aborts the prompt.
+func PickFromChannel(question string, ch <-chan string, options ...Option) (string, int, error) {
+	config := newConfig(options)
+	updates, stop := streamChoices(ch)
+	defer stop()
+	config.updates, config.loading = updates, true
+	return toValueAndIndex(runPicker(context.Background(), question, nil, config))
+}
+
+func pickFromChannel(question string, ch <-chan string, screen tcell.Screen, config *Config) (string, int, error) {
+	updates, stop := streamChoices(ch)
+	defer stop()
+	config.updates, config.loading = updates, true
+	return toValueAndIndex(pickChoices(context.Background(), question, nil, screen, config))
+}
+
+// PickFromLoader is like Pick, but the choices are returned by the given function, which is called in the background
+// once the prompt is open. A spinner is displayed until it returns. If it fails, its error is displayed in place of
+// the choices, and it is returned once the user aborts the prompt.
+func PickFromLoader(question string, load func() ([]string, error), options ...Option) (string, int, error) {
+	config := newConfig(options)
+	updates, loadErrs, stop := loadChoices(load, config)
+	defer stop()
+	config.updates, config.loading = updates, true
+	selectedChoices, err := runPicker(context.Background(), question, nil, config)
+	return toValueAndIndex(selectedChoices, withLoadError(err, loadErrs))
+}
+
+func pickFromLoader(question string, load func() ([]string, error), screen tcell.Screen, config *Config) (string, int, error) {
+	updates, loadErrs, stop := loadChoices(load, config)
+	defer stop()
+	config.updates, config.loading = updates, true
+	selectedChoices, err := pickChoices(context.Background(), question, nil, screen, config)
+	return toValueAndIndex(selectedChoices, withLoadError(err, loadErrs))
+}
+
+// loadChoices returns a channel of updates adding a choice for each value returned by the given function, which is
+// closed once the function has returned, as well as a channel receiving the error of the function if it fails and
+// a function that stops waiting for it. If the function fails, the update displays its error in place of the choices.
+func loadChoices(load func() ([]string, error), config *Config) (<-chan choiceUpdate, <-chan error, func()) {
+	updates := make(chan choiceUpdate)
+	loadErrs := make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(updates)
+		values, err := load()
+		update := func(choices []*Choice) []*Choice {
+			for _, value := range values {
+				choices = appendChoice(value)(choices)
+			}
+			return choices
+		}
+		if err != nil {
+			loadErrs <- err
+			update = func(choices []*Choice) []*Choice {
+				config.EmptyMessage = fmt.Sprintf("Failed to load the choices: %v", err)
+				return choices
+			}
+		}
+		select {
+		case updates <- update:
+		case <-done:
+		}
+	}()
+	return updates, loadErrs, func() { close(done) }
+}
+
+// withLoadError returns the error received from loadErrs instead of the given error, if there is one,
+// since the prompt most likely failed because the choices failed to load, e.g. the user aborted it
+func withLoadError(err error, loadErrs <-chan error) error {
+	if err == nil {
+		return nil
+	}
+	select {
+	case loadErr := <-loadErrs:
+		return loadErr
+	default:
+		return err
+	}
+}
+
+// streamChoices returns a channel of updates appending a choice for each value received from the given channel,
+// which is closed once the given channel is closed, as well as a function that stops receiving values
+func streamChoices(ch <-chan string) (<-chan choiceUpdate, func()) {
+	updates := make(chan choiceUpdate)
+	done := make(chan struct{})
+	go func() {
+		defer close(updates)
+		for {
+			select {
+			case value, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case updates <- appendChoice(value):
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return updates, func() { close(done) }
+}
+
+// appendChoice returns an update appending a choice with the given value
+func appendChoice(value string) choiceUpdate {
+	return func(choices []*Choice) []*Choice {
+		return append(choices, &Choice{Id: len(choices), Value: value})
+	}
+}
+
+// spinner returns the current frame of the spinner
+func (state *promptState) spinner() string {
+	return string(spinnerFrames[state.spinnerFrame%len(spinnerFrames)])
+}
+
+// renderSpinner renders the spinner at the end of the search bar, which is on the given line,
+// indicating that more choices are still loading
+func renderSpinner(screen tcell.Screen, y int, config *Config, state *promptState) {
+	screenWidth, _ := screen.Size()
+	text := state.spinner() + " loading"
+	printText(screen, screenWidth-runewidth.StringWidth(text)-1, y, text, config.Theme.Spinner)
+}
+
+// OptionLoadingMessage replaces the message displayed in place of the choices until the first ones are loaded,
+// which defaults to "Loading…"
+func OptionLoadingMessage(message string) func(config *Config) {
+	return func(config *Config) {
+		config.LoadingMessage = message
+	}
+}
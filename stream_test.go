@@ -0,0 +1,127 @@
+package gochoice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickFromChannel(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	ch := make(chan string)
+	go func() {
+		for _, value := range []string{"A", "B", "C", "D"} {
+			ch <- value
+		}
+		// Once D has been received, the choice created for C has been added
+		SimulateKeys(screen, []tcell.Event{
+			tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+			tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+			tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		})
+	}()
+	choice, index, err := pickFromChannel("question", ch, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" || index != 2 {
+		t.Errorf("expected C at index 2, got %s at index %d", choice, index)
+	}
+}
+
+func TestPickFromChannelKeepsSearchQuery(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyRune, 'b', tcell.ModNone)
+	ch := make(chan string)
+	go func() {
+		for _, value := range []string{"abc", "xyz", "bcd", "end"} {
+			ch <- value
+		}
+		close(ch)
+		screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}()
+	choice, index, err := pickFromChannel("question", ch, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "bcd" || index != 2 {
+		t.Errorf("expected bcd at index 2, got %s at index %d", choice, index)
+	}
+}
+
+func TestPickFromClosedChannel(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	ch := make(chan string)
+	close(ch)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	if _, _, err = pickFromChannel("question", ch, screen, &config); !errors.Is(err, ErrAborted) {
+		t.Error("expected ErrAborted, got", err)
+	}
+}
+
+func TestPickFromLoader(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	loaded := make(chan struct{})
+	load := func() ([]string, error) {
+		<-loaded
+		return []string{"A", "B", "C"}, nil
+	}
+	go func() {
+		// The loading message is displayed in place of the choices until they are loaded
+		waitForText(t, screen, "Loading…")
+		close(loaded)
+		waitForText(t, screen, "B")
+		screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+		screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	}()
+	choice, index, err := pickFromLoader("question", load, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" || index != 1 {
+		t.Errorf("expected B at index 1, got %s at index %d", choice, index)
+	}
+}
+
+func TestPickFromLoaderWithError(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	loadErr := errors.New("connection refused")
+	load := func() ([]string, error) {
+		return nil, loadErr
+	}
+	go func() {
+		waitForText(t, screen, "Failed to load the choices: connection refused")
+		screen.InjectKey(tcell.KeyEscape, 0, tcell.ModNone)
+	}()
+	if _, _, err = pickFromLoader("question", load, screen, &config); !errors.Is(err, loadErr) {
+		t.Error("expected the error of the loader, got", err)
+	}
+}
@@ -0,0 +1,93 @@
+package gochoice
+
+import "github.com/gdamore/tcell/v2"
+
+// Style is a set of text attributes, which are added to those of the style they are applied to
+type Style struct {
+	Bold      bool
+	Italic    bool
+	Underline bool
+	Reverse   bool
+	Dim       bool
+	Blink     bool
+}
+
+// applyTo returns the given style with the attributes of the Style added to it
+func (style Style) applyTo(tcellStyle tcell.Style) tcell.Style {
+	if style.Bold {
+		tcellStyle = tcellStyle.Bold(true)
+	}
+	if style.Italic {
+		tcellStyle = tcellStyle.Italic(true)
+	}
+	if style.Underline {
+		tcellStyle = tcellStyle.Underline(true)
+	}
+	if style.Reverse {
+		tcellStyle = tcellStyle.Reverse(true)
+	}
+	if style.Dim {
+		tcellStyle = tcellStyle.Dim(true)
+	}
+	if style.Blink {
+		tcellStyle = tcellStyle.Blink(true)
+	}
+	return tcellStyle
+}
+
+// choiceStyle returns the style of the value of the choice. The style of the choice, and then the one returned by
+// Config.ItemStyle, are applied on top of Theme.Item, unless the choice is selected or disabled.
+func choiceStyle(choice *Choice, config *Config, state *promptState) tcell.Style {
+	if choice.Selected && state.reverseSelected {
+		return config.Theme.Selected.Reverse(true)
+	}
+	if choice.Selected {
+		return config.Theme.Selected
+	}
+	if choice.Disabled || choice.filtered {
+		return config.Theme.Disabled
+	}
+	style := overrideStyle(config.Theme.Item, choice.Style)
+	if config.ItemStyle != nil && !choice.custom {
+		style = overrideStyle(style, config.ItemStyle(choice.Id, choice.Value))
+	}
+	return style
+}
+
+// overrideStyle returns the base style with the colors set in the override style, and the attributes of both
+func overrideStyle(base, override tcell.Style) tcell.Style {
+	foreground, background, attributes := override.Decompose()
+	if foreground != tcell.ColorDefault {
+		base = base.Foreground(foreground)
+	}
+	if background != tcell.ColorDefault {
+		base = base.Background(background)
+	}
+	_, _, baseAttributes := base.Decompose()
+	return base.Attributes(baseAttributes | attributes)
+}
+
+// OptionItemStyle applies the style returned by the given function for each choice on top of the style of the theme,
+// e.g. to display choices that delete something in red. Only the colors and attributes that are set are applied,
+// and the selected choice and the disabled choices keep the style of the theme.
+func OptionItemStyle(itemStyle func(index int, value string) tcell.Style) func(config *Config) {
+	return func(config *Config) {
+		config.ItemStyle = itemStyle
+	}
+}
+
+// OptionTextStyle adds the attributes of the given style to the choices that aren't selected,
+// e.g. OptionTextStyle(Style{Italic: true})
+func OptionTextStyle(style Style) func(config *Config) {
+	return func(config *Config) {
+		config.Theme.Item = style.applyTo(config.Theme.Item)
+	}
+}
+
+// OptionSelectedStyle adds the attributes of the given style to the selected choice,
+// e.g. OptionSelectedStyle(Style{Bold: true, Underline: true})
+func OptionSelectedStyle(style Style) func(config *Config) {
+	return func(config *Config) {
+		config.Theme.Selected = style.applyTo(config.Theme.Selected)
+	}
+}
@@ -0,0 +1,87 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestOverrideStyle(t *testing.T) {
+	base := tcell.StyleDefault.Foreground(tcell.ColorWhite).Background(tcell.ColorBlack).Bold(true)
+	style := overrideStyle(base, tcell.StyleDefault.Foreground(tcell.ColorRed).Underline(true))
+	expected := tcell.StyleDefault.Foreground(tcell.ColorRed).Background(tcell.ColorBlack).Bold(true).Underline(true)
+	if style != expected {
+		t.Errorf("expected %v, got %v", expected, style)
+	}
+}
+
+func TestRenderWithItemStyle(t *testing.T) {
+	config := defaultConfig
+	OptionItemStyle(func(index int, value string) tcell.Style {
+		if value == "delete" {
+			return tcell.StyleDefault.Foreground(tcell.ColorRed)
+		}
+		return tcell.StyleDefault
+	})(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(30, 5)
+	choices := newChoices([]string{"create", "delete"})
+	choices[0].Style = tcell.StyleDefault.Foreground(tcell.ColorGreen)
+	// Neither choice is selected, so that both are displayed with their own style
+	choices[0].Selected = false
+	render(screen, "question", choices, &config, &promptState{}, nil, "", true, 0, choices)
+	screen.Show()
+	scenarios := []struct {
+		y                  int
+		expectedForeground tcell.Color
+	}{
+		{y: 1, expectedForeground: tcell.ColorGreen},
+		{y: 2, expectedForeground: tcell.ColorRed},
+	}
+	for _, scenario := range scenarios {
+		_, _, style, _ := screen.GetContent(3, scenario.y)
+		if foreground, background, _ := style.Decompose(); foreground != scenario.expectedForeground {
+			t.Errorf("expected line %d to have foreground %v, got %v", scenario.y, scenario.expectedForeground, foreground)
+		} else if _, themeBackground, _ := config.Theme.Item.Decompose(); background != themeBackground {
+			t.Errorf("expected line %d to keep the background of the theme, got %v", scenario.y, background)
+		}
+	}
+}
+
+func TestChoiceStyleOfSelectedChoice(t *testing.T) {
+	config := defaultConfig
+	choice := &Choice{Value: "delete", Selected: true, Style: tcell.StyleDefault.Foreground(tcell.ColorRed)}
+	if style := choiceStyle(choice, &config, &promptState{}); style != config.Theme.Selected {
+		t.Errorf("expected the selected choice to have the style of the theme, got %v", style)
+	}
+}
+
+func TestOptionSelectedStyle(t *testing.T) {
+	config := defaultConfig
+	OptionSelectedStyle(Style{Italic: true, Underline: true, Reverse: true, Dim: true, Blink: true})(&config)
+	_, _, attributes := config.Theme.Selected.Decompose()
+	expected := tcell.AttrItalic | tcell.AttrUnderline | tcell.AttrReverse | tcell.AttrDim | tcell.AttrBlink
+	if attributes != expected {
+		t.Errorf("expected attributes %v, got %v", expected, attributes)
+	}
+	if _, _, itemAttributes := config.Theme.Item.Decompose(); itemAttributes != tcell.AttrNone {
+		t.Errorf("expected the other choices to have no attributes, got %v", itemAttributes)
+	}
+}
+
+func TestOptionTextStyle(t *testing.T) {
+	config := defaultConfig
+	OptionTextStyle(Style{Bold: true, Dim: true})(&config)
+	foreground, background, attributes := config.Theme.Item.Decompose()
+	if attributes != tcell.AttrBold|tcell.AttrDim {
+		t.Errorf("expected bold and dim attributes, got %v", attributes)
+	}
+	// The colors of the theme are kept
+	if themeForeground, themeBackground, _ := DefaultTheme().Item.Decompose(); foreground != themeForeground || background != themeBackground {
+		t.Errorf("expected the colors of the theme to be kept, got %v on %v", foreground, background)
+	}
+}
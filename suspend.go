@@ -0,0 +1,36 @@
+package gochoice
+
+import (
+	"errors"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// errSuspendNotSupported is the error returned when suspending the process isn't supported by the platform
+var errSuspendNotSupported = errors.New("suspending the process is not supported on this platform")
+
+// suspend restores the terminal and stops the process like Ctrl+Z does in a shell, then redraws the screen
+// once the process is resumed, e.g. with fg
+func suspend(screen tcell.Screen) error {
+	if !suspendSupported {
+		return errSuspendNotSupported
+	}
+	if err := screen.Suspend(); err != nil {
+		return err
+	}
+	err := suspendProcess()
+	if resumeErr := screen.Resume(); err == nil {
+		err = resumeErr
+	}
+	screen.Sync()
+	return err
+}
+
+// OptionSuspend makes the keys bound to KeyMap.Suspend, Ctrl+Z by default, suspend the process with job control
+// the way they do for other programs of the terminal. The prompt is displayed again once the process is resumed.
+// This has no effect on Windows.
+func OptionSuspend() func(config *Config) {
+	return func(config *Config) {
+		config.Suspend = true
+	}
+}
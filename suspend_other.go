@@ -0,0 +1,10 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+
+package gochoice
+
+const suspendSupported = false
+
+// suspendProcess is never called, since there is no job control on this platform
+var suspendProcess = func() error {
+	return errSuspendNotSupported
+}
@@ -0,0 +1,12 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package gochoice
+
+import "syscall"
+
+const suspendSupported = true
+
+// suspendProcess stops the process group of the process, and returns once it has been resumed
+var suspendProcess = func() error {
+	return syscall.Kill(0, syscall.SIGTSTP)
+}
@@ -0,0 +1,61 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickWithSuspend(t *testing.T) {
+	suspended := 0
+	defer func(original func() error) {
+		suspendProcess = original
+	}(suspendProcess)
+	suspendProcess = func() error {
+		suspended++
+		return nil
+	}
+	config := defaultConfig
+	OptionSuspend()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyCtrlZ, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	choice, _, err := pick("question", []string{"A", "B"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+	if suspended != 1 {
+		t.Errorf("expected the process to be suspended once, got %d", suspended)
+	}
+}
+
+func TestPickWithoutSuspend(t *testing.T) {
+	defer func(original func() error) {
+		suspendProcess = original
+	}(suspendProcess)
+	suspendProcess = func() error {
+		t.Error("expected the process not to be suspended without OptionSuspend")
+		return nil
+	}
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyCtrlZ, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	if _, _, err := pick("question", []string{"A", "B"}, screen, &config); err != nil {
+		t.Fatal(err.Error())
+	}
+}
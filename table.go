@@ -0,0 +1,66 @@
+package gochoice
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+)
+
+// tableColumnSeparator is the text displayed between two columns of a table
+const tableColumnSeparator = "  "
+
+// PickTable prompts the user to choose a row from a table, whose columns are aligned under a header row.
+// The search query matches the content of any column. It returns the row selected and its index.
+func PickTable(question string, headers []string, rows [][]string, options ...Option) ([]string, int, error) {
+	config := withoutCustomChoice(newConfig(options))
+	choices := newChoicesFromTable(headers, rows, config)
+	selectedChoices, err := runPicker(context.Background(), question, choices, config)
+	return toItemAndIndex(rows, selectedChoices, err)
+}
+
+func pickTable(question string, headers []string, rows [][]string, screen tcell.Screen, config *Config) ([]string, int, error) {
+	choices := newChoicesFromTable(headers, rows, withoutCustomChoice(config))
+	selectedChoices, err := pickChoices(context.Background(), question, choices, screen, config)
+	return toItemAndIndex(rows, selectedChoices, err)
+}
+
+// newChoicesFromTable creates a choice for each row, whose value is made of its cells padded to the width of
+// their column, and sets the header row of the configuration so that it is aligned with them
+func newChoicesFromTable(headers []string, rows [][]string, config *Config) []*Choice {
+	columnWidths := make([]int, len(headers))
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, cell := range row {
+			if i == len(columnWidths) {
+				columnWidths = append(columnWidths, 0)
+			}
+			if width := runewidth.StringWidth(cell); width > columnWidths[i] {
+				columnWidths[i] = width
+			}
+		}
+	}
+	config.columnHeader = alignColumns(headers, columnWidths)
+	choices := make([]*Choice, 0, len(rows))
+	for i, row := range rows {
+		choices = append(choices, &Choice{Id: i, Value: alignColumns(row, columnWidths), Data: row, Selected: i == 0})
+	}
+	return choices
+}
+
+// alignColumns joins the cells of a row, padding each of them to the width of its column.
+// Missing cells are left empty, and the last column isn't padded.
+func alignColumns(row []string, columnWidths []int) string {
+	cells := make([]string, len(columnWidths))
+	for i := range columnWidths {
+		var cell string
+		if i < len(row) {
+			cell = row[i]
+		}
+		if i < len(columnWidths)-1 {
+			cell = runewidth.FillRight(cell, columnWidths[i])
+		}
+		cells[i] = cell
+	}
+	return strings.TrimRight(strings.Join(cells, tableColumnSeparator), " ")
+}
@@ -0,0 +1,73 @@
+package gochoice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickTable(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	// "Up" is in the third column of the second row only
+	screen.InjectKeyBytes([]byte("up 2"))
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	row, index, err := pickTable("question", []string{"NAME", "IMAGE", "STATUS"}, [][]string{
+		{"web", "nginx", "Exited (0)"},
+		{"db", "postgres", "Up 2 hours"},
+	}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if index != 1 || row[0] != "db" {
+		t.Errorf("expected db at index 1, got %v at index %d", row, index)
+	}
+}
+
+func TestNewChoicesFromTable(t *testing.T) {
+	config := defaultConfig
+	choices := newChoicesFromTable([]string{"NAME", "IMAGE"}, [][]string{{"web", "nginx"}, {"database", "postgres", "extra"}, {"日本"}}, &config)
+	expectedValues := []string{
+		"web       nginx",
+		"database  postgres  extra",
+		"日本",
+	}
+	for i, expectedValue := range expectedValues {
+		if choices[i].Value != expectedValue {
+			t.Errorf("expected %q, got %q", expectedValue, choices[i].Value)
+		}
+	}
+	if config.columnHeader != "NAME      IMAGE" {
+		t.Errorf("expected header to be aligned with the rows, got %q", config.columnHeader)
+	}
+}
+
+func TestRenderTableHeader(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(30, 4)
+	choices := newChoicesFromTable([]string{"NAME"}, [][]string{{"a"}, {"b"}, {"c"}}, &config)
+	choicesByLine := render(screen, "question", choices, &config, &promptState{}, choices[0], "", true, 0, choices)
+	screen.Show()
+	cells, width, _ := screen.GetContents()
+	var line []rune
+	for _, cell := range cells[width : 2*width] {
+		line = append(line, cell.Runes...)
+	}
+	if strings.TrimSpace(string(line)) != "NAME" {
+		t.Errorf("expected header on the second line, got %q", string(line))
+	}
+	// The question, the header and the search bar leave a single line for the choices
+	if choicesByLine[2] != choices[0] || choicesByLine[3] != nil {
+		t.Error("expected only the first choice to be displayed, got", choicesByLine)
+	}
+}
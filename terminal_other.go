@@ -0,0 +1,20 @@
+//go:build !aix && !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris && !windows
+
+package gochoice
+
+import (
+	"errors"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// openTty fails, since tcell doesn't use a tcell.Tty on this platform
+func openTty() (tcell.Tty, error) {
+	return nil, errors.New("opening the terminal is not supported on this platform")
+}
+
+// hasTerminal reports whether the process has a terminal other than stdin and stdout to display the prompt on,
+// which is never the case on this platform
+func hasTerminal() bool {
+	return false
+}
@@ -0,0 +1,26 @@
+//go:build aix || darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package gochoice
+
+import (
+	"os"
+
+	"github.com/gdamore/tcell/v2"
+	"golang.org/x/term"
+)
+
+// openTty opens the terminal of the process
+func openTty() (tcell.Tty, error) {
+	return tcell.NewDevTty()
+}
+
+// hasTerminal reports whether the process has a terminal to display the prompt on, which tcell opens through
+// /dev/tty, even if stdin and stdout are redirected
+func hasTerminal() bool {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return false
+	}
+	defer tty.Close()
+	return term.IsTerminal(int(tty.Fd()))
+}
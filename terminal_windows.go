@@ -0,0 +1,24 @@
+package gochoice
+
+import (
+	"errors"
+	"os"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// openTty fails, since tcell uses the console API instead of a tcell.Tty on Windows
+func openTty() (tcell.Tty, error) {
+	return nil, errors.New("opening the terminal is not supported on this platform")
+}
+
+// hasTerminal reports whether the process has a console to display the prompt on, which tcell opens through
+// CONOUT$, even if stdin and stdout are redirected
+func hasTerminal() bool {
+	console, err := os.OpenFile("CONOUT$", os.O_RDWR, 0)
+	if err != nil {
+		return false
+	}
+	_ = console.Close()
+	return true
+}
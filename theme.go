@@ -0,0 +1,224 @@
+package gochoice
+
+import (
+	"github.com/gdamore/tcell/v2"
+)
+
+// Theme is the set of styles used to render the picker
+type Theme struct {
+	// Question is the style of the question
+	Question tcell.Style
+
+	// Item is the style of the choices that aren't selected
+	Item tcell.Style
+
+	// Selected is the style of the selected choice
+	Selected tcell.Style
+
+	// Match is the style of the characters matching the search query
+	Match tcell.Style
+
+	// Description is the style of the descriptions displayed next to choices
+	Description tcell.Style
+
+	// Disabled is the style of the choices that cannot be selected
+	Disabled tcell.Style
+
+	// Header is the style of the headers of groups
+	Header tcell.Style
+
+	// SearchBar is the style of the line displaying the search query
+	SearchBar tcell.Style
+
+	// Scrollbar is the style of the scrollbar displayed when there are more choices than lines,
+	// as well as of the line separating the choices from the preview and of the separators between choices
+	Scrollbar tcell.Style
+
+	// Preview is the style of the text of the preview pane
+	Preview tcell.Style
+
+	// HeaderBar is the style of the lines set with OptionHeader, displayed below the question
+	HeaderBar tcell.Style
+
+	// FooterBar is the style of the lines set with OptionFooter, displayed above the search bar
+	FooterBar tcell.Style
+
+	// Counter is the style of the number of choices matching the search query, displayed next to it
+	Counter tcell.Style
+
+	// Border is the style of the border drawn around the prompt with OptionBorder
+	Border tcell.Style
+
+	// LineNumber is the style of the numbers displayed before the choices with OptionLineNumbers
+	LineNumber tcell.Style
+
+	// Spinner is the style of the spinner displayed while the choices are loading
+	Spinner tcell.Style
+
+	// Placeholder is the style of the text set with OptionSearchPlaceholder, displayed while the search query is empty
+	Placeholder tcell.Style
+
+	// Warning is the style of the message asking to confirm the choice of an item marked as Dangerous
+	Warning tcell.Style
+
+	// Breadcrumb is the style of the steps set with OptionBreadcrumbs, displayed above the question
+	Breadcrumb tcell.Style
+
+	// Icons is the variant of the icons displayed before the items that have one, which defaults to IconsUnicode
+	Icons IconMode
+
+	// CheckedMarker and UncheckedMarker are displayed before the choices of PickMultiple that are checked and
+	// that aren't checked respectively. They default to "[x]" and "[ ]".
+	CheckedMarker   string
+	UncheckedMarker string
+}
+
+// DefaultTheme returns the Theme used unless another one is set with OptionTheme
+func DefaultTheme() Theme {
+	base := tcell.StyleDefault.Background(tcell.ColorBlack).Foreground(tcell.ColorWhite)
+	return Theme{
+		Question:    base,
+		Item:        base,
+		Selected:    base,
+		Match:       base.Foreground(tcell.ColorGreen),
+		Description: base.Foreground(tcell.ColorGray),
+		Disabled:    base.Foreground(tcell.ColorDarkGray),
+		Header:      base.Foreground(tcell.ColorLightCyan).Bold(true),
+		SearchBar:   base,
+		Scrollbar:   base,
+		Preview:     base,
+		HeaderBar:   base,
+		FooterBar:   base.Foreground(tcell.ColorGray),
+		Counter:     base.Foreground(tcell.ColorGray),
+		Border:      base.Foreground(tcell.ColorGray),
+		LineNumber:  base.Foreground(tcell.ColorGray),
+		Spinner:     base.Foreground(tcell.ColorLightCyan),
+		Placeholder: base.Foreground(tcell.ColorGray),
+		Warning:     base.Foreground(tcell.ColorYellow).Bold(true),
+		Breadcrumb:  base.Foreground(tcell.ColorGray),
+	}
+}
+
+// SolarizedTheme returns a Theme based on the dark variant of the Solarized palette
+func SolarizedTheme() Theme {
+	base := tcell.StyleDefault.Background(tcell.NewHexColor(0x002b36)).Foreground(tcell.NewHexColor(0x839496))
+	return Theme{
+		Question:    base.Foreground(tcell.NewHexColor(0x93a1a1)).Bold(true),
+		Item:        base,
+		Selected:    base.Background(tcell.NewHexColor(0x073642)).Foreground(tcell.NewHexColor(0xeee8d5)),
+		Match:       base.Foreground(tcell.NewHexColor(0xb58900)).Bold(true),
+		Description: base.Foreground(tcell.NewHexColor(0x586e75)),
+		Disabled:    base.Foreground(tcell.NewHexColor(0x586e75)),
+		Header:      base.Foreground(tcell.NewHexColor(0x268bd2)).Bold(true),
+		SearchBar:   base.Foreground(tcell.NewHexColor(0x2aa198)),
+		Scrollbar:   base.Foreground(tcell.NewHexColor(0x586e75)),
+		Preview:     base.Foreground(tcell.NewHexColor(0x93a1a1)),
+		HeaderBar:   base.Foreground(tcell.NewHexColor(0x93a1a1)),
+		FooterBar:   base.Foreground(tcell.NewHexColor(0x586e75)),
+		Counter:     base.Foreground(tcell.NewHexColor(0x586e75)),
+		Border:      base.Foreground(tcell.NewHexColor(0x586e75)),
+		LineNumber:  base.Foreground(tcell.NewHexColor(0x586e75)),
+		Spinner:     base.Foreground(tcell.NewHexColor(0x2aa198)),
+		Placeholder: base.Foreground(tcell.NewHexColor(0x586e75)),
+		Warning:     base.Foreground(tcell.NewHexColor(0xcb4b16)).Bold(true),
+		Breadcrumb:  base.Foreground(tcell.NewHexColor(0x586e75)),
+	}
+}
+
+// DraculaTheme returns a Theme based on the Dracula palette
+func DraculaTheme() Theme {
+	base := tcell.StyleDefault.Background(tcell.NewHexColor(0x282a36)).Foreground(tcell.NewHexColor(0xf8f8f2))
+	return Theme{
+		Question:    base.Foreground(tcell.NewHexColor(0xbd93f9)).Bold(true),
+		Item:        base,
+		Selected:    base.Background(tcell.NewHexColor(0x44475a)).Foreground(tcell.NewHexColor(0x50fa7b)),
+		Match:       base.Foreground(tcell.NewHexColor(0xff79c6)).Bold(true),
+		Description: base.Foreground(tcell.NewHexColor(0x6272a4)),
+		Disabled:    base.Foreground(tcell.NewHexColor(0x6272a4)),
+		Header:      base.Foreground(tcell.NewHexColor(0x8be9fd)).Bold(true),
+		SearchBar:   base.Foreground(tcell.NewHexColor(0xf1fa8c)),
+		Scrollbar:   base.Foreground(tcell.NewHexColor(0x6272a4)),
+		Preview:     base,
+		HeaderBar:   base,
+		FooterBar:   base.Foreground(tcell.NewHexColor(0x6272a4)),
+		Counter:     base.Foreground(tcell.NewHexColor(0x6272a4)),
+		Border:      base.Foreground(tcell.NewHexColor(0x6272a4)),
+		LineNumber:  base.Foreground(tcell.NewHexColor(0x6272a4)),
+		Spinner:     base.Foreground(tcell.NewHexColor(0xbd93f9)),
+		Placeholder: base.Foreground(tcell.NewHexColor(0x6272a4)),
+		Warning:     base.Foreground(tcell.NewHexColor(0xffb86c)).Bold(true),
+		Breadcrumb:  base.Foreground(tcell.NewHexColor(0x6272a4)),
+	}
+}
+
+// MonochromeTheme returns a Theme that only relies on text attributes, using the terminal's default colors
+func MonochromeTheme() Theme {
+	base := tcell.StyleDefault
+	return Theme{
+		Question:    base.Bold(true),
+		Item:        base,
+		Selected:    base.Reverse(true),
+		Match:       base.Underline(true),
+		Description: base.Dim(true),
+		Disabled:    base.Dim(true),
+		Header:      base.Bold(true).Underline(true),
+		SearchBar:   base,
+		Scrollbar:   base.Dim(true),
+		Preview:     base,
+		HeaderBar:   base,
+		FooterBar:   base.Dim(true),
+		Counter:     base.Dim(true),
+		Border:      base.Dim(true),
+		LineNumber:  base.Dim(true),
+		Spinner:     base.Bold(true),
+		Placeholder: base.Dim(true),
+		Warning:     base.Bold(true).Reverse(true),
+		Breadcrumb:  base.Dim(true),
+	}
+}
+
+// checkboxMarkers returns the markers displayed before the choices that are checked and that aren't checked
+func (theme Theme) checkboxMarkers() (string, string) {
+	checkedMarker, uncheckedMarker := theme.CheckedMarker, theme.UncheckedMarker
+	if len(checkedMarker) == 0 {
+		checkedMarker = defaultCheckedMarker
+	}
+	if len(uncheckedMarker) == 0 {
+		uncheckedMarker = defaultUncheckedMarker
+	}
+	return checkedMarker, uncheckedMarker
+}
+
+// OptionCheckboxMarkers sets the markers displayed before the choices of PickMultiple that are checked and that
+// aren't checked, e.g. "◉" and "◯", instead of "[x]" and "[ ]". Both markers should have the same width.
+func OptionCheckboxMarkers(checked, unchecked string) func(config *Config) {
+	return func(config *Config) {
+		config.Theme.CheckedMarker, config.Theme.UncheckedMarker = checked, unchecked
+	}
+}
+
+// styles returns pointers to all the styles of the theme
+func (theme *Theme) styles() []*tcell.Style {
+	return []*tcell.Style{&theme.Question, &theme.Item, &theme.Selected, &theme.Match, &theme.Description, &theme.Disabled, &theme.Header, &theme.SearchBar, &theme.Scrollbar, &theme.Preview, &theme.HeaderBar, &theme.FooterBar, &theme.Counter, &theme.Border, &theme.LineNumber, &theme.Spinner, &theme.Placeholder, &theme.Warning, &theme.Breadcrumb}
+}
+
+// withStylesOf returns the theme with the styles of the other theme. The markers and the icons aren't styles,
+// so they are kept.
+func (theme Theme) withStylesOf(other Theme) Theme {
+	other.Icons, other.CheckedMarker, other.UncheckedMarker = theme.Icons, theme.CheckedMarker, theme.UncheckedMarker
+	return other
+}
+
+// background returns the style used to fill the parts of the screen with nothing on it
+func (theme Theme) background() tcell.Style {
+	_, bg, _ := theme.Item.Decompose()
+	return tcell.StyleDefault.Background(bg)
+}
+
+// OptionTheme sets the theme used to render the picker.
+// Options that change colors override the theme if they are passed after this option.
+func OptionTheme(theme Theme) func(config *Config) {
+	return func(config *Config) {
+		config.Theme = theme
+	}
+}
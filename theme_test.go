@@ -0,0 +1,68 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestOptionTheme(t *testing.T) {
+	config := defaultConfig
+	OptionTheme(DraculaTheme())(&config)
+	if config.Theme != DraculaTheme() {
+		t.Error("expected the Dracula theme to be used")
+	}
+}
+
+func TestColorOptionsOverrideTheme(t *testing.T) {
+	config := defaultConfig
+	OptionTheme(SolarizedTheme())(&config)
+	OptionBackgroundColor(Black)(&config)
+	OptionTextColor(Red)(&config)
+	OptionSelectedTextColor(Green)(&config)
+	OptionSelectedTextBold()(&config)
+	OptionMatchTextColor(Yellow)(&config)
+	for name, style := range map[string]tcell.Style{"Question": config.Theme.Question, "Item": config.Theme.Item, "SearchBar": config.Theme.SearchBar, "Scrollbar": config.Theme.Scrollbar} {
+		if fg, bg, _ := style.Decompose(); fg != tcell.ColorRed || bg != tcell.ColorBlack {
+			t.Errorf("expected %s to be red on black, got %v on %v", name, fg, bg)
+		}
+	}
+	if fg, bg, attributes := config.Theme.Selected.Decompose(); fg != tcell.ColorGreen || bg != tcell.ColorBlack || attributes&tcell.AttrBold == 0 {
+		t.Errorf("expected Selected to be bold green on black, got %v on %v with attributes %v", fg, bg, attributes)
+	}
+	if fg, bg, _ := config.Theme.Match.Decompose(); fg != tcell.ColorYellow || bg != tcell.ColorBlack {
+		t.Errorf("expected Match to be yellow on black, got %v on %v", fg, bg)
+	}
+	// Styles that weren't overridden should keep the theme's foreground color
+	if fg, bg, _ := config.Theme.Header.Decompose(); fg != tcell.NewHexColor(0x268bd2) || bg != tcell.ColorBlack {
+		t.Errorf("expected Header to keep the theme's color on black, got %v on %v", fg, bg)
+	}
+}
+
+func TestThemeBackground(t *testing.T) {
+	if _, bg, _ := DraculaTheme().background().Decompose(); bg != tcell.NewHexColor(0x282a36) {
+		t.Errorf("expected the background of the Dracula theme, got %v", bg)
+	}
+	if MonochromeTheme().background() != tcell.StyleDefault {
+		t.Error("expected the monochrome theme to use the terminal's default background")
+	}
+}
+
+func TestChoicePrefixWithCheckboxMarkers(t *testing.T) {
+	config := defaultConfig
+	config.multiSelect = true
+	OptionCheckboxMarkers("◉", "◯")(&config)
+	choices := newChoices([]string{"A", "B"})
+	choices[0].Checked = true
+	if prefix := choicePrefix(choices[0], &config, &promptState{}); prefix != " > ◉ " {
+		t.Errorf("expected %q, got %q", " > ◉ ", prefix)
+	}
+	if prefix := choicePrefix(choices[1], &config, &promptState{}); prefix != "   ◯ " {
+		t.Errorf("expected %q, got %q", "   ◯ ", prefix)
+	}
+	// A theme set afterwards without markers uses the default ones
+	OptionTheme(DraculaTheme())(&config)
+	if prefix := choicePrefix(choices[0], &config, &promptState{}); prefix != " > [x] " {
+		t.Errorf("expected %q, got %q", " > [x] ", prefix)
+	}
+}
@@ -0,0 +1,66 @@
+package gochoice
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// OptionTimeout makes the prompt resolve automatically if the user hasn't pressed any key or mouse button
+// before the given duration has elapsed, like the boot menu of a bootloader. The choice whose index is
+// fallbackIndex is then returned or, if fallbackIndex is negative or doesn't match a choice that can be
+// selected, the choice selected at that time. With PickMultiple, the checked choices are returned instead.
+func OptionTimeout(duration time.Duration, fallbackIndex int) func(config *Config) {
+	return func(config *Config) {
+		config.Timeout = duration
+		config.TimeoutIndex = fallbackIndex
+	}
+}
+
+// OptionTimeoutCountdown displays the number of seconds left before the prompt set with OptionTimeout
+// resolves automatically at the end of the first line of the question
+func OptionTimeoutCountdown() func(config *Config) {
+	return func(config *Config) {
+		config.TimeoutCountdown = true
+	}
+}
+
+// timeoutChoices returns the choices to return once the timeout set with OptionTimeout has elapsed
+func timeoutChoices(choices []*Choice, selectedChoice *Choice, config *Config) ([]*Choice, error) {
+	if !config.multiSelect && config.TimeoutIndex >= 0 {
+		for _, choice := range choices {
+			if choice.Id == config.TimeoutIndex && !choice.Disabled && !choice.header {
+				return []*Choice{choice}, nil
+			}
+		}
+	}
+	if selectedChoice == nil && !config.multiSelect {
+		return nil, ErrTimeout
+	}
+	return confirm(choices, selectedChoice, config)
+}
+
+// questionWithCountdown returns the question with the number of seconds left, rounded up,
+// appended to its first line
+func questionWithCountdown(question string, remaining time.Duration) string {
+	seconds := int((remaining + time.Second - 1) / time.Second)
+	if seconds < 0 {
+		seconds = 0
+	}
+	questionLines := strings.SplitN(question, "\n", 2)
+	questionLines[0] += fmt.Sprintf(" (%ds)", seconds)
+	return strings.Join(questionLines, "\n")
+}
+
+// userInteracted reports whether the event was caused by the user pressing a key or a mouse button
+func userInteracted(ev tcell.Event) bool {
+	switch ev := ev.(type) {
+	case *tcell.EventKey:
+		return true
+	case *tcell.EventMouse:
+		return ev.Buttons() != tcell.ButtonNone
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package gochoice
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickWithTimeoutReturnsFallbackIndex(t *testing.T) {
+	config := defaultConfig
+	OptionTimeout(20*time.Millisecond, 2)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	choice, index, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "C" || index != 2 {
+		t.Errorf("expected C at index 2, got %s at index %d", choice, index)
+	}
+}
+
+func TestPickWithTimeoutReturnsCurrentSelection(t *testing.T) {
+	config := defaultConfig
+	OptionDefaultIndex(1)(&config)
+	OptionTimeout(20*time.Millisecond, -1)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	choice, _, err := pick("question", []string{"A", "B", "C"}, screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if choice != "B" {
+		t.Error("expected B, got", choice)
+	}
+}
+
+func TestPickWithTimeoutAndNoChoiceSelected(t *testing.T) {
+	config := defaultConfig
+	OptionInitialQuery("z")(&config)
+	OptionTimeout(20*time.Millisecond, -1)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	_, _, err = pick("question", []string{"A", "B", "C"}, screen, &config)
+	if !errors.Is(err, ErrTimeout) {
+		t.Error("expected ErrTimeout, got", err)
+	}
+	if !errors.Is(err, ErrNoChoiceSelected) {
+		t.Error("expected ErrTimeout to match ErrNoChoiceSelected")
+	}
+}
+
+func TestPickWithTimeoutStoppedByKey(t *testing.T) {
+	config := defaultConfig
+	OptionTimeout(20*time.Millisecond, 0)(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	_, _, err = pickContext(ctx, "question", []string{"A", "B", "C"}, screen, &config)
+	if !errors.Is(err, ErrContextCanceled) {
+		t.Error("expected ErrContextCanceled, got", err)
+	}
+}
+
+func TestQuestionWithCountdown(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		question         string
+		remaining        time.Duration
+		expectedQuestion string
+	}{
+		{name: "single-line", question: "question", remaining: 5 * time.Second, expectedQuestion: "question (5s)"},
+		{name: "rounded-up", question: "question", remaining: 1500 * time.Millisecond, expectedQuestion: "question (2s)"},
+		{name: "multiple-lines", question: "question\nPick:", remaining: time.Second, expectedQuestion: "question (1s)\nPick:"},
+		{name: "elapsed", question: "question", remaining: -time.Second, expectedQuestion: "question (0s)"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if question := questionWithCountdown(scenario.question, scenario.remaining); question != scenario.expectedQuestion {
+				t.Errorf("expected %q, got %q", scenario.expectedQuestion, question)
+			}
+		})
+	}
+}
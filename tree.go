@@ -0,0 +1,132 @@
+package gochoice
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+// treeIndentation is the text displayed before a node of a tree for each of its ancestors
+const treeIndentation = "  "
+
+// TreeNode is a node of the tree picked from with PickTree.
+// Nodes with children are branches, which can be expanded and collapsed, while nodes without children are leaves.
+type TreeNode struct {
+	// Label is the text displayed for the node
+	Label string
+
+	// Children are the nodes under this node
+	Children []*TreeNode
+
+	// Expanded determines whether the children of the node are displayed when the prompt opens
+	Expanded bool
+
+	// Data is an optional value associated with the node
+	Data any
+}
+
+// PickTree prompts the user to choose a leaf among the descendants of the root node, which isn't displayed.
+// Branches are expanded with the right arrow key and collapsed with the left arrow key, while the enter key
+// toggles them. The search query matches nodes at any depth, regardless of whether their ancestors are expanded.
+// It returns the leaf selected as well as the labels of the nodes leading to it, starting from a child of the root.
+func PickTree(question string, root *TreeNode, options ...Option) (*TreeNode, []string, error) {
+	config := newConfig(options)
+	config.tree = true
+	return toTreeNodeAndPath(runPicker(context.Background(), question, newChoicesFromTree(root), config))
+}
+
+func pickTree(question string, root *TreeNode, screen tcell.Screen, config *Config) (*TreeNode, []string, error) {
+	config.tree = true
+	return toTreeNodeAndPath(pickChoices(context.Background(), question, newChoicesFromTree(root), screen, config))
+}
+
+// newChoicesFromTree creates a choice for each descendant of the root node, in depth-first order
+func newChoicesFromTree(root *TreeNode) []*Choice {
+	var choices []*Choice
+	var addChoices func(nodes []*TreeNode, parent *Choice, depth int)
+	addChoices = func(nodes []*TreeNode, parent *Choice, depth int) {
+		for _, node := range nodes {
+			choice := &Choice{
+				Id:       len(choices),
+				Value:    node.Label,
+				Data:     node,
+				parent:   parent,
+				depth:    depth,
+				branch:   len(node.Children) > 0,
+				expanded: node.Expanded,
+			}
+			choices = append(choices, choice)
+			addChoices(node.Children, choice, depth+1)
+		}
+	}
+	if root != nil {
+		addChoices(root.Children, nil, 0)
+	}
+	return choices
+}
+
+// toTreeNodeAndPath returns the node of the first choice selected and the labels of the nodes leading to it
+func toTreeNodeAndPath(selectedChoices []*Choice, err error) (*TreeNode, []string, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	var path []string
+	for choice := selectedChoices[0]; choice != nil; choice = choice.parent {
+		path = append([]string{choice.Value}, path...)
+	}
+	return selectedChoices[0].Data.(*TreeNode), path, nil
+}
+
+// filterTree marks every choice that is neither displayed because all of its ancestors are expanded
+// nor, if there is a search query, matching it or an ancestor of a choice matching it as hidden.
+// It returns the remaining choices in their original order. Hidden choices are deselected.
+func filterTree(choices []*Choice, searchQuery string, config *Config, state *promptState) []*Choice {
+	for _, choice := range choices {
+		matched, score, positions := matchChoiceAndDescription(choice, searchQuery, config, state)
+		choice.hidden = !matched
+		choice.score = score
+		choice.matchedPositions = positions
+	}
+	for _, choice := range choices {
+		if len(searchQuery) == 0 {
+			choice.hidden = !choice.ancestorsExpanded()
+		} else if !choice.hidden {
+			for ancestor := choice.parent; ancestor != nil; ancestor = ancestor.parent {
+				ancestor.hidden = false
+			}
+		}
+	}
+	visibleChoices := make([]*Choice, 0, len(choices))
+	for _, choice := range choices {
+		if choice.hidden {
+			choice.Selected = false
+		} else {
+			visibleChoices = append(visibleChoices, choice)
+		}
+	}
+	return visibleChoices
+}
+
+// ancestorsExpanded reports whether all ancestors of the choice are expanded
+func (choice *Choice) ancestorsExpanded() bool {
+	for ancestor := choice.parent; ancestor != nil; ancestor = ancestor.parent {
+		if !ancestor.expanded {
+			return false
+		}
+	}
+	return true
+}
+
+// treePrefix returns the indentation and the marker displayed before the value of a choice of a tree
+func treePrefix(choice *Choice) string {
+	marker := "  "
+	if choice.branch {
+		if choice.expanded {
+			marker = "▾ "
+		} else {
+			marker = "▸ "
+		}
+	}
+	return strings.Repeat(treeIndentation, choice.depth) + marker
+}
@@ -0,0 +1,115 @@
+package gochoice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func newTestTree() *TreeNode {
+	return &TreeNode{Children: []*TreeNode{
+		{Label: "default", Children: []*TreeNode{{Label: "api"}, {Label: "worker"}}},
+		{Label: "kube-system", Children: []*TreeNode{{Label: "coredns"}, {Label: "etcd"}}},
+		{Label: "README"},
+	}}
+}
+
+func TestPickTree(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	SimulateKeys(screen, []tcell.Event{
+		// Skip over the collapsed "default" branch and expand "kube-system"
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone),
+		// Move to its first child, then to the second one, and pick it
+		tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyRight, 0, tcell.ModNone),
+	})
+	node, path, err := pickTree("question", newTestTree(), screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if node.Label != "etcd" {
+		t.Error("expected etcd, got", node.Label)
+	}
+	if strings.Join(path, "/") != "kube-system/etcd" {
+		t.Error("expected kube-system/etcd, got", path)
+	}
+}
+
+func TestPickTreeCollapse(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	SimulateKeys(screen, []tcell.Event{
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		// Go back to the parent of "api", collapse it and move to the next branch
+		tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyLeft, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone),
+		tcell.NewEventKey(tcell.KeyEnter, 0, tcell.ModNone),
+	})
+	node, _, err := pickTree("question", newTestTree(), screen, &config)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if node.Label != "README" {
+		t.Error("expected README, got", node.Label)
+	}
+}
+
+func TestPickTreeAbortFromTopLevel(t *testing.T) {
+	config := defaultConfig
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.InjectKey(tcell.KeyLeft, 0, tcell.ModNone)
+	if _, _, err = pickTree("question", newTestTree(), screen, &config); !errors.Is(err, ErrAborted) {
+		t.Error("expected ErrAborted, got", err)
+	}
+}
+
+func TestFilterTree(t *testing.T) {
+	config := defaultConfig
+	config.tree = true
+	choices := newChoicesFromTree(newTestTree())
+	if visibleChoices := filterChoices(choices, "", &config, &promptState{}); len(visibleChoices) != 3 {
+		t.Error("expected only the top-level nodes to be visible, got", len(visibleChoices))
+	}
+	visibleChoices := filterChoices(choices, "etc", &config, &promptState{})
+	if len(visibleChoices) != 2 || visibleChoices[0].Value != "kube-system" || visibleChoices[1].Value != "etcd" {
+		t.Error("expected the matching node and its ancestor to be visible, got", visibleChoices)
+	}
+}
+
+func TestPickTreeWithFallback(t *testing.T) {
+	config := defaultConfig
+	config.tree = true
+	output := &bytes.Buffer{}
+	selectedChoices, err := pickWithFallback(context.Background(), "question", newChoicesFromTree(newTestTree()), &config, strings.NewReader("\n4\n"), output)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if selectedChoices[0].Value != "etcd" {
+		t.Error("expected etcd, got", selectedChoices[0].Value)
+	}
+	if !strings.HasPrefix(output.String(), "question\ndefault\n    1) api\n    2) worker\nkube-system\n") {
+		t.Error("expected branches to be printed as headers, got", output.String())
+	}
+}
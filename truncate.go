@@ -0,0 +1,127 @@
+package gochoice
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// TruncatePosition is the part of a choice that is cut off when it is too long to fit in the screen
+type TruncatePosition int
+
+const (
+	// TruncateEnd cuts off the end of the choices that are too long
+	TruncateEnd TruncatePosition = iota
+
+	// TruncateStart cuts off the start of the choices that are too long
+	TruncateStart
+
+	// TruncateMiddle cuts off the middle of the choices that are too long, keeping both their start and their end.
+	// This is especially useful for file paths.
+	TruncateMiddle
+)
+
+// truncation describes the runes of a text that were replaced by the ellipsis
+type truncation struct {
+	start, end     int
+	ellipsisLength int
+}
+
+// shiftPositions returns the positions of the given runes in the truncated text.
+// Positions of runes that were cut off are dropped.
+func (t truncation) shiftPositions(positions []int) []int {
+	if t.start == t.end {
+		return positions
+	}
+	var shiftedPositions []int
+	for _, position := range positions {
+		if position < t.start {
+			shiftedPositions = append(shiftedPositions, position)
+		} else if position >= t.end {
+			shiftedPositions = append(shiftedPositions, position-(t.end-t.start)+t.ellipsisLength)
+		}
+	}
+	return shiftedPositions
+}
+
+// shiftStyles returns the styles of the runes of the truncated text, given those of the runes of the original text.
+// The runes of the ellipsis have no style of their own.
+func (t truncation) shiftStyles(styles []tcell.Style) []tcell.Style {
+	if t.start == t.end || t.start > len(styles) {
+		return styles
+	}
+	shiftedStyles := append([]tcell.Style{}, styles[:t.start]...)
+	for i := 0; i < t.ellipsisLength; i++ {
+		shiftedStyles = append(shiftedStyles, tcell.StyleDefault)
+	}
+	if t.end < len(styles) {
+		shiftedStyles = append(shiftedStyles, styles[t.end:]...)
+	}
+	return shiftedStyles
+}
+
+// truncateText replaces part of the text by the ellipsis so that it fits in the given width,
+// and returns the truncated text along with which runes were replaced
+func truncateText(text string, width int, position TruncatePosition, ellipsis string) (string, truncation) {
+	if runewidth.StringWidth(text) <= width {
+		return text, truncation{}
+	}
+	available := width - runewidth.StringWidth(ellipsis)
+	if available < 0 {
+		available = 0
+	}
+	// Split the text into characters, as a single character may consist of several runes
+	var characters []string
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		characters = append(characters, graphemes.Str())
+	}
+	var head, tail string
+	switch position {
+	case TruncateStart:
+		tail = takeCharacters(characters, available, true)
+	case TruncateMiddle:
+		head = takeCharacters(characters, available-available/2, false)
+		tail = takeCharacters(characters, available-runewidth.StringWidth(head), true)
+	default:
+		head = takeCharacters(characters, available, false)
+	}
+	runes := len([]rune(text))
+	return head + ellipsis + tail, truncation{
+		start:          len([]rune(head)),
+		end:            runes - len([]rune(tail)),
+		ellipsisLength: len([]rune(ellipsis)),
+	}
+}
+
+// takeCharacters returns as many characters as fit in the given width, from the start or from the end
+func takeCharacters(characters []string, width int, fromEnd bool) string {
+	text, textWidth := "", 0
+	for i := range characters {
+		character := characters[i]
+		if fromEnd {
+			character = characters[len(characters)-1-i]
+		}
+		characterWidth := runewidth.StringWidth(character)
+		if textWidth+characterWidth > width {
+			break
+		}
+		textWidth += characterWidth
+		if fromEnd {
+			text = character + text
+		} else {
+			text += character
+		}
+	}
+	return text
+}
+
+// OptionTruncate cuts off the part of the choices at the given position when they are too long to fit in the screen,
+// and replaces it by the given ellipsis, e.g. "…". It has no effect on choices displayed with OptionWrap.
+func OptionTruncate(position TruncatePosition, ellipsis string) func(config *Config) {
+	return func(config *Config) {
+		config.Truncate = true
+		config.TruncatePosition = position
+		config.Ellipsis = ellipsis
+	}
+}
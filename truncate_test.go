@@ -0,0 +1,60 @@
+package gochoice
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTruncateText(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		text              string
+		width             int
+		position          TruncatePosition
+		expectedText      string
+		positions         []int
+		expectedPositions []int
+	}{
+		{name: "fits", text: "hello", width: 5, position: TruncateEnd, expectedText: "hello", positions: []int{0, 4}, expectedPositions: []int{0, 4}},
+		{name: "end", text: "hello world", width: 6, position: TruncateEnd, expectedText: "hello…", positions: []int{0, 6, 10}, expectedPositions: []int{0}},
+		{name: "start", text: "hello world", width: 6, position: TruncateStart, expectedText: "…world", positions: []int{0, 6, 10}, expectedPositions: []int{1, 5}},
+		{name: "middle", text: "/home/user/projects/main.go", width: 13, position: TruncateMiddle, expectedText: "/home/…ain.go", positions: []int{1, 10, 26}, expectedPositions: []int{1, 12}},
+		{name: "wide-characters", text: "日本語です", width: 7, position: TruncateEnd, expectedText: "日本語…"},
+		{name: "too-narrow", text: "hello", width: 0, position: TruncateEnd, expectedText: "…"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			text, textTruncation := truncateText(scenario.text, scenario.width, scenario.position, "…")
+			if text != scenario.expectedText {
+				t.Errorf("expected %q, got %q", scenario.expectedText, text)
+			}
+			if positions := textTruncation.shiftPositions(scenario.positions); !reflect.DeepEqual(positions, scenario.expectedPositions) {
+				t.Errorf("expected positions %v, got %v", scenario.expectedPositions, positions)
+			}
+		})
+	}
+}
+
+func TestRenderWithTruncate(t *testing.T) {
+	config := defaultConfig
+	OptionTruncate(TruncateMiddle, "...")(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(17, 4)
+	choices := newChoices([]string{"/var/log/nginx/access.log"})
+	render(screen, "question", choices, &config, &promptState{}, choices[0], "", true, 0, choices)
+	screen.Show()
+	var line []rune
+	for x := 0; x < 17; x++ {
+		mainc, _, _, _ := screen.GetContent(x, 1)
+		line = append(line, mainc)
+	}
+	// The last column is left for the scrollbar, so the value is truncated to 13 columns
+	if text := strings.TrimSpace(string(line)); text != "> /var/...s.log" {
+		t.Errorf("expected the middle of the value to be cut off, got %q", text)
+	}
+}
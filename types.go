@@ -1,6 +1,11 @@
 package gochoice
 
 import (
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/gdamore/tcell/v2"
 )
 
@@ -8,15 +13,199 @@ type Choice struct {
 	Id       int
 	Value    string
 	Selected bool
+	Checked  bool
+	// Description is an optional secondary text displayed next to the value
+	Description string
+	// Disabled choices are displayed, but cannot be selected
+	Disabled bool
+	// Data is the original item the choice was created from, if any
+	Data any
+	// Style is applied to the value of the choice on top of Theme.Item, unless the choice is selected or disabled
+	Style tcell.Style
+	// Hotkey picks the choice when pressed with Alt, or alone when the search query isn't being typed
+	Hotkey rune
+	// Icon is displayed before the value
+	Icon Icon
+	// URL is the target of the hyperlink the value is displayed as, on terminals supporting hyperlinks.
+	// URLs containing control characters are ignored.
+	URL string
+	// Dangerous choices must be confirmed by pressing the Confirm key again once they have been picked
+	Dangerous bool
+	// ansiStyles are the styles of the runes of the value set by the escape sequences removed from it with OptionANSI
+	ansiStyles []tcell.Style
 
 	hidden bool
+	// duplicate is true for the choices that aren't displayed because a previous choice has the same value,
+	// whose indices include their index, with OptionDedupe
+	duplicate bool
+	indices   []int
+	// filtered is true for the choices that don't match the search query, but are displayed with OptionDimFiltered
+	filtered bool
+	header   bool
+	// separator is true for the headers displayed as a horizontal rule
+	separator        bool
+	score            int
+	matchedPositions []int
+	// custom is true for the choice created from the search query with OptionAllowCustom
+	custom bool
+	// value is the value of the item the choice was created from, which may differ from the text displayed
+	value string
+	// shortcut is the number of the key picking the choice with OptionNumberShortcuts, or 0 if it has none
+	shortcut int
+	// checkOrder increases with the time the choice was checked, so that the oldest checked choice can be unchecked
+	checkOrder int
+	// position is the index of the choice in the choices displayed the last time they were filtered, so that
+	// the selected choice can be found without going through all of them
+	position int
+	// ordinal is the number of the choice among the choices displayed that can be picked, from 1
+	ordinal int
+	// The following fields are only used by the choices of a tree
+	parent   *Choice
+	depth    int
+	branch   bool
+	expanded bool
+}
+
+// selectable reports whether the choice can be selected
+func (choice *Choice) selectable() bool {
+	return !choice.hidden && !choice.duplicate && !choice.filtered && !choice.Disabled && !choice.header
+}
+
+// counted reports whether the choice is counted among the choices that can be picked, leaving out the headers,
+// the choice created from the search query and the choices displayed even though they don't match it
+func (choice *Choice) counted() bool {
+	return !choice.header && !choice.custom && !choice.filtered
 }
 
 type Config struct {
-	TextColor         tcell.Color
-	BackgroundColor   tcell.Color
-	SelectedTextColor tcell.Color
-	SelectedTextBold  bool
+	Theme                Theme
+	DefaultIndex         int
+	DefaultValue         string
+	FuzzySearch          bool
+	SearchDescriptions   bool
+	SearchCase           SearchCase
+	RegexSearch          bool
+	ExtendedSearch       bool
+	UnicodeNormalization bool
+	Matcher              Matcher
+	InitialQuery         string
+	CustomLabel          string
+	EmptyMessage         string
+	LoadingMessage       string
+	KeyMap               KeyMap
+	VimBindings          bool
+	Mouse                bool
+	ItemRenderer         ItemRenderer
+	Fallback             FallbackMode
+	Timeout              time.Duration
+	TimeoutIndex         int
+	TimeoutCountdown     bool
+	Placeholder          string
+	Mask                 rune
+	Preview              func(value string, index int) string
+	PreviewPosition      PreviewPosition
+	OnChange             func(value string, index int)
+	Header               string
+	Footer               string
+	KeyHints             bool
+	Wrap                 bool
+	Truncate             bool
+	TruncatePosition     TruncatePosition
+	Ellipsis             string
+	State                *PickerState
+	MRU                  MRUStore
+	Sort                 func(a, b string) bool
+	Dedupe               bool
+	Validator            func(value string, index int) error
+	Breadcrumbs          []string
+	MinSelections        int
+	MaxSelections        int
+	EvictOldestSelection bool
+	Bell                 bool
+	SignalHandling       bool
+	Suspend              bool
+	InlineHeight         int
+	EchoResult           io.Writer
+	ItemStyle            func(index int, value string) tcell.Style
+	Cursor               string
+	UnselectedPrefix     string
+	PaddingTop           int
+	PaddingLeft          int
+	Centered             bool
+	Border               BorderStyle
+	BorderTitle          string
+	QuestionAlignment    []Alignment
+	StatusLine           bool
+	NumberShortcuts      bool
+	WithoutSearch        bool
+	WrapAround           bool
+	PageSize             int
+	ScrollOffset         int
+	PositionIndicator    bool
+	LineNumbers          bool
+	Grid                 bool
+	Columns              int
+	ANSI                 bool
+	SearchBarPosition    SearchBarPosition
+	SearchPrompt         string
+	SearchPlaceholder    string
+	DimFiltered          bool
+	SelectOnUniqueMatch  bool
+	AutoPickSingle       bool
+	EnvOverrides         bool
+	Accessible           bool
+	AutoTheme            bool
+
+	multiSelect bool
+	secret      bool
+	backAllowed bool
+	tree        bool
+	// submenu is true for the submenus of PickMenu, from which the keys bound to KeyMap.Back go back to the parent menu
+	submenu bool
+	// columnHeader is displayed between the question and the choices
+	columnHeader string
+	// updates are applied to the choices while the prompt is open, until the channel is closed
+	updates <-chan choiceUpdate
+	// status returns the text displayed on the status line, unless an action was just refused
+	status func() string
+	// loading is true if the updates add choices that are still being loaded
+	loading bool
+	// tty is the terminal the screen is displayed on, to which the escape sequences tcell can't write are written,
+	// or nil if the screen wasn't created by the prompt or isn't displayed on a tcell.Tty
+	tty io.Writer
+	// onClose is called with the search query once the prompt is closed
+	onClose func(searchQuery string)
+	// noColor is true if the NO_COLOR environment variable is set, in which case the colors of all styles are removed
+	noColor bool
+}
+
+// promptState is what is computed while a prompt is open and must not outlive it, unlike the Config,
+// which may be used for several prompts
+type promptState struct {
+	// lineNumberWidth is the number of columns of the line numbers displayed with OptionLineNumbers
+	lineNumberWidth int
+	// icons is true if any of the choices has an icon displayed with the IconMode of the theme
+	icons bool
+	// horizontalOffset is the number of columns by which the choices are scrolled horizontally
+	horizontalOffset int
+	// spinnerFrame is the number of the frame of the spinner displayed while the choices are loading
+	spinnerFrame int
+	// filter filters the choices displayed, and may still be running
+	filter *filterJob
+	// choiceCount is the number of countedChoices that can be picked, which are only counted again once they change
+	countedChoices []*Choice
+	choiceCount    int
+	// checks keeps track of the checked choices, so that they are only gone through again once the choices change
+	checks *checkedState
+	// filterHistory is used to filter the choices incrementally while the search query is typed
+	filterHistory *filterHistory
+	// searchRegexp is the last search query compiled with OptionRegexSearch
+	searchRegexp *compiledSearchQuery
+	// loading is true until there are no more updates to the choices loaded by PickFromChannel or PickWithLoader
+	loading bool
+	// reverseSelected is true if the selected choice is displayed in reverse video, as it would otherwise look like
+	// the others with the colors supported by the terminal
+	reverseSelected bool
 }
 
 type Color int
@@ -48,7 +237,45 @@ const (
 	White
 )
 
+const (
+	// colorRGBFlag marks colors created from RGB values, which are stored in the lower 24 bits
+	colorRGBFlag Color = 1 << 24
+
+	// colorDefault is the default color of the terminal
+	colorDefault Color = -1
+)
+
+// ColorRGB returns the color made of the given red, green and blue components.
+// On terminals that don't support true colors, the closest color available is used instead.
+func ColorRGB(r, g, b uint8) Color {
+	return colorRGBFlag | Color(r)<<16 | Color(g)<<8 | Color(b)
+}
+
+// ColorHex returns the color represented by the given hexadecimal string, e.g. "#ff8800" or "#f80".
+// If the string isn't a valid hexadecimal color, the default color of the terminal is used.
+// On terminals that don't support true colors, the closest color available is used instead.
+func ColorHex(hex string) Color {
+	hex = strings.TrimPrefix(hex, "#")
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	if len(hex) != 6 {
+		return colorDefault
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return colorDefault
+	}
+	return colorRGBFlag | Color(value)
+}
+
 func (c Color) toTcellColor() tcell.Color {
+	if c == colorDefault {
+		return tcell.ColorDefault
+	}
+	if c&colorRGBFlag != 0 {
+		return tcell.NewHexColor(int32(c &^ colorRGBFlag))
+	}
 	switch c {
 	case Black:
 		return tcell.ColorBlack
@@ -101,24 +328,165 @@ type Option func(config *Config)
 
 func OptionTextColor(color Color) func(config *Config) {
 	return func(config *Config) {
-		config.TextColor = color.toTcellColor()
+		theme := &config.Theme
+		theme.Question = theme.Question.Foreground(color.toTcellColor())
+		theme.Item = theme.Item.Foreground(color.toTcellColor())
+		theme.SearchBar = theme.SearchBar.Foreground(color.toTcellColor())
+		theme.Scrollbar = theme.Scrollbar.Foreground(color.toTcellColor())
+		theme.Preview = theme.Preview.Foreground(color.toTcellColor())
+		theme.HeaderBar = theme.HeaderBar.Foreground(color.toTcellColor())
 	}
 }
 
 func OptionBackgroundColor(color Color) func(config *Config) {
 	return func(config *Config) {
-		config.BackgroundColor = color.toTcellColor()
+		theme := &config.Theme
+		for _, style := range theme.styles() {
+			*style = style.Background(color.toTcellColor())
+		}
 	}
 }
 
 func OptionSelectedTextColor(color Color) func(config *Config) {
 	return func(config *Config) {
-		config.SelectedTextColor = color.toTcellColor()
+		config.Theme.Selected = config.Theme.Selected.Foreground(color.toTcellColor())
 	}
 }
 
+// OptionSelectedTextBold makes the selected choice bold. Other attributes can be set with OptionSelectedStyle.
 func OptionSelectedTextBold() func(config *Config) {
+	return OptionSelectedStyle(Style{Bold: true})
+}
+
+// OptionMatchTextColor sets the color of the characters matching the search query
+func OptionMatchTextColor(color Color) func(config *Config) {
+	return func(config *Config) {
+		config.Theme.Match = config.Theme.Match.Foreground(color.toTcellColor())
+	}
+}
+
+// OptionMatchTextBold makes the characters matching the search query bold
+func OptionMatchTextBold() func(config *Config) {
+	return func(config *Config) {
+		config.Theme.Match = config.Theme.Match.Bold(true)
+	}
+}
+
+// OptionDescriptionColor sets the color of the descriptions displayed next to choices
+func OptionDescriptionColor(color Color) func(config *Config) {
+	return func(config *Config) {
+		config.Theme.Description = config.Theme.Description.Foreground(color.toTcellColor())
+	}
+}
+
+// OptionDisabledTextColor sets the color of the choices that cannot be selected
+func OptionDisabledTextColor(color Color) func(config *Config) {
+	return func(config *Config) {
+		config.Theme.Disabled = config.Theme.Disabled.Foreground(color.toTcellColor())
+	}
+}
+
+// OptionHeaderTextColor sets the color of the headers of the groups used with PickGrouped
+func OptionHeaderTextColor(color Color) func(config *Config) {
+	return func(config *Config) {
+		config.Theme.Header = config.Theme.Header.Foreground(color.toTcellColor())
+	}
+}
+
+// OptionDefaultIndex sets the index of the choice that is selected when the prompt opens
+func OptionDefaultIndex(index int) func(config *Config) {
+	return func(config *Config) {
+		config.DefaultIndex = index
+	}
+}
+
+// OptionDefaultValue sets the value of the choice that is selected when the prompt opens.
+// With PickRich and PickGrouped, it is either the Value or the Label of an item.
+// If no choice has that value, OptionDefaultIndex is used instead.
+// With Input, it sets the text that is already typed when the prompt opens.
+func OptionDefaultValue(value string) func(config *Config) {
+	return func(config *Config) {
+		config.DefaultValue = value
+	}
+}
+
+// OptionAutoPickSingle makes Pick return the choice right away, without displaying the prompt, when there is only
+// one choice that can be picked. It has no effect on prompts allowing several choices to be selected, nor on those
+// whose choices are loaded while they are displayed. The prompt is displayed anyway if the validator set by
+// OptionValidator rejects the choice, or if the choice is dangerous, so that it is confirmed.
+func OptionAutoPickSingle() func(config *Config) {
+	return func(config *Config) {
+		config.AutoPickSingle = true
+	}
+}
+
+// OptionFuzzySearch replaces the default substring search by a fuzzy search, which matches choices
+// containing all characters of the search query in the same order and ranks them by relevance
+func OptionFuzzySearch() func(config *Config) {
+	return func(config *Config) {
+		config.FuzzySearch = true
+	}
+}
+
+// OptionWrapAround makes moving down from the last choice select the first choice, and moving up from the first
+// choice select the last choice, instead of stopping at the edges of the list
+func OptionWrapAround() func(config *Config) {
+	return func(config *Config) {
+		config.WrapAround = true
+	}
+}
+
+// OptionPageSize displays at most the given number of choices at once, even if there is room for more of them.
+// It also sets how far the keys of KeyMap.PageUp and KeyMap.PageDown move the selection.
+func OptionPageSize(pageSize int) func(config *Config) {
+	return func(config *Config) {
+		config.PageSize = pageSize
+	}
+}
+
+// OptionScrollOffset keeps the given number of choices visible above and below the selected choice while scrolling,
+// like the scrolloff option of vim, so that the user can see what comes next
+func OptionScrollOffset(lines int) func(config *Config) {
+	return func(config *Config) {
+		config.ScrollOffset = lines
+	}
+}
+
+// OptionMouse enables mouse support: clicking a choice selects it, double-clicking it confirms it,
+// and the mouse wheel moves the selection
+func OptionMouse() func(config *Config) {
+	return func(config *Config) {
+		config.Mouse = true
+	}
+}
+
+// OptionSearchDescriptions makes the search query match the description of choices in addition to their value
+func OptionSearchDescriptions() func(config *Config) {
+	return func(config *Config) {
+		config.SearchDescriptions = true
+	}
+}
+
+// OptionOnChange sets a function called with the value and the index of the selected choice whenever the
+// selection changes, including when the prompt opens. It is called by the event loop, so it should return quickly.
+func OptionOnChange(onChange func(value string, index int)) func(config *Config) {
+	return func(config *Config) {
+		config.OnChange = onChange
+	}
+}
+
+// OptionHeader sets text displayed below the question, e.g. to give more context about the choices.
+// It may span several lines, and its style is Theme.HeaderBar.
+func OptionHeader(header string) func(config *Config) {
+	return func(config *Config) {
+		config.Header = header
+	}
+}
+
+// OptionFooter sets text displayed above the search bar, e.g. "↑/↓ move · enter select · esc cancel".
+// It may span several lines, and its style is Theme.FooterBar.
+func OptionFooter(footer string) func(config *Config) {
 	return func(config *Config) {
-		config.SelectedTextBold = true
+		config.Footer = footer
 	}
 }
@@ -0,0 +1,39 @@
+package gochoice
+
+import (
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestColorToTcellColor(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		color         Color
+		expectedColor tcell.Color
+	}{
+		{name: "named", color: Red, expectedColor: tcell.ColorRed},
+		{name: "rgb", color: ColorRGB(255, 136, 0), expectedColor: tcell.NewRGBColor(255, 136, 0)},
+		{name: "rgb-black", color: ColorRGB(0, 0, 0), expectedColor: tcell.NewRGBColor(0, 0, 0)},
+		{name: "hex", color: ColorHex("#ff8800"), expectedColor: tcell.NewRGBColor(255, 136, 0)},
+		{name: "hex-without-hash", color: ColorHex("FF8800"), expectedColor: tcell.NewRGBColor(255, 136, 0)},
+		{name: "hex-short", color: ColorHex("#f80"), expectedColor: tcell.NewRGBColor(255, 136, 0)},
+		{name: "hex-invalid-length", color: ColorHex("#ff88"), expectedColor: tcell.ColorDefault},
+		{name: "hex-invalid-characters", color: ColorHex("#gg8800"), expectedColor: tcell.ColorDefault},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if color := scenario.color.toTcellColor(); color != scenario.expectedColor {
+				t.Errorf("expected %v, got %v", scenario.expectedColor, color)
+			}
+		})
+	}
+}
+
+func TestOptionWithRGBColor(t *testing.T) {
+	config := defaultConfig
+	OptionSelectedTextColor(ColorHex("#50fa7b"))(&config)
+	if fg, _, _ := config.Theme.Selected.Decompose(); fg != tcell.NewRGBColor(0x50, 0xfa, 0x7b) {
+		t.Errorf("expected #50fa7b, got %v", fg)
+	}
+}
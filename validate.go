@@ -0,0 +1,36 @@
+package gochoice
+
+// confirmationMessage returns the message explaining why the selection can't be confirmed, either because the number
+// of checked choices isn't within the limits set by OptionSelectionLimits or because the validator set by
+// OptionValidator rejected one of the choices, or an empty string if it can be confirmed
+func confirmationMessage(choices []*Choice, selectedChoice *Choice, config *Config) string {
+	if message := selectionLimitsMessage(choices, config); len(message) > 0 {
+		return message
+	}
+	if config.Validator == nil {
+		return ""
+	}
+	choicesToValidate := []*Choice{selectedChoice}
+	if config.multiSelect {
+		choicesToValidate = checkedChoices(choices)
+	}
+	for _, choice := range choicesToValidate {
+		if choice == nil {
+			continue
+		}
+		if err := config.Validator(choice.Value, choice.Id); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// OptionValidator checks the choice selected before accepting it. If the validator returns an error, its message is
+// displayed on the status line and the prompt stays open, e.g. to refuse the branch that is already checked out.
+// In the fallback, the message is printed and the number of another choice is read.
+// With PickMultiple, each checked choice is validated. The index of the choice created with OptionAllowCustom is -1.
+func OptionValidator(validator func(value string, index int) error) func(config *Config) {
+	return func(config *Config) {
+		config.Validator = validator
+	}
+}
@@ -0,0 +1,72 @@
+package gochoice
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gdamore/tcell/v2"
+)
+
+func TestPickWithValidator(t *testing.T) {
+	config := defaultConfig
+	OptionValidator(func(value string, index int) error {
+		if value == "main" {
+			return errors.New("You're already on main")
+		}
+		return nil
+	})(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetStyle(config.Theme.background())
+	screen.Show()
+	type result struct {
+		value string
+		index int
+		err   error
+	}
+	results := make(chan result)
+	go func() {
+		value, index, err := pick("question", []string{"main", "feature"}, screen, &config)
+		results <- result{value, index, err}
+	}()
+	// Picking the branch that is already checked out is refused
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	waitForText(t, screen, "You're already on main")
+	screen.InjectKey(tcell.KeyDown, 0, tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	r := <-results
+	if r.err != nil {
+		t.Fatal(r.err.Error())
+	}
+	if r.value != "feature" || r.index != 1 {
+		t.Errorf("expected feature at index 1, got %s at index %d", r.value, r.index)
+	}
+}
+
+func TestConfirmationMessageWithValidator(t *testing.T) {
+	config := defaultConfig
+	config.multiSelect = true
+	var validatedIndices []int
+	OptionValidator(func(value string, index int) error {
+		validatedIndices = append(validatedIndices, index)
+		if value == "c" {
+			return errors.New("c can't be picked")
+		}
+		return nil
+	})(&config)
+	choices := newChoices([]string{"a", "b", "c"})
+	choices[0].Checked, choices[1].Checked = true, true
+	if message := confirmationMessage(choices, choices[0], &config); len(message) > 0 {
+		t.Error("expected no message, got", message)
+	}
+	if len(validatedIndices) != 2 {
+		t.Errorf("expected each checked choice to be validated, got %v", validatedIndices)
+	}
+	choices[2].Checked = true
+	if message := confirmationMessage(choices, choices[0], &config); message != "c can't be picked" {
+		t.Error("expected the error of the validator, got", message)
+	}
+}
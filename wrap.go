@@ -0,0 +1,165 @@
+package gochoice
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/rivo/uniseg"
+)
+
+// wrapText splits the text into lines that fit in the given width, breaking them after a space when possible.
+// No characters are removed, so the lines joined together are the text.
+func wrapText(text string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	var lines []string
+	line, lineWidth := "", 0
+	// The index in the line that follows its last space, or 0 if it has none
+	lastSpaceEnd := 0
+	graphemes := uniseg.NewGraphemes(text)
+	for graphemes.Next() {
+		character := graphemes.Str()
+		characterWidth := runewidth.StringWidth(character)
+		if lineWidth+characterWidth > width && len(line) > 0 {
+			if lastSpaceEnd > 0 && lastSpaceEnd < len(line) {
+				// Move the word being cut off to the next line
+				lines = append(lines, line[:lastSpaceEnd])
+				line = line[lastSpaceEnd:]
+			} else {
+				lines = append(lines, line)
+				line = ""
+			}
+			lineWidth, lastSpaceEnd = runewidth.StringWidth(line), 0
+		}
+		line += character
+		lineWidth += characterWidth
+		if character == " " {
+			lastSpaceEnd = len(line)
+		}
+	}
+	return append(lines, line)
+}
+
+// wrappedChoiceText returns the text of the choice when it is wrapped, which is its value followed by its description,
+// and the index of the first rune of the description, or -1 if it has none
+func wrappedChoiceText(choice *Choice, config *Config) (string, int) {
+	if choice.custom {
+		return customChoiceText(choice, config), -1
+	}
+	if len(choice.Description) == 0 {
+		return choice.Value, -1
+	}
+	return choice.Value + descriptionSeparator + choice.Description, len([]rune(choice.Value + descriptionSeparator))
+}
+
+// computeOptionHeight returns the number of lines on which the option is displayed with the given width
+func computeOptionHeight(option *Choice, width int, config *Config, state *promptState) int {
+	if !config.Wrap || option.header || config.ItemRenderer != nil {
+		return 1
+	}
+	text, _ := wrappedChoiceText(option, config)
+	return len(wrapText(text, width-runewidth.StringWidth(choicePrefix(option, config, state))))
+}
+
+// computeOptionsScrollOffset returns the index of the first option to display so that the option at selectedIndex
+// is visible, taking into account that options may be displayed on several lines when they are wrapped
+func computeOptionsScrollOffset(screen tcell.Screen, question string, options []*Choice, previousScrollOffset, selectedIndex int, config *Config, state *promptState) int {
+	if config.Grid {
+		return computeGridScrollOffset(screen, question, options, previousScrollOffset, selectedIndex, config, state)
+	}
+	pageSize := computePageSize(screen, question, config)
+	if !config.Wrap {
+		return computeScrollOffset(previousScrollOffset, selectedIndex, pageSize, len(options), config.ScrollOffset)
+	}
+	screenWidth, _ := screen.Size()
+	// The last column of the options is left for the scrollbar
+	width := computeOptionsWidth(screenWidth, config) - 1
+	height := func(i int) int {
+		return computeOptionHeight(options[i], width, config, state)
+	}
+	scrollOffset := previousScrollOffset
+	if selectedIndex >= 0 {
+		// Keep the options around the selected option visible, as long as the selected option fits in the page
+		margin := computeScrollMargin(config.ScrollOffset, pageSize)
+		first, last := selectedIndex-margin, selectedIndex+margin
+		if first < 0 {
+			first = 0
+		}
+		if last >= len(options) {
+			last = len(options) - 1
+		}
+		if first < scrollOffset {
+			scrollOffset = first
+		}
+		lines := 0
+		for i := scrollOffset; i <= last; i++ {
+			lines += height(i)
+		}
+		for scrollOffset < selectedIndex && lines > pageSize {
+			lines -= height(scrollOffset)
+			scrollOffset++
+		}
+	}
+	if scrollOffset > len(options) {
+		scrollOffset = len(options)
+	}
+	// Display as many options as possible if the last ones don't fill the page
+	lines := 0
+	for i := scrollOffset; i < len(options) && lines <= pageSize; i++ {
+		lines += height(i)
+	}
+	for scrollOffset > 0 && lines+height(scrollOffset-1) <= pageSize {
+		scrollOffset--
+		lines += height(scrollOffset)
+	}
+	return scrollOffset
+}
+
+// renderWrappedChoice renders the choice on as many lines as needed for its value and its description to fit
+// in the given width, without going past maxLineNumber, and returns the line that follows the choice.
+// The continuation lines are indented so that they are aligned with the first line of the value.
+func renderWrappedChoice(screen tcell.Screen, lineNumber, maxLineNumber int, choice *Choice, prefix string, style tcell.Style, width int, config *Config, choicesByLine []*Choice) int {
+	text, descriptionStart := wrappedChoiceText(choice, config)
+	prefixWidth := runewidth.StringWidth(prefix)
+	start := 0
+	for i, line := range wrapText(text, width-prefixWidth) {
+		if lineNumber >= maxLineNumber {
+			break
+		}
+		linePrefix := prefix
+		if i > 0 {
+			linePrefix = strings.Repeat(" ", prefixWidth)
+		}
+		lineRunes := []rune(line)
+		highlightedPositions := offsetPositions(choice.matchedPositions, -start)
+		switch {
+		case descriptionStart < 0 || descriptionStart >= start+len(lineRunes):
+			printHighlightedText(screen, 0, lineNumber, linePrefix+line, offsetPositions(highlightedPositions, len([]rune(linePrefix))), style, config.Theme.Match)
+		case descriptionStart <= start:
+			printText(screen, 0, lineNumber, linePrefix, style)
+			printHighlightedText(screen, prefixWidth, lineNumber, line, highlightedPositions, config.Theme.Description, config.Theme.Match)
+		default:
+			// The description starts on this line
+			value := linePrefix + string(lineRunes[:descriptionStart-start])
+			printHighlightedText(screen, 0, lineNumber, value, offsetPositions(highlightedPositions, len([]rune(linePrefix))), style, config.Theme.Match)
+			printHighlightedText(screen, runewidth.StringWidth(value), lineNumber, string(lineRunes[descriptionStart-start:]), offsetPositions(highlightedPositions, start-descriptionStart), config.Theme.Description, config.Theme.Match)
+		}
+		if start < len(choice.ansiStyles) {
+			// The styles only cover the value, so they don't apply to the description
+			styleANSI(screen, prefixWidth, lineNumber, line, choice.ansiStyles[start:], style)
+		}
+		choicesByLine[lineNumber] = choice
+		lineNumber++
+		start += len(lineRunes)
+	}
+	return lineNumber
+}
+
+// OptionWrap wraps the choices that are too long to fit in the screen onto several lines instead of cutting them off
+func OptionWrap() func(config *Config) {
+	return func(config *Config) {
+		config.Wrap = true
+	}
+}
@@ -0,0 +1,95 @@
+package gochoice
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		text          string
+		width         int
+		expectedLines []string
+	}{
+		{name: "fits", text: "hello", width: 10, expectedLines: []string{"hello"}},
+		{name: "empty", text: "", width: 10, expectedLines: []string{""}},
+		{name: "break-after-space", text: "hello world", width: 8, expectedLines: []string{"hello ", "world"}},
+		{name: "break-long-word", text: "abcdefghij", width: 4, expectedLines: []string{"abcd", "efgh", "ij"}},
+		{name: "wide-characters", text: "日本語です", width: 5, expectedLines: []string{"日本", "語で", "す"}},
+		{name: "invalid-width", text: "ab", width: 0, expectedLines: []string{"a", "b"}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if lines := wrapText(scenario.text, scenario.width); !reflect.DeepEqual(lines, scenario.expectedLines) {
+				t.Errorf("expected %q, got %q", scenario.expectedLines, lines)
+			}
+		})
+	}
+}
+
+func TestRenderWithWrap(t *testing.T) {
+	config := defaultConfig
+	OptionWrap()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	screen.SetSize(16, 6)
+	choices := newChoices([]string{"a rather long choice", "b"})
+	choices[0].Selected = true
+	choicesByLine := render(screen, "question", choices, &config, &promptState{}, choices[0], "", true, 0, choices)
+	screen.Show()
+	// The last column is left for the scrollbar, so the value is wrapped at 12 columns
+	expectedLines := map[int]string{1: "> a rather", 2: "long choice", 3: "b"}
+	for y, expectedText := range expectedLines {
+		var line []rune
+		for x := 0; x < 16; x++ {
+			mainc, _, _, _ := screen.GetContent(x, y)
+			line = append(line, mainc)
+		}
+		if text := strings.TrimSpace(string(line)); text != expectedText {
+			t.Errorf("expected %q on line %d, got %q", expectedText, y, text)
+		}
+	}
+	// The highlight of the selected choice spans all its lines
+	if _, _, style, _ := screen.GetContent(1, 2); style != config.Theme.Selected {
+		t.Errorf("expected the continuation line to be highlighted, got %v", style)
+	}
+	if choicesByLine[1] != choices[0] || choicesByLine[2] != choices[0] || choicesByLine[3] != choices[1] {
+		t.Error("expected the first choice to be displayed on two lines, got", choicesByLine)
+	}
+}
+
+func TestComputeOptionsScrollOffsetWithWrap(t *testing.T) {
+	config := defaultConfig
+	OptionWrap()(&config)
+	screen, err := createSimulationScreen()
+	if err != nil {
+		t.Fatalf("encountered error while creating simulation screen: %v", err)
+	}
+	defer screen.Fini()
+	// The question and the search bar leave 4 lines for the options, each of which is wrapped on 2 lines
+	screen.SetSize(10, 6)
+	choices := newChoices([]string{"first line", "second line", "third line", "fourth line"})
+	scenarios := []struct {
+		name                 string
+		previousScrollOffset int
+		selectedIndex        int
+		expectedScrollOffset int
+	}{
+		{name: "selected-in-page", previousScrollOffset: 0, selectedIndex: 1, expectedScrollOffset: 0},
+		{name: "selected-below-page", previousScrollOffset: 0, selectedIndex: 2, expectedScrollOffset: 1},
+		{name: "selected-above-page", previousScrollOffset: 2, selectedIndex: 0, expectedScrollOffset: 0},
+		{name: "last-page", previousScrollOffset: 3, selectedIndex: 3, expectedScrollOffset: 2},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scrollOffset := computeOptionsScrollOffset(screen, "question", choices, scenario.previousScrollOffset, scenario.selectedIndex, &config, &promptState{}); scrollOffset != scenario.expectedScrollOffset {
+				t.Errorf("expected %d, got %d", scenario.expectedScrollOffset, scrollOffset)
+			}
+		})
+	}
+}